@@ -0,0 +1,138 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/corp/message/response"
+)
+
+// AccessControl 查询成员所属部门的缓存时间
+const AccessControlCacheTTL = 5 * time.Minute
+
+var _ corp.MessageHandler = (*AccessControl)(nil)
+
+// AccessControl 只允许指定的成员或者指定部门的成员使用后端的 corp.MessageHandler, 其他成员回复 "你没有权限使用这个功能":
+//  mux.MessageHandle(request.MsgTypeText, addresslist.NewAccessControl(adminHandler, clt, []string{"zhangsan"}, []int64{2}))
+//
+//  NOTE: 只检查成员直接所属的部门, 不检查上级部门; 查询成员信息失败的时候按照没有权限处理.
+type AccessControl struct {
+	handler     corp.MessageHandler
+	clt         *Client
+	userIds     map[string]bool
+	departments map[int64]bool
+
+	mutex sync.Mutex
+	cache map[string]accessControlEntry // map[userId]accessControlEntry
+}
+
+type accessControlEntry struct {
+	departments []int64
+	expiresAt   time.Time
+}
+
+// 创建一个新的 AccessControl.
+//  allowedUserIds:       允许的成员 UserID
+//  allowedDepartmentIds: 允许的部门id, 为空时不需要 clt 查询成员的部门, clt 可以为 nil
+func NewAccessControl(handler corp.MessageHandler, clt *Client, allowedUserIds []string, allowedDepartmentIds []int64) *AccessControl {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if clt == nil && len(allowedDepartmentIds) > 0 {
+		panic("nil Client")
+	}
+
+	ac := &AccessControl{
+		handler:     handler,
+		clt:         clt,
+		userIds:     make(map[string]bool, len(allowedUserIds)),
+		departments: make(map[int64]bool, len(allowedDepartmentIds)),
+		cache:       make(map[string]accessControlEntry),
+	}
+	for _, userId := range allowedUserIds {
+		ac.userIds[userId] = true
+	}
+	for _, id := range allowedDepartmentIds {
+		ac.departments[id] = true
+	}
+	return ac
+}
+
+// AccessControl 实现了 corp.MessageHandler 接口.
+func (ac *AccessControl) ServeMessage(w http.ResponseWriter, r *corp.Request) {
+	if r.MixedMsg == nil {
+		return
+	}
+	if ac.allowed(r.MixedMsg.FromUserName) {
+		ac.handler.ServeMessage(w, r)
+		return
+	}
+
+	msg := response.NewText(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, "你没有权限使用这个功能")
+	if err := corp.WriteResponse(w, r, msg); err != nil {
+		corp.LogInfoln("[WECHAT_ACCESS_CONTROL]", err)
+	}
+}
+
+func (ac *AccessControl) allowed(userId string) bool {
+	if userId == "" {
+		return false
+	}
+	if ac.userIds[userId] {
+		return true
+	}
+	if len(ac.departments) == 0 {
+		return false
+	}
+
+	departments, err := ac.userDepartments(userId)
+	if err != nil {
+		corp.LogInfoln("[WECHAT_ACCESS_CONTROL]", err)
+		return false
+	}
+	for _, id := range departments {
+		if ac.departments[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// 获取成员直接所属的部门, 结果缓存 AccessControlCacheTTL.
+func (ac *AccessControl) userDepartments(userId string) (departments []int64, err error) {
+	now := time.Now()
+
+	ac.mutex.Lock()
+	entry, ok := ac.cache[userId]
+	ac.mutex.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.departments, nil
+	}
+
+	info, err := ac.clt.UserInfo(userId)
+	if err != nil {
+		return
+	}
+	departments = info.Department
+
+	ac.mutex.Lock()
+	// 顺便清除失效的缓存, 防止缓存一直增长
+	for key, entry := range ac.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(ac.cache, key)
+		}
+	}
+	ac.cache[userId] = accessControlEntry{
+		departments: departments,
+		expiresAt:   now.Add(AccessControlCacheTTL),
+	}
+	ac.mutex.Unlock()
+	return
+}
@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package dingtalk
+
+import (
+	"github.com/chanxuehong/wechat/corp/addresslist"
+)
+
+// 钉钉的成员, 一般从钉钉通讯录导出.
+type User struct {
+	UserId string // 钉钉的 userid
+	Name   string
+	Mobile string
+	Email  string
+}
+
+// 映射的方式
+const (
+	MappedByMobile = "mobile"
+	MappedByEmail  = "email"
+)
+
+// 映射成功的成员
+type MappedUser struct {
+	DingTalkUser *User
+	WorkUser     *addresslist.UserInfo
+	MappedBy     string // MappedByMobile 或者 MappedByEmail
+}
+
+// Mapper 把钉钉的成员映射到企业微信的成员.
+//  Mapper 创建后是只读的, 并发安全.
+type Mapper struct {
+	byMobile map[string]*addresslist.UserInfo
+	byEmail  map[string]*addresslist.UserInfo
+}
+
+// 用企业微信的成员列表创建 Mapper, 一般是 addresslist.Client.UserList(1, true, 0) 的结果.
+//  创建的时候建立手机号码和邮箱的索引, 之后的查询都是 O(1) 的.
+//  手机号码或者邮箱重复的时候以第一个成员为准; 返回的 *addresslist.UserInfo 指向 workUsers 里的元素.
+func NewMapper(workUsers []addresslist.UserInfo) *Mapper {
+	mapper := &Mapper{
+		byMobile: make(map[string]*addresslist.UserInfo, len(workUsers)),
+		byEmail:  make(map[string]*addresslist.UserInfo, len(workUsers)),
+	}
+	for i := range workUsers {
+		user := &workUsers[i]
+		if mobile := addresslist.NormalizeMobile(user.Mobile); mobile != "" {
+			if _, ok := mapper.byMobile[mobile]; !ok {
+				mapper.byMobile[mobile] = user
+			}
+		}
+		if email := addresslist.NormalizeEmail(user.Email); email != "" {
+			if _, ok := mapper.byEmail[email]; !ok {
+				mapper.byEmail[email] = user
+			}
+		}
+	}
+	return mapper
+}
+
+// 按照手机号码查找企业微信的成员, 参考 addresslist.NormalizeMobile.
+func (mapper *Mapper) MapByMobile(mobile string) (user *addresslist.UserInfo, ok bool) {
+	if mobile = addresslist.NormalizeMobile(mobile); mobile == "" {
+		return
+	}
+	user, ok = mapper.byMobile[mobile]
+	return
+}
+
+// 按照邮箱查找企业微信的成员, 不区分大小写.
+func (mapper *Mapper) MapByEmail(email string) (user *addresslist.UserInfo, ok bool) {
+	if email = addresslist.NormalizeEmail(email); email == "" {
+		return
+	}
+	user, ok = mapper.byEmail[email]
+	return
+}
+
+// 映射 users, 先按照手机号码映射, 失败后再按照邮箱映射.
+//  返回映射成功的成员和映射失败的钉钉 userid 列表, 都保持 users 原来的顺序.
+func (mapper *Mapper) MapAll(users []*User) (mapped []*MappedUser, unmapped []string) {
+	for _, user := range users {
+		if user == nil {
+			continue
+		}
+		if workUser, ok := mapper.MapByMobile(user.Mobile); ok {
+			mapped = append(mapped, &MappedUser{DingTalkUser: user, WorkUser: workUser, MappedBy: MappedByMobile})
+			continue
+		}
+		if workUser, ok := mapper.MapByEmail(user.Email); ok {
+			mapped = append(mapped, &MappedUser{DingTalkUser: user, WorkUser: workUser, MappedBy: MappedByEmail})
+			continue
+		}
+		unmapped = append(unmapped, user.UserId)
+	}
+	return
+}
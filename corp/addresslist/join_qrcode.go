@@ -0,0 +1,53 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 加入企业二维码的尺寸
+const (
+	JoinQRCodeSize171  = 1 // 171 x 171
+	JoinQRCodeSize399  = 2 // 399 x 399
+	JoinQRCodeSize741  = 3 // 741 x 741
+	JoinQRCodeSize2052 = 4 // 2052 x 2052
+)
+
+// 获取加入企业二维码, 成员扫码后可以申请加入企业.
+//
+//  sizeType: 二维码尺寸类型, JoinQRCodeSize171 ~ JoinQRCodeSize2052
+//
+//  NOTE:
+//  1. 返回的是二维码图片的链接, 有效期 7 天;
+//  2. 这个二维码用于成员加入企业(通讯录), 和客户联系里面用于添加外部联系人的「联系我」二维码是不同的接口.
+func (clt *Client) GetJoinQRCode(sizeType int) (qrcodeURL string, err error) {
+	if sizeType < JoinQRCodeSize171 || sizeType > JoinQRCodeSize2052 {
+		err = fmt.Errorf("sizeType 必须在 %d 和 %d 之间, 现在为 %d", JoinQRCodeSize171, JoinQRCodeSize2052, sizeType)
+		return
+	}
+
+	var result struct {
+		corp.Error
+		JoinQRCode string `json:"join_qrcode"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/corp/get_join_qrcode?size_type=" +
+		strconv.Itoa(sizeType) + "&access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	qrcodeURL = result.JoinQRCode
+	return
+}
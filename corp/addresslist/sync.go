@@ -0,0 +1,352 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 组织架构树的一个节点, 对应企业微信的一个部门.
+type OrgNode struct {
+	DeptId   int64      // 必须; 部门id, 新建的部门也用这个id创建, 一般使用 HR 系统里的部门编号
+	DeptName string     // 必须; 部门名称
+	Users    []OrgUser  // 直属于这个部门的成员
+	Children []*OrgNode // 子部门
+}
+
+// 组织架构里的成员, 同一个成员可以出现在多个部门里.
+type OrgUser struct {
+	UserId   string // 必须;  成员UserID
+	Name     string // 必须;  成员名称
+	Position string // 非必须; 职位信息, 为空时不同步
+	Mobile   string // 非必须; 手机号码, 为空时不同步
+	Email    string // 非必须; 邮箱, 为空时不同步
+}
+
+// 需要同步到企业微信的组织架构.
+//  Root.DeptId 对应的部门必须已经存在(一般为根部门 1), 只同步 Root 及其子部门, 其他部门不受影响.
+type OrgChart struct {
+	Root *OrgNode
+}
+
+// 同步失败的操作
+type SyncError struct {
+	Op     string // 操作, 比如 "DepartmentCreate", "UserDelete"
+	Target string // 部门id或者成员UserID
+	Err    error
+}
+
+func (e *SyncError) Error() string {
+	return e.Op + " " + e.Target + ": " + e.Err.Error()
+}
+
+// 同步的结果
+type SyncReport struct {
+	CreatedDepartments []int64
+	UpdatedDepartments []int64
+	DeletedDepartments []int64
+
+	CreatedUsers []string
+	UpdatedUsers []string
+	DeletedUsers []string
+
+	Errors []*SyncError // 部分失败的操作, 失败的操作不影响其他操作继续执行
+}
+
+// 把 desired 同步到企业微信的通讯录, 只做必要的修改.
+//
+//  执行的顺序:
+//  1. 按照从父部门到子部门的顺序创建和更新部门;
+//  2. 创建和更新成员;
+//  3. 删除不在 desired 里的成员, 如果成员还属于同步范围以外的部门, 只从同步范围内的部门移除;
+//  4. 按照从子部门到父部门的顺序删除不在 desired 里的部门.
+//
+//  NOTE: 获取当前的通讯录失败或者 desired 不合法时返回 err, 其他操作失败记录在 report.Errors 里.
+func (clt *Client) Sync(desired *OrgChart) (report *SyncReport, err error) {
+	if desired == nil || desired.Root == nil {
+		err = errors.New("nil OrgChart")
+		return
+	}
+	root := desired.Root
+
+	desiredDepts, desiredUsers, err := flattenOrgChart(root)
+	if err != nil {
+		return
+	}
+
+	currentDepts, err := clt.DepartmentList(root.DeptId)
+	if err != nil {
+		return
+	}
+	currentUsers, err := clt.UserList(root.DeptId, true, 0)
+	if err != nil {
+		return
+	}
+
+	report = &SyncReport{}
+	fail := func(op, target string, e error) {
+		report.Errors = append(report.Errors, &SyncError{Op: op, Target: target, Err: e})
+	}
+
+	currentDeptMap := make(map[int64]Department, len(currentDepts))
+	for _, dept := range currentDepts {
+		currentDeptMap[dept.Id] = dept
+	}
+
+	// 1. 部门, desiredDepts 已经是从父部门到子部门的顺序, 根部门不做修改
+	for _, dept := range desiredDepts[1:] {
+		target := fmt.Sprint(dept.Id)
+		current, ok := currentDeptMap[dept.Id]
+		if !ok {
+			id := dept.Id
+			para := DepartmentCreateParameters{
+				DepartmentName: dept.Name,
+				ParentId:       dept.ParentId,
+				DepartmentId:   &id,
+			}
+			if _, e := clt.DepartmentCreate(&para); e != nil {
+				fail("DepartmentCreate", target, e)
+				continue
+			}
+			report.CreatedDepartments = append(report.CreatedDepartments, dept.Id)
+			continue
+		}
+		if current.Name == dept.Name && current.ParentId == dept.ParentId {
+			continue
+		}
+		parentId := dept.ParentId
+		para := DepartmentUpdateParameters{
+			DepartmentId:   dept.Id,
+			DepartmentName: dept.Name,
+			ParentId:       &parentId,
+		}
+		if e := clt.DepartmentUpdate(&para); e != nil {
+			fail("DepartmentUpdate", target, e)
+			continue
+		}
+		report.UpdatedDepartments = append(report.UpdatedDepartments, dept.Id)
+	}
+
+	// 2. 成员
+	managed := make(map[int64]bool, len(currentDepts)+len(desiredDepts))
+	for _, dept := range currentDepts {
+		managed[dept.Id] = true
+	}
+	for _, dept := range desiredDepts {
+		managed[dept.Id] = true
+	}
+
+	currentUserMap := make(map[string]*UserInfo, len(currentUsers))
+	for i := range currentUsers {
+		currentUserMap[currentUsers[i].Id] = &currentUsers[i]
+	}
+
+	for _, user := range desiredUsers {
+		current := currentUserMap[user.UserId]
+		if current == nil {
+			para := UserCreateParameters{
+				UserId:     user.UserId,
+				Name:       user.Name,
+				Department: user.Departments,
+				Position:   user.Position,
+				Mobile:     user.Mobile,
+				Email:      user.Email,
+			}
+			if e := clt.UserCreate(&para); e != nil {
+				fail("UserCreate", user.UserId, e)
+				continue
+			}
+			report.CreatedUsers = append(report.CreatedUsers, user.UserId)
+			continue
+		}
+
+		// 保留成员在同步范围以外的部门
+		departments := append([]int64(nil), user.Departments...)
+		for _, id := range current.Department {
+			if !managed[id] {
+				departments = append(departments, id)
+			}
+		}
+		if current.Name == user.Name &&
+			(user.Position == "" || current.Position == user.Position) &&
+			(user.Mobile == "" || current.Mobile == user.Mobile) &&
+			(user.Email == "" || current.Email == user.Email) &&
+			sameInt64Set(current.Department, departments) {
+			continue
+		}
+		para := UserUpdateParameters{
+			UserId:     user.UserId,
+			Name:       user.Name,
+			Department: departments,
+			Position:   user.Position,
+			Mobile:     user.Mobile,
+			Email:      user.Email,
+		}
+		if e := clt.UserUpdate(&para); e != nil {
+			fail("UserUpdate", user.UserId, e)
+			continue
+		}
+		report.UpdatedUsers = append(report.UpdatedUsers, user.UserId)
+	}
+
+	// 3. 删除成员
+	desiredUserMap := make(map[string]bool, len(desiredUsers))
+	for _, user := range desiredUsers {
+		desiredUserMap[user.UserId] = true
+	}
+	for i := range currentUsers {
+		current := &currentUsers[i]
+		if desiredUserMap[current.Id] {
+			continue
+		}
+		desiredUserMap[current.Id] = true // UserList 递归获取的时候同一个成员可能出现多次
+
+		var departments []int64
+		for _, id := range current.Department {
+			if !managed[id] {
+				departments = append(departments, id)
+			}
+		}
+		if len(departments) > 0 {
+			para := UserUpdateParameters{
+				UserId:     current.Id,
+				Department: departments,
+			}
+			if e := clt.UserUpdate(&para); e != nil {
+				fail("UserUpdate", current.Id, e)
+				continue
+			}
+			report.UpdatedUsers = append(report.UpdatedUsers, current.Id)
+			continue
+		}
+		if e := clt.UserDelete(current.Id); e != nil {
+			fail("UserDelete", current.Id, e)
+			continue
+		}
+		report.DeletedUsers = append(report.DeletedUsers, current.Id)
+	}
+
+	// 4. 删除部门, 从子部门到父部门
+	desiredDeptMap := make(map[int64]bool, len(desiredDepts))
+	for _, dept := range desiredDepts {
+		desiredDeptMap[dept.Id] = true
+	}
+	var deletes []Department
+	maxDepth := 0
+	depths := make(map[int64]int)
+	for _, dept := range currentDepts {
+		if desiredDeptMap[dept.Id] {
+			continue
+		}
+		deletes = append(deletes, dept)
+
+		// 当前部门到 root 的深度, 限制循环次数防止数据异常的时候死循环
+		n := 0
+		for d, ok := dept, true; ok && d.Id != root.DeptId && n <= len(currentDepts); d, ok = currentDeptMap[d.ParentId] {
+			n++
+		}
+		depths[dept.Id] = n
+		if n > maxDepth {
+			maxDepth = n
+		}
+	}
+	ordered := make([]Department, 0, len(deletes))
+	for n := maxDepth; n >= 0; n-- {
+		for _, dept := range deletes {
+			if depths[dept.Id] == n {
+				ordered = append(ordered, dept)
+			}
+		}
+	}
+	deletes = ordered
+
+	for _, dept := range deletes {
+		if e := clt.DepartmentDelete(dept.Id); e != nil {
+			fail("DepartmentDelete", fmt.Sprint(dept.Id), e)
+			continue
+		}
+		report.DeletedDepartments = append(report.DeletedDepartments, dept.Id)
+	}
+	return
+}
+
+type orgUser struct {
+	OrgUser
+	Departments []int64
+}
+
+// 按照从父部门到子部门的顺序(广度优先)展开 root, 第一个元素是 root.
+// 同一个成员出现在多个部门时合并成一个, 属性以第一次出现的为准.
+func flattenOrgChart(root *OrgNode) (depts []Department, users []*orgUser, err error) {
+	seenDepts := make(map[int64]bool)
+	userMap := make(map[string]*orgUser)
+
+	type item struct {
+		node     *OrgNode
+		parentId int64
+	}
+	queue := []item{{node: root}}
+	for len(queue) > 0 {
+		it := queue[0]
+		queue = queue[1:]
+
+		node := it.node
+		if node == nil {
+			err = errors.New("nil OrgNode")
+			return
+		}
+		if node.DeptId <= 0 {
+			err = fmt.Errorf("invalid DeptId %d of department %q", node.DeptId, node.DeptName)
+			return
+		}
+		if node.DeptName == "" && node != root {
+			err = fmt.Errorf("empty DeptName of department %d", node.DeptId)
+			return
+		}
+		if seenDepts[node.DeptId] {
+			err = fmt.Errorf("duplicate DeptId %d", node.DeptId)
+			return
+		}
+		seenDepts[node.DeptId] = true
+		depts = append(depts, Department{Id: node.DeptId, Name: node.DeptName, ParentId: it.parentId})
+
+		for _, u := range node.Users {
+			if u.UserId == "" {
+				err = fmt.Errorf("empty UserId in department %d", node.DeptId)
+				return
+			}
+			user := userMap[u.UserId]
+			if user == nil {
+				user = &orgUser{OrgUser: u}
+				userMap[u.UserId] = user
+				users = append(users, user)
+			}
+			user.Departments = append(user.Departments, node.DeptId)
+		}
+		for _, child := range node.Children {
+			queue = append(queue, item{node: child, parentId: node.DeptId})
+		}
+	}
+	return
+}
+
+func sameInt64Set(a, b []int64) bool {
+	set := make(map[int64]bool, len(a))
+	for _, x := range a {
+		set[x] = true
+	}
+	for _, x := range b {
+		if !set[x] {
+			return false
+		}
+	}
+	set2 := make(map[int64]bool, len(b))
+	for _, x := range b {
+		set2[x] = true
+	}
+	return len(set) == len(set2)
+}
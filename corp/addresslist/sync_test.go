@@ -0,0 +1,170 @@
+package addresslist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type testAccessTokenServer string
+
+func (srv testAccessTokenServer) Token() (string, error)               { return string(srv), nil }
+func (srv testAccessTokenServer) TokenRefresh() (string, error)        { return string(srv), nil }
+func (srv testAccessTokenServer) Tag6D89F2E2FE9811E49EAAA4DB30FED8E1() {}
+
+// 把所有请求都转发到 target, 用来模拟微信服务器.
+type testRedirectTransport struct {
+	target *url.URL
+}
+
+func (t testRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSync(t *testing.T) {
+	// 当前的通讯录:
+	//  1
+	//  ├── 2 Sales:    alice, erin
+	//  └── 3 Old:      carol, dave(同时属于同步范围以外的部门 100)
+	//      └── 4 OldChild: bob
+	const (
+		departmentList = `{"errcode":0,"department":[
+			{"id":1,"name":"Root","parentid":0},
+			{"id":2,"name":"Sales","parentid":1},
+			{"id":3,"name":"Old","parentid":1},
+			{"id":4,"name":"OldChild","parentid":3}]}`
+		userList = `{"errcode":0,"userlist":[
+			{"userid":"alice","name":"Alice","department":[2]},
+			{"userid":"erin","name":"Erin","department":[2]},
+			{"userid":"carol","name":"Carol","department":[3]},
+			{"userid":"dave","name":"Dave","department":[3,100]},
+			{"userid":"bob","name":"Bob","department":[4]}]}`
+	)
+
+	// 期望的通讯录:
+	//  1
+	//  ├── 2 Sales:       alice
+	//  └── 5 Engineering
+	//      ├── 6 Backend:  bob
+	//      └── 7 Frontend(创建失败)
+	desired := &OrgChart{Root: &OrgNode{
+		DeptId: 1,
+		Children: []*OrgNode{
+			{DeptId: 2, DeptName: "Sales", Users: []OrgUser{{UserId: "alice", Name: "Alice"}}},
+			{DeptId: 5, DeptName: "Engineering", Children: []*OrgNode{
+				{DeptId: 6, DeptName: "Backend", Users: []OrgUser{{UserId: "bob", Name: "Bob"}}},
+				{DeptId: 7, DeptName: "Frontend"},
+			}},
+		},
+	}}
+
+	var calls []string // 按照顺序记录修改通讯录的调用
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Id         int64   `json:"id"`
+			UserId     string  `json:"userid"`
+			ParentId   int64   `json:"parentid"`
+			Department []int64 `json:"department"`
+		}
+		if r.Method == "POST" {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+		}
+		query := r.URL.Query()
+
+		var call string
+		switch r.URL.Path {
+		case "/cgi-bin/department/list":
+			io.WriteString(w, departmentList)
+			return
+		case "/cgi-bin/user/list":
+			io.WriteString(w, userList)
+			return
+		case "/cgi-bin/department/create":
+			call = fmt.Sprintf("department/create %d parent %d", body.Id, body.ParentId)
+		case "/cgi-bin/department/update":
+			call = fmt.Sprintf("department/update %d", body.Id)
+		case "/cgi-bin/department/delete":
+			call = "department/delete " + query.Get("id")
+		case "/cgi-bin/user/create":
+			call = fmt.Sprintf("user/create %s %v", body.UserId, body.Department)
+		case "/cgi-bin/user/update":
+			call = fmt.Sprintf("user/update %s %v", body.UserId, body.Department)
+		case "/cgi-bin/user/delete":
+			call = "user/delete " + query.Get("userid")
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			return
+		}
+		calls = append(calls, call)
+
+		switch call {
+		case "department/create 7 parent 5", "user/delete erin":
+			io.WriteString(w, `{"errcode":60003,"errmsg":"failed"}`)
+		default:
+			io.WriteString(w, `{"errcode":0,"errmsg":"ok"}`)
+		}
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	clt := NewClient(testAccessTokenServer("ACCESS_TOKEN"), &http.Client{Transport: testRedirectTransport{target}})
+
+	report, err := clt.Sync(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		// 部门从父部门到子部门创建
+		"department/create 5 parent 1",
+		"department/create 6 parent 5",
+		"department/create 7 parent 5",
+		// 成员先移动到新部门
+		"user/update bob [6]",
+		"user/delete erin",
+		"user/delete carol",
+		"user/update dave [100]",
+		// 部门从子部门到父部门删除
+		"department/delete 4",
+		"department/delete 3",
+	}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls:\nhave: %q\nwant: %q", calls, wantCalls)
+	}
+
+	if have, want := report.CreatedDepartments, []int64{5, 6}; !reflect.DeepEqual(have, want) {
+		t.Errorf("CreatedDepartments, have: %v, want: %v", have, want)
+	}
+	if len(report.UpdatedDepartments) != 0 {
+		t.Errorf("UpdatedDepartments, have: %v, want: []", report.UpdatedDepartments)
+	}
+	if have, want := report.DeletedDepartments, []int64{4, 3}; !reflect.DeepEqual(have, want) {
+		t.Errorf("DeletedDepartments, have: %v, want: %v", have, want)
+	}
+	if len(report.CreatedUsers) != 0 {
+		t.Errorf("CreatedUsers, have: %v, want: []", report.CreatedUsers)
+	}
+	if have, want := report.UpdatedUsers, []string{"bob", "dave"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("UpdatedUsers, have: %v, want: %v", have, want)
+	}
+	if have, want := report.DeletedUsers, []string{"carol"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("DeletedUsers, have: %v, want: %v", have, want)
+	}
+
+	var errs []string
+	for _, e := range report.Errors {
+		errs = append(errs, e.Op+" "+e.Target)
+	}
+	if want := []string{"DepartmentCreate 7", "UserDelete erin"}; !reflect.DeepEqual(errs, want) {
+		t.Errorf("Errors, have: %q, want: %q", errs, want)
+	}
+}
@@ -18,6 +18,39 @@ type Attribute struct {
 	Value string `json:"value"`
 }
 
+const (
+	ExternalAttrTypeText        = 0 // 文本
+	ExternalAttrTypeWeb         = 1 // 网页
+	ExternalAttrTypeMiniprogram = 2 // 小程序
+)
+
+// 成员对外属性
+type ExternalAttribute struct {
+	Type int    `json:"type"` // 属性类型, 参考 ExternalAttrTypeXXX
+	Name string `json:"name"` // 属性名称, 需要先确保在管理端有创建该属性, 否则会忽略
+
+	Text *struct {
+		Value string `json:"value"` // 文本属性内容, 长度限制12个UTF8字符
+	} `json:"text,omitempty"` // 文本类型的属性, type 为 0 时必填
+
+	Web *struct {
+		URL   string `json:"url"`   // 网页的url, 必须包含http或者https头
+		Title string `json:"title"` // 网页的展示标题, 长度限制12个UTF8字符
+	} `json:"web,omitempty"` // 网页类型的属性, url和title字段要么同时为空表示清除该属性, 要么同时不为空, type 为 1 时必填
+
+	Miniprogram *struct {
+		AppId    string `json:"appid"`    // 小程序appid, 必须是有在本企业安装授权的小程序, 否则会被忽略
+		PagePath string `json:"pagepath"` // 小程序的页面路径
+		Title    string `json:"title"`    // 小程序的展示标题, 长度限制12个UTF8字符
+	} `json:"miniprogram,omitempty"` // 小程序类型的属性, appid和title字段要么同时为空表示清除改属性, 要么同时不为空, type 为 2 时必填
+}
+
+// 成员对外属性
+type ExternalProfile struct {
+	ExternalCorpName string              `json:"external_corp_name,omitempty"` // 企业对外简称, 需从已认证的企业简称中选填. 可在"我的企业"页中查看企业简称认证状态
+	ExternalAttr     []ExternalAttribute `json:"external_attr,omitempty"`      // 属性列表, 目前支持文本, 网页, 小程序三种类型
+}
+
 // 创建成员的参数
 type UserCreateParameters struct {
 	UserId     string  `json:"userid,omitempty"`     // 必须;  员工UserID. 对应管理端的帐号, 企业内必须唯一. 长度为1~64个字符
@@ -30,6 +63,23 @@ type UserCreateParameters struct {
 	ExtAttr    struct {
 		Attrs []Attribute `json:"attrs,omitempty"`
 	} `json:"extattr"` // 非必须; 扩展属性. 扩展属性需要在WEB管理端创建后才生效, 否则忽略未知属性的赋值
+
+	UserCommonParameters
+	ToInvite *bool `json:"to_invite,omitempty"` // 非必须; 是否邀请该成员使用企业微信(将通过微信服务通知或短信或邮件下发邀请, 每天自动下发一次, 最多持续3个工作日), 默认值为true
+}
+
+// 创建成员和更新成员共有的参数
+type UserCommonParameters struct {
+	Alias            string           `json:"alias,omitempty"`             // 非必须; 成员别名. 长度1~64个utf8字符
+	Order            []int64          `json:"order,omitempty"`             // 非必须; 部门内的排序值, 默认为0, 成员次序以创建时间从小到大排列. 个数必须和参数department的个数一致
+	Gender           string           `json:"gender,omitempty"`            // 非必须; 性别. 1表示男性, 2表示女性
+	IsLeaderInDept   []int            `json:"is_leader_in_dept,omitempty"` // 非必须; 个数必须和参数department的个数一致, 表示在所在的部门内是否为部门负责人. 1表示为部门负责人, 0表示非部门负责人
+	DirectLeader     []string         `json:"direct_leader,omitempty"`     // 非必须; 直属上级UserID, 可以设置1到5个上级
+	Telephone        string           `json:"telephone,omitempty"`         // 非必须; 座机. 32字节以内, 由纯数字, "-", "+"或","组成
+	Address          string           `json:"address,omitempty"`           // 非必须; 地址. 长度最大128个字符
+	MainDepartment   int64            `json:"main_department,omitempty"`   // 非必须; 主部门
+	ExternalPosition string           `json:"external_position,omitempty"` // 非必须; 对外职务, 如果设置了该值, 则以此作为对外展示的职务, 否则以position来展示. 长度12个汉字内
+	ExternalProfile  *ExternalProfile `json:"external_profile,omitempty"`  // 非必须; 成员对外属性
 }
 
 // 创建成员
@@ -65,6 +115,8 @@ type UserUpdateParameters struct {
 	ExtAttr    struct {
 		Attrs []Attribute `json:"attrs,omitempty"`
 	} `json:"extattr"` // 非必须; 扩展属性. 扩展属性需要在WEB管理端创建后才生效, 否则忽略未知属性的赋值
+
+	UserCommonParameters
 }
 
 func (para *UserUpdateParameters) SetEnable(b bool) {
@@ -148,10 +200,24 @@ type UserInfo struct {
 	Email      string  `json:"email"`                // 邮箱
 	WeixinId   string  `json:"weixinid"`             // 微信号
 	Avatar     string  `json:"avatar"`               // 头像url. 注: 如果要获取小图将url最后的"/0"改成"/64"即可
-	Status     int     `json:"status"`               // 关注状态: 1=已关注, 2=已冻结, 4=未关注
+	Status     int     `json:"status"`               // 关注状态: 1=已关注, 2=已冻结, 4=未关注, 5=退出企业
 	ExtAttr    struct {
 		Attrs []Attribute `json:"attrs,omitempty"`
 	} `json:"extattr"` // 扩展属性
+
+	Alias            string           `json:"alias"`             // 别名
+	Order            []int64          `json:"order"`             // 部门内的排序值, 个数与 Department 一致
+	Gender           string           `json:"gender"`            // 性别. 0表示未定义, 1表示男性, 2表示女性
+	IsLeaderInDept   []int            `json:"is_leader_in_dept"` // 在所在的部门内是否为部门负责人, 个数与 Department 一致
+	DirectLeader     []string         `json:"direct_leader"`     // 直属上级UserID
+	ThumbAvatar      string           `json:"thumb_avatar"`      // 头像缩略图url
+	Telephone        string           `json:"telephone"`         // 座机
+	Address          string           `json:"address"`           // 地址
+	MainDepartment   int64            `json:"main_department"`   // 主部门
+	Enable           int              `json:"enable"`            // 成员启用状态. 1表示启用的成员, 0表示被禁用
+	QRCode           string           `json:"qr_code"`           // 员工个人二维码, 扫描可添加为外部联系人
+	ExternalPosition string           `json:"external_position"` // 对外职务
+	ExternalProfile  *ExternalProfile `json:"external_profile"`  // 成员对外属性
 }
 
 func (clt *Client) UserInfo(userId string) (info *UserInfo, err error) {
@@ -216,6 +282,22 @@ func (clt *Client) UserSimpleList(departmentId int64,
 	return
 }
 
+// 获取部门全部成员的 UserID 列表
+//  departmentId: 获取的部门id
+//  fetchChild:   是否递归获取子部门下面的成员
+func (clt *Client) UserIdList(departmentId int64, fetchChild bool) (UserIdList []string, err error) {
+	UserList, err := clt.UserSimpleList(departmentId, fetchChild, 0)
+	if err != nil {
+		return
+	}
+
+	UserIdList = make([]string, len(UserList))
+	for i := 0; i < len(UserList); i++ {
+		UserIdList[i] = UserList[i].Id
+	}
+	return
+}
+
 // 获取部门成员(详情)
 //  departmentId: 获取的部门id
 //  fetchChild:   是否递归获取子部门下面的成员
@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// userid 转换成 openid.
+//  该接口使用场景为企业支付, 在使用企业红包和向员工付款时, 需要自行将企业微信的 userid 转成 openid.
+func (clt *Client) ConvertToOpenId(userId string) (openId string, err error) {
+	var request = struct {
+		UserId string `json:"userid"`
+	}{
+		UserId: userId,
+	}
+
+	var result struct {
+		corp.Error
+		OpenId string `json:"openid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_openid?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openId = result.OpenId
+	return
+}
+
+// openid 转换成 userid.
+//  该接口主要应用于使用企业支付之后的结果查询, 成员需要已经关注企业微信.
+func (clt *Client) ConvertToUserId(openId string) (userId string, err error) {
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: openId,
+	}
+
+	var result struct {
+		corp.Error
+		UserId string `json:"userid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/convert_to_userid?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	userId = result.UserId
+	return
+}
+
+// 获取企业活跃成员数.
+//  date: 具体某天的活跃人数, 格式为 YYYY-MM-DD, 最长支持获取30天前数据.
+func (clt *Client) UserActiveStat(date string) (activeCount int, err error) {
+	var request = struct {
+		Date string `json:"date"`
+	}{
+		Date: date,
+	}
+
+	var result struct {
+		corp.Error
+		ActiveCount int `json:"active_cnt"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/get_active_stat?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	activeCount = result.ActiveCount
+	return
+}
@@ -0,0 +1,202 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package addresslist
+
+import (
+	"container/list"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 邮箱的类型
+const (
+	EmailTypeCorp     = 1 // 企业邮箱
+	EmailTypePersonal = 2 // 个人邮箱
+)
+
+// 没有找到手机号或者邮箱对应的成员
+var ErrUserNotFound = errors.New("user not found")
+
+// 微信返回的没有找到成员的错误码
+const errCodeUserNotFound = 46004
+
+// 规范化手机号, 去掉空白字符, '-' 和 +86 前缀, 比如 "+86 138-0013-8000" 规范化为 "13800138000".
+//  GetUserIdByMobile, UserIdFinder 和 dingtalk.Mapper 都用它比较手机号.
+func NormalizeMobile(mobile string) string {
+	mobile = strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, mobile)
+	return strings.TrimPrefix(mobile, "+86")
+}
+
+// 规范化邮箱, 去掉首尾的空白字符并转换成小写.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// 通过手机号获取成员的 UserID.
+//  没有找到成员时返回 ErrUserNotFound.
+func (clt *Client) GetUserIdByMobile(mobile string) (userId string, err error) {
+	mobile = NormalizeMobile(mobile)
+	if mobile == "" {
+		err = errors.New("empty mobile")
+		return
+	}
+
+	var request = struct {
+		Mobile string `json:"mobile"`
+	}{
+		Mobile: mobile,
+	}
+	return clt.getUserId("https://qyapi.weixin.qq.com/cgi-bin/user/getuserid?access_token=", &request)
+}
+
+// 通过邮箱获取成员的 UserID.
+//  emailType: EmailTypeCorp 或者 EmailTypePersonal, 0 表示默认的企业邮箱
+//  没有找到成员时返回 ErrUserNotFound.
+func (clt *Client) GetUserIdByEmail(email string, emailType int) (userId string, err error) {
+	email = NormalizeEmail(email)
+	if email == "" {
+		err = errors.New("empty email")
+		return
+	}
+
+	var request = struct {
+		Email     string `json:"email"`
+		EmailType int    `json:"email_type,omitempty"`
+	}{
+		Email:     email,
+		EmailType: emailType,
+	}
+	return clt.getUserId("https://qyapi.weixin.qq.com/cgi-bin/user/get_userid_by_email?access_token=", &request)
+}
+
+func (clt *Client) getUserId(incompleteURL string, request interface{}) (userId string, err error) {
+	var result struct {
+		corp.Error
+		UserId string `json:"userid"`
+	}
+
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case corp.ErrCodeOK:
+		userId = result.UserId
+		return
+	case errCodeUserNotFound:
+		err = ErrUserNotFound
+		return
+	default:
+		err = &result.Error
+		return
+	}
+}
+
+// 默认的 UserIdFinder 缓存时间
+const UserIdFinderCacheTTL = time.Hour
+
+// UserIdFinder 缓存手机号和邮箱对应的成员 UserID, 用于从外部系统(比如 HR 系统)频繁查找成员.
+//  缓存按照 LRU 淘汰; 只缓存找到的结果, 不缓存 ErrUserNotFound, 因为成员可能稍后才被创建.
+type UserIdFinder struct {
+	clt     *Client
+	ttl     time.Duration
+	maxSize int
+
+	mutex sync.Mutex
+	ll    *list.List
+	cache map[string]*list.Element
+}
+
+type userIdFinderEntry struct {
+	key       string
+	userId    string
+	expiresAt time.Time
+}
+
+// 创建一个新的 UserIdFinder.
+//  maxSize: 缓存的最大条目数
+//  ttl:     缓存时间, <= 0 时使用 UserIdFinderCacheTTL
+func NewUserIdFinder(clt *Client, maxSize int, ttl time.Duration) *UserIdFinder {
+	if clt == nil {
+		panic("nil Client")
+	}
+	if maxSize <= 0 {
+		panic("maxSize must be positive")
+	}
+	if ttl <= 0 {
+		ttl = UserIdFinderCacheTTL
+	}
+	return &UserIdFinder{
+		clt:     clt,
+		ttl:     ttl,
+		maxSize: maxSize,
+		ll:      list.New(),
+		cache:   make(map[string]*list.Element),
+	}
+}
+
+// 参考 Client.GetUserIdByMobile.
+func (finder *UserIdFinder) GetUserIdByMobile(mobile string) (userId string, err error) {
+	mobile = NormalizeMobile(mobile)
+	return finder.find("mobile:"+mobile, func() (string, error) {
+		return finder.clt.GetUserIdByMobile(mobile)
+	})
+}
+
+// 参考 Client.GetUserIdByEmail.
+func (finder *UserIdFinder) GetUserIdByEmail(email string, emailType int) (userId string, err error) {
+	email = NormalizeEmail(email)
+	return finder.find("email:"+strconv.Itoa(emailType)+":"+email, func() (string, error) {
+		return finder.clt.GetUserIdByEmail(email, emailType)
+	})
+}
+
+func (finder *UserIdFinder) find(key string, fn func() (string, error)) (userId string, err error) {
+	now := time.Now()
+
+	finder.mutex.Lock()
+	if elem, ok := finder.cache[key]; ok {
+		entry := elem.Value.(*userIdFinderEntry)
+		if now.Before(entry.expiresAt) {
+			finder.ll.MoveToFront(elem)
+			userId = entry.userId
+			finder.mutex.Unlock()
+			return
+		}
+		finder.ll.Remove(elem)
+		delete(finder.cache, key)
+	}
+	finder.mutex.Unlock()
+
+	if userId, err = fn(); err != nil {
+		return
+	}
+
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	if elem, ok := finder.cache[key]; ok {
+		finder.ll.Remove(elem)
+	}
+	finder.cache[key] = finder.ll.PushFront(&userIdFinderEntry{key: key, userId: userId, expiresAt: now.Add(finder.ttl)})
+	if finder.ll.Len() > finder.maxSize {
+		elem := finder.ll.Back()
+		finder.ll.Remove(elem)
+		delete(finder.cache, elem.Value.(*userIdFinderEntry).key)
+	}
+	return
+}
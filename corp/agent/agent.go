@@ -0,0 +1,120 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package agent
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 企业应用的详情
+type Agent struct {
+	AgentId       int64  `json:"agentid"`         // 企业应用id
+	Name          string `json:"name"`            // 企业应用名称
+	SquareLogoURL string `json:"square_logo_url"` // 企业应用方形头像
+	Description   string `json:"description"`     // 企业应用详情
+
+	AllowUserInfos struct {
+		User []struct {
+			UserId string `json:"userid"`
+		} `json:"user"`
+	} `json:"allow_userinfos"` // 企业应用可见范围(人员), 其中包括userid
+	AllowPartys struct {
+		PartyId []int64 `json:"partyid"`
+	} `json:"allow_partys"` // 企业应用可见范围(部门)
+	AllowTags struct {
+		TagId []int64 `json:"tagid"`
+	} `json:"allow_tags"` // 企业应用可见范围(标签)
+
+	Close              int    `json:"close"`                // 企业应用是否被停用, 0: 未被停用, 1: 被停用
+	RedirectDomain     string `json:"redirect_domain"`      // 企业应用可信域名
+	ReportLocationFlag int    `json:"report_location_flag"` // 企业应用是否打开地理位置上报 0: 不上报, 1: 进入会话上报
+	IsReportEnter      int    `json:"isreportenter"`        // 是否上报用户进入应用事件. 0: 不接收, 1: 接收
+	HomeURL            string `json:"home_url"`             // 应用主页url
+}
+
+// 获取企业应用的详情.
+func (clt *Client) AgentGet(agentId int64) (agent *Agent, err error) {
+	var result struct {
+		corp.Error
+		Agent
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/get?agentid=" +
+		strconv.FormatInt(agentId, 10) + "&access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	agent = &result.Agent
+	return
+}
+
+// 设置企业应用的参数, 只需要设置要修改的字段
+type AgentSetParameters struct {
+	AgentId            int64  `json:"agentid"`                        // 必须;  企业应用的id
+	ReportLocationFlag *int   `json:"report_location_flag,omitempty"` // 非必须; 企业应用是否打开地理位置上报 0: 不上报, 1: 进入会话上报
+	LogoMediaId        string `json:"logo_mediaid,omitempty"`         // 非必须; 企业应用头像的mediaid, 通过素材管理接口上传图片获得mediaid
+	Name               string `json:"name,omitempty"`                 // 非必须; 企业应用名称, 长度不超过32个utf8字符
+	Description        string `json:"description,omitempty"`          // 非必须; 企业应用详情, 长度为4至120个utf8字符
+	RedirectDomain     string `json:"redirect_domain,omitempty"`      // 非必须; 企业应用可信域名
+	IsReportEnter      *int   `json:"isreportenter,omitempty"`        // 非必须; 是否上报用户进入应用事件. 0: 不接收, 1: 接收
+	HomeURL            string `json:"home_url,omitempty"`             // 非必须; 应用主页url, url必须以http或者https开头
+}
+
+// 设置企业应用.
+func (clt *Client) AgentSet(para *AgentSetParameters) (err error) {
+	if para == nil {
+		err = errors.New("nil parameters")
+		return
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/set?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, para, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 企业应用列表里的应用信息
+type AgentBaseInfo struct {
+	AgentId       int64  `json:"agentid"`         // 企业应用id
+	Name          string `json:"name"`            // 企业应用名称
+	SquareLogoURL string `json:"square_logo_url"` // 企业应用方形头像url
+}
+
+// 获取 access_token 对应的应用列表.
+func (clt *Client) AgentList() (AgentList []AgentBaseInfo, err error) {
+	var result struct {
+		corp.Error
+		AgentList []AgentBaseInfo `json:"agentlist"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/agent/list?access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	AgentList = result.AgentList
+	return
+}
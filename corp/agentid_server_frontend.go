@@ -0,0 +1,132 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package corp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// 多个 AgentServer 共用一个回调 URL 的前端, http.Handler 的实现.
+//
+//  和 MultiAgentServerFrontend 不同, AgentIdServerFrontend 不需要在回调 URL 上加查询参数,
+//  而是根据消息 http body 里面明文的 AgentID 来索引对应的 AgentServer, 然后由这个 AgentServer
+//  的 Token 和 AESKey 来校验签名和解密, 所以每个应用都可以有自己的 Token 和 EncodingAESKey.
+//
+//  NOTE:
+//  1. 没有注册 AgentID 对应的 AgentServer 时使用 defaultServer(比如 AgentID 为 0 的关注/取消关注企业号事件);
+//  2. 首次验证回调 URL 的 GET 请求里面没有 AgentID, 使用 defaultServer;
+//  3. AgentIdServerFrontend 并发安全, 可以在运行中动态增加和删除 AgentServer.
+type AgentIdServerFrontend struct {
+	defaultServer AgentServer
+
+	errHandler  ErrorHandler
+	interceptor Interceptor
+
+	rwmutex        sync.RWMutex
+	agentServerMap map[int64]AgentServer
+}
+
+// NewAgentIdServerFrontend 创建一个新的 AgentIdServerFrontend.
+//  defaultServer: 没有找到 AgentID 对应的 AgentServer 时使用, 可以为 nil
+//  errHandler:    错误处理 handler, 可以为 nil
+//  interceptor:   拦截器, 可以为 nil
+func NewAgentIdServerFrontend(defaultServer AgentServer, errHandler ErrorHandler, interceptor Interceptor) *AgentIdServerFrontend {
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+
+	return &AgentIdServerFrontend{
+		defaultServer:  defaultServer,
+		errHandler:     errHandler,
+		interceptor:    interceptor,
+		agentServerMap: make(map[int64]AgentServer),
+	}
+}
+
+func (frontend *AgentIdServerFrontend) SetAgentServer(agentId int64, server AgentServer) (err error) {
+	if server == nil {
+		return errors.New("nil AgentServer")
+	}
+
+	frontend.rwmutex.Lock()
+	frontend.agentServerMap[agentId] = server
+	frontend.rwmutex.Unlock()
+	return
+}
+
+func (frontend *AgentIdServerFrontend) DeleteAgentServer(agentId int64) {
+	frontend.rwmutex.Lock()
+	delete(frontend.agentServerMap, agentId)
+	frontend.rwmutex.Unlock()
+}
+
+func (frontend *AgentIdServerFrontend) DeleteAllAgentServer() {
+	frontend.rwmutex.Lock()
+	frontend.agentServerMap = make(map[int64]AgentServer)
+	frontend.rwmutex.Unlock()
+}
+
+func (frontend *AgentIdServerFrontend) getAgentServer(agentId int64) (server AgentServer) {
+	frontend.rwmutex.RLock()
+	server = frontend.agentServerMap[agentId]
+	frontend.rwmutex.RUnlock()
+
+	if server == nil {
+		server = frontend.defaultServer
+	}
+	return
+}
+
+func (frontend *AgentIdServerFrontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	queryValues, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		frontend.errHandler.ServeError(w, r, err)
+		return
+	}
+
+	if interceptor := frontend.interceptor; interceptor != nil && !interceptor.Intercept(w, r, queryValues) {
+		return
+	}
+
+	if r.Method != "POST" {
+		if frontend.defaultServer == nil {
+			frontend.errHandler.ServeError(w, r, errors.New("nil defaultServer"))
+			return
+		}
+		ServeHTTP(w, r, queryValues, frontend.defaultServer, frontend.errHandler)
+		return
+	}
+
+	// 先读取 http body 里面明文的 AgentID, 再把 body 还原, 交给对应的 AgentServer 做完整的校验和解密
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		frontend.errHandler.ServeError(w, r, err)
+		return
+	}
+
+	var requestHttpBody RequestHttpBody
+	if err = xml.Unmarshal(body, &requestHttpBody); err != nil {
+		frontend.errHandler.ServeError(w, r, err)
+		return
+	}
+
+	agentServer := frontend.getAgentServer(requestHttpBody.AgentId)
+	if agentServer == nil {
+		err = fmt.Errorf("Not found AgentServer for AgentID == %d", requestHttpBody.AgentId)
+		frontend.errHandler.ServeError(w, r, err)
+		return
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	ServeHTTP(w, r, queryValues, agentServer, frontend.errHandler)
+}
@@ -0,0 +1,202 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package approval
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 控件的类型
+const (
+	ControlText     = "Text"     // 文本
+	ControlTextarea = "Textarea" // 多行文本
+	ControlNumber   = "Number"   // 数字
+	ControlMoney    = "Money"    // 金额
+	ControlDate     = "Date"     // 日期/日期+时间
+	ControlSelector = "Selector" // 单选/多选
+	ControlContact  = "Contact"  // 成员/部门
+	ControlTips     = "Tips"     // 说明文字
+	ControlFile     = "File"     // 附件
+	ControlTable    = "Table"    // 明细
+)
+
+// 多语言的文字
+type Text struct {
+	Text string `json:"text"`
+	Lang string `json:"lang"` // 语言, 比如 zh_CN
+}
+
+// 控件的基础属性
+type ControlProperty struct {
+	Control     string `json:"control"`               // 控件类型
+	Id          string `json:"id"`                    // 控件id
+	Title       []Text `json:"title"`                 // 控件名称
+	Placeholder []Text `json:"placeholder,omitempty"` // 控件说明
+	Require     int    `json:"require"`               // 是否必填, 1: 必填, 0: 非必填
+	UnPrint     int    `json:"un_print"`              // 是否参与打印, 1: 不参与打印, 0: 参与打印
+}
+
+// Date 控件的配置
+type DateConfig struct {
+	Type string `json:"type"` // 时间展示类型, day: 日期, hour: 日期+时间
+}
+
+type SelectorOption struct {
+	Key   string `json:"key"`   // 选项 key
+	Value []Text `json:"value"` // 选项值
+}
+
+// Selector 控件的配置
+type SelectorConfig struct {
+	Type    string           `json:"type"` // 选择类型, single: 单选, multi: 多选
+	Options []SelectorOption `json:"options"`
+}
+
+// Contact 控件的配置
+type ContactConfig struct {
+	Type string `json:"type"` // 选择方式, single: 单选, multi: 多选
+	Mode string `json:"mode"` // 选择对象, user: 成员, department: 部门
+}
+
+// Tips 控件的配置
+type TipsConfig struct {
+	TipsContent []struct {
+		Text struct {
+			SubText []struct {
+				Type    int `json:"type"` // 1: 纯文本, 2: 链接
+				Content struct {
+					PlainText *struct {
+						Content string `json:"content"`
+					} `json:"plain_text,omitempty"`
+					Link *struct {
+						Title string `json:"title"`
+						URL   string `json:"url"`
+					} `json:"link,omitempty"`
+				} `json:"content"`
+			} `json:"sub_text"`
+		} `json:"text"`
+		Lang string `json:"lang"`
+	} `json:"tips_content"`
+}
+
+// File 控件的配置
+type FileConfig struct {
+	IsApiUploadOnly int `json:"is_api_upload_only"` // 是否只允许通过 api 上传, 1: 是, 0: 否
+}
+
+// Table 控件的配置, Children 为明细里的子控件
+type TableConfig struct {
+	Children []Control `json:"children"`
+}
+
+// 控件的配置, 根据控件类型只有一个字段不为 nil; Text, Textarea, Number, Money 控件没有配置, 都为 nil.
+type ControlConfig struct {
+	Date     *DateConfig     `json:"date,omitempty"`
+	Selector *SelectorConfig `json:"selector,omitempty"`
+	Contact  *ContactConfig  `json:"contact,omitempty"`
+	Tips     *TipsConfig     `json:"tips,omitempty"`
+	File     *FileConfig     `json:"file,omitempty"`
+	Table    *TableConfig    `json:"table,omitempty"`
+}
+
+// 审批模板的控件
+type Control struct {
+	Property ControlProperty `json:"property"`
+	Config   ControlConfig   `json:"config"`
+}
+
+// 根据 property.control 只解析对应类型的 config, 忽略和控件类型不符的配置.
+func (control *Control) UnmarshalJSON(data []byte) (err error) {
+	var raw struct {
+		Property ControlProperty `json:"property"`
+		Config   struct {
+			Date     json.RawMessage `json:"date"`
+			Selector json.RawMessage `json:"selector"`
+			Contact  json.RawMessage `json:"contact"`
+			Tips     json.RawMessage `json:"tips"`
+			File     json.RawMessage `json:"file"`
+			Table    json.RawMessage `json:"table"`
+		} `json:"config"`
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return
+	}
+
+	control.Property = raw.Property
+	control.Config = ControlConfig{}
+
+	var src json.RawMessage
+	var dst interface{}
+	switch raw.Property.Control {
+	case ControlDate:
+		control.Config.Date = new(DateConfig)
+		src, dst = raw.Config.Date, control.Config.Date
+	case ControlSelector:
+		control.Config.Selector = new(SelectorConfig)
+		src, dst = raw.Config.Selector, control.Config.Selector
+	case ControlContact:
+		control.Config.Contact = new(ContactConfig)
+		src, dst = raw.Config.Contact, control.Config.Contact
+	case ControlTips:
+		control.Config.Tips = new(TipsConfig)
+		src, dst = raw.Config.Tips, control.Config.Tips
+	case ControlFile:
+		control.Config.File = new(FileConfig)
+		src, dst = raw.Config.File, control.Config.File
+	case ControlTable:
+		control.Config.Table = new(TableConfig)
+		src, dst = raw.Config.Table, control.Config.Table
+	default:
+		return
+	}
+	if len(src) == 0 || string(src) == "null" {
+		return
+	}
+	return json.Unmarshal(src, dst)
+}
+
+// 审批模板
+type Template struct {
+	TemplateNames   []Text `json:"template_names"` // 模板名称
+	TemplateContent struct {
+		Controls []Control `json:"controls"` // 控件列表
+	} `json:"template_content"` // 模板控件信息
+}
+
+// 获取审批模板详情.
+//  templateId: 模板id, 可以在管理后台的审批应用里获取
+func (clt *Client) GetTemplateDetail(templateId string) (template *Template, err error) {
+	if templateId == "" {
+		err = errors.New("empty templateId")
+		return
+	}
+
+	var request = struct {
+		TemplateId string `json:"template_id"`
+	}{
+		TemplateId: templateId,
+	}
+
+	var result struct {
+		corp.Error
+		Template
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/gettemplatedetail?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	template = &result.Template
+	return
+}
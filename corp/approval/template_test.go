@@ -0,0 +1,45 @@
+package approval
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestControlUnmarshalJSON(t *testing.T) {
+	data := []byte(`{"controls":[
+		{"property":{"control":"Text","id":"Text-1","title":[{"text":"事由","lang":"zh_CN"}],"require":1,"un_print":0},"config":{}},
+		{"property":{"control":"Date","id":"Date-1","title":[{"text":"日期","lang":"zh_CN"}]},"config":{"date":{"type":"day"},"selector":{"type":"multi"}}},
+		{"property":{"control":"Selector","id":"Selector-1"},"config":{"selector":{"type":"single","options":[{"key":"option-1","value":[{"text":"是","lang":"zh_CN"}]}]}}},
+		{"property":{"control":"Table","id":"Table-1"},"config":{"table":{"children":[
+			{"property":{"control":"Contact","id":"Contact-1"},"config":{"contact":{"type":"multi","mode":"user"}}}
+		]}}}
+	]}`)
+
+	var content struct {
+		Controls []Control `json:"controls"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		t.Fatal(err)
+	}
+	controls := content.Controls
+	if len(controls) != 4 {
+		t.Fatalf("got %d controls, want 4", len(controls))
+	}
+
+	if c := controls[0]; c.Property.Require != 1 || c.Property.Title[0].Text != "事由" || c.Config != (ControlConfig{}) {
+		t.Errorf("unexpected Text control: %+v", c)
+	}
+	if c := controls[1]; c.Config.Date == nil || c.Config.Date.Type != "day" || c.Config.Selector != nil {
+		t.Errorf("unexpected Date control config: %+v", c.Config)
+	}
+	if c := controls[2]; c.Config.Selector == nil || len(c.Config.Selector.Options) != 1 || c.Config.Selector.Options[0].Key != "option-1" {
+		t.Errorf("unexpected Selector control config: %+v", c.Config)
+	}
+	c := controls[3]
+	if c.Config.Table == nil || len(c.Config.Table.Children) != 1 {
+		t.Fatalf("unexpected Table control config: %+v", c.Config)
+	}
+	if child := c.Config.Table.Children[0]; child.Config.Contact == nil || child.Config.Contact.Mode != "user" {
+		t.Errorf("unexpected Table child control: %+v", child)
+	}
+}
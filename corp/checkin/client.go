@@ -0,0 +1,34 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+type Client corp.Client
+
+func NewClient(srv corp.AccessTokenServer, clt *http.Client) *Client {
+	return (*Client)(corp.NewClient(srv, clt))
+}
+
+const (
+	UserIdListCountLimit = 100 // 打卡接口每次请求的 useridlist 最多 100 个用户
+)
+
+// 把 UserIdList 按照 UserIdListCountLimit 分组
+func splitUserIdList(UserIdList []string) (groups [][]string) {
+	for len(UserIdList) > UserIdListCountLimit {
+		groups = append(groups, UserIdList[:UserIdListCountLimit])
+		UserIdList = UserIdList[UserIdListCountLimit:]
+	}
+	if len(UserIdList) > 0 {
+		groups = append(groups, UserIdList)
+	}
+	return
+}
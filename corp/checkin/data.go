@@ -0,0 +1,199 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	DataTypeOnOffDuty = 1 // 上下班打卡
+	DataTypeOutside   = 2 // 外出打卡
+	DataTypeAll       = 3 // 全部打卡
+)
+
+// 打卡记录
+type CheckinData struct {
+	UserId         string   `json:"userid"`          // 用户id
+	GroupName      string   `json:"groupname"`       // 打卡规则名称
+	CheckinType    string   `json:"checkin_type"`    // 打卡类型: 上班打卡, 下班打卡, 外出打卡
+	ExceptionType  string   `json:"exception_type"`  // 异常类型: 时间异常, 地点异常, 未打卡, wifi异常, 非常用设备; 如果有多个异常, 以分号间隔
+	CheckinTime    int64    `json:"checkin_time"`    // 打卡时间, unix 时间戳
+	LocationTitle  string   `json:"location_title"`  // 打卡地点title
+	LocationDetail string   `json:"location_detail"` // 打卡地点详情
+	WifiName       string   `json:"wifiname"`        // 打卡的 WiFi 名称
+	Notes          string   `json:"notes"`           // 打卡备注
+	WifiMac        string   `json:"wifimac"`         // 打卡的 MAC 地址/bssid
+	MediaIds       []string `json:"mediaids"`        // 打卡的附件media_id, 可使用media/get获取附件
+	Lat            int64    `json:"lat"`             // 位置打卡地点纬度, 是实际纬度的1000000倍
+	Lng            int64    `json:"lng"`             // 位置打卡地点经度, 是实际经度的1000000倍
+	DeviceId       string   `json:"deviceid"`        // 打卡设备id
+}
+
+// 获取打卡记录数据.
+//  dataType:          打卡类型, 参考 DataTypeXXX;
+//  startTime/endTime: 获取打卡记录的开始/结束时间, unix 时间戳, 时间跨度不超过30天;
+//  UserIdList:        需要获取打卡记录的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetCheckinData(dataType int, startTime, endTime int64, UserIdList []string) (DataList []CheckinData, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var request = struct {
+			DataType   int      `json:"opencheckindatatype"`
+			StartTime  int64    `json:"starttime"`
+			EndTime    int64    `json:"endtime"`
+			UserIdList []string `json:"useridlist"`
+		}{
+			DataType:   dataType,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			UserIdList: group,
+		}
+
+		var result struct {
+			corp.Error
+			DataList []CheckinData `json:"checkindata"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckindata?access_token="
+		if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		DataList = append(DataList, result.DataList...)
+	}
+	return
+}
+
+// 日报/月报的基础信息
+type ReportBaseInfo struct {
+	Date        int64  `json:"date"`         // 日报的日期, unix 时间戳, 月报没有这个字段
+	RecordType  int    `json:"record_type"`  // 记录类型: 1 固定上下班, 2 外出(此报表中不会出现外出打卡数据), 3 按班次上下班, 4 自由签到, 5 加班, 7 无规则
+	Name        string `json:"name"`         // 打卡人员姓名
+	NameEx      string `json:"name_ex"`      // 打卡人员别名
+	DepartsName string `json:"departs_name"` // 打卡人员所在部门, 会显示所有所在部门
+	AcctId      string `json:"acctid"`       // 打卡人员帐号, 即userid
+	RuleInfo    struct {
+		GroupId    int64  `json:"groupid"`    // 所属规则的id
+		GroupName  string `json:"groupname"`  // 打卡规则名
+		ScheduleId int64  `json:"scheduleid"` // 当日所属班次id, 仅按班次上下班才有值
+	} `json:"rule_info"` // 打卡人员所属规则信息
+	DayType int `json:"day_type"` // 日报类型: 0 工作日日报, 1 休息日日报
+}
+
+// 日报/月报的汇总信息
+type ReportSummaryInfo struct {
+	CheckinCount    int   `json:"checkin_count"`     // 当日打卡次数, 月报没有这个字段
+	RegularWorkSec  int64 `json:"regular_work_sec"`  // 当日(当月)实际工作时长, 单位: 秒
+	StandardWorkSec int64 `json:"standard_work_sec"` // 当日(当月)标准工作时长, 单位: 秒
+	EarliestTime    int64 `json:"earliest_time"`     // 当日最早打卡时间, 月报没有这个字段
+	LastestTime     int64 `json:"lastest_time"`      // 当日最晚打卡时间, 月报没有这个字段
+	WorkDays        int   `json:"work_days"`         // 应打卡天数, 日报没有这个字段
+	ExceptDays      int   `json:"except_days"`       // 异常天数, 日报没有这个字段
+	RestDays        int   `json:"rest_days"`         // 休息天数, 日报没有这个字段
+}
+
+// 日报/月报的异常状态统计
+type ReportExceptionInfo struct {
+	Exception int   `json:"exception"` // 异常类型: 1 迟到, 2 早退, 3 缺卡, 4 旷工, 5 地点异常, 6 设备异常
+	Count     int   `json:"count"`     // 异常次数
+	Duration  int64 `json:"duration"`  // 异常时长(迟到/早退/旷工才有值), 单位: 秒
+}
+
+// 打卡日报
+type CheckinDayData struct {
+	BaseInfo      ReportBaseInfo        `json:"base_info"`       // 基础信息
+	SummaryInfo   ReportSummaryInfo     `json:"summary_info"`    // 汇总信息
+	ExceptionInfo []ReportExceptionInfo `json:"exception_infos"` // 异常状态统计信息
+}
+
+// 打卡月报
+type CheckinMonthData struct {
+	BaseInfo      ReportBaseInfo        `json:"base_info"`       // 基础信息
+	SummaryInfo   ReportSummaryInfo     `json:"summary_info"`    // 汇总信息
+	ExceptionInfo []ReportExceptionInfo `json:"exception_infos"` // 异常状态统计信息
+}
+
+// 获取打卡日报数据.
+//  startTime/endTime: 获取日报的开始/结束日期当天0点的 unix 时间戳;
+//  UserIdList:        需要获取日报的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetCheckinDayData(startTime, endTime int64, UserIdList []string) (DataList []CheckinDayData, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var result struct {
+			corp.Error
+			DataList []CheckinDayData `json:"datas"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckin_daydata?access_token="
+		if err = clt.postReportRequest(incompleteURL, startTime, endTime, group, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		DataList = append(DataList, result.DataList...)
+	}
+	return
+}
+
+// 获取打卡月报数据.
+//  startTime/endTime: 获取月报的开始/结束日期当天0点的 unix 时间戳, 时间跨度不超过一个月;
+//  UserIdList:        需要获取月报的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetCheckinMonthData(startTime, endTime int64, UserIdList []string) (DataList []CheckinMonthData, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var result struct {
+			corp.Error
+			DataList []CheckinMonthData `json:"datas"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckin_monthdata?access_token="
+		if err = clt.postReportRequest(incompleteURL, startTime, endTime, group, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		DataList = append(DataList, result.DataList...)
+	}
+	return
+}
+
+// 日报, 月报, 排班信息等接口的请求参数都是一样的
+func (clt *Client) postReportRequest(incompleteURL string, startTime, endTime int64, UserIdList []string, response interface{}) error {
+	var request = struct {
+		StartTime  int64    `json:"starttime"`
+		EndTime    int64    `json:"endtime"`
+		UserIdList []string `json:"useridlist"`
+	}{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		UserIdList: UserIdList,
+	}
+	return ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, response)
+}
@@ -0,0 +1,67 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	HardwareFilterTypeAll   = 1 // 获取所有打卡记录
+	HardwareFilterTypeFirst = 2 // 只获取每天的第一次打卡记录(用于上下班考勤的第一次和最后一次打卡)
+)
+
+// 考勤设备的打卡记录
+type HardwareCheckinData struct {
+	UserId      string `json:"userid"`       // 用户id
+	CheckinTime int64  `json:"checkin_time"` // 打卡时间, unix 时间戳
+	DeviceSN    string `json:"device_sn"`    // 打卡设备的序列号
+	DeviceName  string `json:"device_name"`  // 打卡设备的名称
+}
+
+// 获取考勤设备(企业微信智慧硬件)的打卡数据.
+//  filterType:        过滤类型, 参考 HardwareFilterTypeXXX;
+//  startTime/endTime: 获取打卡记录的开始/结束时间, unix 时间戳, 时间跨度不超过30天;
+//  UserIdList:        需要获取打卡记录的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetHardwareCheckinData(filterType int, startTime, endTime int64, UserIdList []string) (DataList []HardwareCheckinData, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var request = struct {
+			FilterType int      `json:"filter_type"`
+			StartTime  int64    `json:"starttime"`
+			EndTime    int64    `json:"endtime"`
+			UserIdList []string `json:"useridlist"`
+		}{
+			FilterType: filterType,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			UserIdList: group,
+		}
+
+		var result struct {
+			corp.Error
+			DataList []HardwareCheckinData `json:"checkindata"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/hardware/get_hardware_checkin_data?access_token="
+		if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		DataList = append(DataList, result.DataList...)
+	}
+	return
+}
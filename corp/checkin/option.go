@@ -0,0 +1,119 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	GroupTypeFixed  = 1 // 固定时间上下班
+	GroupTypeSwitch = 2 // 按班次上下班
+	GroupTypeFree   = 3 // 自由上下班
+)
+
+// 上下班时间
+type CheckinTime struct {
+	WorkSec          int64 `json:"work_sec"`            // 上班时间, 表示为距离当天0点的秒数
+	OffWorkSec       int64 `json:"off_work_sec"`        // 下班时间, 表示为距离当天0点的秒数
+	RemindWorkSec    int64 `json:"remind_work_sec"`     // 上班提醒时间, 表示为距离当天0点的秒数
+	RemindOffWorkSec int64 `json:"remind_off_work_sec"` // 下班提醒时间, 表示为距离当天0点的秒数
+}
+
+// 打卡时间
+type CheckinDate struct {
+	Workdays        []int         `json:"workdays"`           // 工作日, 若为固定时间上下班或自由上下班, 则1到7分别表示星期一到星期日; 若为按班次上下班, 则表示拉取周期内的天数
+	CheckinTime     []CheckinTime `json:"checkintime"`        // 工作日上下班打卡时间信息
+	FlexTime        int64         `json:"flex_time"`          // 弹性时间(毫秒)
+	NoNeedOffWork   bool          `json:"noneed_offwork"`     // 下班不需要打卡
+	LimitAheadTime  int64         `json:"limit_aheadtime"`    // 打卡时间限制(毫秒)
+	FlexOnDutyTime  int64         `json:"flex_on_duty_time"`  // 允许迟到时间, 单位秒
+	FlexOffDutyTime int64         `json:"flex_off_duty_time"` // 允许早退时间, 单位秒
+}
+
+// 特殊日期
+type SpecialDay struct {
+	Timestamp   int64         `json:"timestamp"`   // 特殊日期具体时间
+	Notes       string        `json:"notes"`       // 特殊日期备注
+	CheckinTime []CheckinTime `json:"checkintime"` // 特殊日期具体时间, 特殊的非工作日为空
+}
+
+// 打卡地点的 WiFi 信息
+type WifiMacInfo struct {
+	WifiName string `json:"wifiname"` // WiFi 名称
+	WifiMac  string `json:"wifimac"`  // WiFi 的 MAC 地址或者 BSSID
+}
+
+// 打卡地点的位置信息
+type LocationInfo struct {
+	Lat       int64  `json:"lat"`        // 位置打卡地点纬度, 是实际纬度的1000000倍
+	Lng       int64  `json:"lng"`        // 位置打卡地点经度, 是实际经度的1000000倍
+	LocTitle  string `json:"loc_title"`  // 位置打卡地点名称
+	LocDetail string `json:"loc_detail"` // 位置打卡地点详情
+	Distance  int64  `json:"distance"`   // 允许打卡范围(米)
+}
+
+// 打卡规则
+type CheckinGroup struct {
+	GroupType              int            `json:"grouptype"`                // 打卡规则类型, 参考 GroupTypeXXX
+	GroupId                int64          `json:"groupid"`                  // 打卡规则id
+	GroupName              string         `json:"groupname"`                // 打卡规则名称
+	CheckinDate            []CheckinDate  `json:"checkindate"`              // 打卡时间
+	SpeWorkdays            []SpecialDay   `json:"spe_workdays"`             // 特殊日期, 必须打卡的日期
+	SpeOffdays             []SpecialDay   `json:"spe_offdays"`              // 特殊日期, 不用打卡的日期
+	SyncHolidays           bool           `json:"sync_holidays"`            // 是否同步法定节假日
+	NeedPhoto              bool           `json:"need_photo"`               // 是否打卡必须拍照
+	WifiMacInfos           []WifiMacInfo  `json:"wifimac_infos"`            // WiFi 打卡地点信息
+	NoteCanUseLocalPic     bool           `json:"note_can_use_local_pic"`   // 是否备注时允许上传本地图片
+	AllowCheckinOffWorkday bool           `json:"allow_checkin_offworkday"` // 是否非工作日允许打卡
+	AllowApplyOffWorkday   bool           `json:"allow_apply_offworkday"`   // 补卡申请
+	LocInfos               []LocationInfo `json:"loc_infos"`                // 位置打卡地点信息
+}
+
+// 员工的打卡规则
+type CheckinOption struct {
+	UserId string       `json:"userid"` // 用户id
+	Group  CheckinGroup `json:"group"`  // 打卡规则相关信息
+}
+
+// 获取员工打卡规则.
+//  datetime:   需要获取规则的日期当天0点的 unix 时间戳;
+//  UserIdList: 需要获取打卡规则的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetCheckinOption(datetime int64, UserIdList []string) (OptionList []CheckinOption, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var request = struct {
+			Datetime   int64    `json:"datetime"`
+			UserIdList []string `json:"useridlist"`
+		}{
+			Datetime:   datetime,
+			UserIdList: group,
+		}
+
+		var result struct {
+			corp.Error
+			OptionList []CheckinOption `json:"info"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckinoption?access_token="
+		if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		OptionList = append(OptionList, result.OptionList...)
+	}
+	return
+}
@@ -0,0 +1,69 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package checkin
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 班次的上下班时间段
+type TimeSection struct {
+	Id               int64 `json:"id"`                  // 时段id, 为班次中某一堆上下班时间组合的id
+	WorkSec          int64 `json:"work_sec"`            // 上班时间, 表示为距离当天0点的秒数
+	OffWorkSec       int64 `json:"off_work_sec"`        // 下班时间, 表示为距离当天0点的秒数
+	RemindWorkSec    int64 `json:"remind_work_sec"`     // 上班提醒时间, 表示为距离当天0点的秒数
+	RemindOffWorkSec int64 `json:"remind_off_work_sec"` // 下班提醒时间, 表示为距离当天0点的秒数
+}
+
+// 某一天的排班信息
+type DaySchedule struct {
+	Day          int `json:"day"` // 排班日期, 为表示当月第几天的数字
+	ScheduleInfo struct {
+		ScheduleId   int64         `json:"schedule_id"`   // 当日安排班次id, 班次id也可在打卡规则中查询获得
+		ScheduleName string        `json:"schedule_name"` // 班次名称
+		TimeSection  []TimeSection `json:"time_section"`  // 班次上下班时段信息
+	} `json:"schedule_info"` // 排班信息
+}
+
+// 员工某个月的排班信息
+type ScheduleList struct {
+	UserId    string        `json:"userid"`    // 打卡人员userid
+	YearMonth int           `json:"yearmonth"` // 排班表月份, 格式为年月, 如202011
+	GroupId   int64         `json:"groupid"`   // 打卡规则id
+	GroupName string        `json:"groupname"` // 打卡规则名
+	Schedule  []DaySchedule `json:"schedule"`  // 个人排班信息
+}
+
+// 获取打卡人员排班信息.
+//  startTime/endTime: 获取排班信息的开始/结束日期当天0点的 unix 时间戳, 时间跨度不超过一个月;
+//  UserIdList:        需要获取排班信息的用户列表, 超过 100 个用户会分多次请求.
+func (clt *Client) GetCheckinScheduleList(startTime, endTime int64, UserIdList []string) (list []ScheduleList, err error) {
+	if len(UserIdList) <= 0 {
+		err = errors.New("empty UserIdList")
+		return
+	}
+
+	for _, group := range splitUserIdList(UserIdList) {
+		var result struct {
+			corp.Error
+			List []ScheduleList `json:"schedule_list"`
+		}
+
+		incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/checkin/getcheckinschedulist?access_token="
+		if err = clt.postReportRequest(incompleteURL, startTime, endTime, group, &result); err != nil {
+			return
+		}
+
+		if result.ErrCode != corp.ErrCodeOK {
+			err = &result.Error
+			return
+		}
+		list = append(list, result.List...)
+	}
+	return
+}
@@ -9,6 +9,7 @@ import "fmt"
 
 const (
 	ErrCodeOK                      = 0
+	ErrCodeInvalidAccessToken      = 40014 // 不合法的 access_token, 比如 access_token 已经被刷新(invalidauth), 返回这个错误
 	ErrCodeAccessTokenExpired      = 42001 // access_token 过期(无效)返回这个错误
 	ErrCodeSuiteAccessTokenExpired = 42009 // suite_access_token 过期(无效)返回这个错误
 )
@@ -0,0 +1,260 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 联系方式的类型
+const (
+	ContactWayTypeSingle = 1 // 单人
+	ContactWayTypeMulti  = 2 // 多人
+)
+
+// 联系方式的场景
+const (
+	ContactWaySceneMiniProgram = 1 // 在小程序中联系
+	ContactWaySceneQRCode      = 2 // 通过二维码联系
+)
+
+// 获取联系方式列表每次最多的个数
+const ContactWayListLimit = 1000
+
+// 结束语, 临时会话结束时发送给客户
+type Conclusions struct {
+	Text *struct {
+		Content string `json:"content"`
+	} `json:"text,omitempty"`
+	Image *struct {
+		MediaId string `json:"media_id,omitempty"`
+		PicURL  string `json:"pic_url,omitempty"` // 获取时返回
+	} `json:"image,omitempty"`
+	Link *struct {
+		Title  string `json:"title"`
+		PicURL string `json:"picurl,omitempty"`
+		Desc   string `json:"desc,omitempty"`
+		URL    string `json:"url"`
+	} `json:"link,omitempty"`
+	MiniProgram *struct {
+		Title      string `json:"title"`
+		PicMediaId string `json:"pic_media_id"`
+		AppId      string `json:"appid"`
+		Page       string `json:"page"`
+	} `json:"miniprogram,omitempty"`
+}
+
+// 「联系我」的联系方式
+type ContactWay struct {
+	ConfigId      string       `json:"config_id,omitempty"`       // 新增联系方式的配置id, 新增时不用填
+	Type          int          `json:"type,omitempty"`            // 联系方式类型, ContactWayTypeSingle, ContactWayTypeMulti
+	Scene         int          `json:"scene,omitempty"`           // 场景, ContactWaySceneMiniProgram, ContactWaySceneQRCode
+	Style         int          `json:"style,omitempty"`           // 在小程序中联系时使用的控件样式
+	Remark        string       `json:"remark,omitempty"`          // 联系方式的备注信息, 用于助记, 不超过30个字符
+	SkipVerify    *bool        `json:"skip_verify,omitempty"`     // 外部客户添加时是否无需验证, 默认为 true
+	State         string       `json:"state,omitempty"`           // 自定义的 state 参数, 会在添加外部联系人事件里回调, 不超过30个字符
+	QRCode        string       `json:"qr_code,omitempty"`         // 联系二维码的URL, 仅在 Scene 为 ContactWaySceneQRCode 时返回
+	User          []string     `json:"user,omitempty"`            // 使用该联系方式的用户 userid 列表, Type 为 ContactWayTypeSingle 时有且只有一个
+	Party         []int64      `json:"party,omitempty"`           // 使用该联系方式的部门 id 列表, 只在 Type 为 ContactWayTypeMulti 时有效
+	IsTemp        bool         `json:"is_temp,omitempty"`         // 是否临时会话模式, 默认为 false
+	ExpiresIn     int          `json:"expires_in,omitempty"`      // 临时会话二维码有效期, 以秒为单位
+	ChatExpiresIn int          `json:"chat_expires_in,omitempty"` // 临时会话有效期, 以秒为单位
+	UnionId       string       `json:"unionid,omitempty"`         // 可进行临时会话的客户 unionid
+	Conclusions   *Conclusions `json:"conclusions,omitempty"`     // 结束语, 临时会话模式才有效
+}
+
+// 配置客户联系「联系我」方式.
+//  cw.Type, cw.Scene 必须设置.
+func (clt *Client) AddContactWay(cw *ContactWay) (configId, qrcode string, err error) {
+	if cw == nil {
+		err = errors.New("nil ContactWay")
+		return
+	}
+	if cw.ConfigId != "" {
+		err = errors.New("ConfigId must be empty")
+		return
+	}
+	if cw.Type == ContactWayTypeSingle && len(cw.User) != 1 {
+		err = fmt.Errorf("单人类型的联系方式有且只能有一个 user, 现在为 %d", len(cw.User))
+		return
+	}
+
+	var result struct {
+		corp.Error
+		ConfigId string `json:"config_id"`
+		QRCode   string `json:"qr_code"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/add_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, cw, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	configId = result.ConfigId
+	qrcode = result.QRCode
+	return
+}
+
+// 获取企业已配置的「联系我」方式.
+func (clt *Client) GetContactWay(configId string) (cw *ContactWay, err error) {
+	var request = struct {
+		ConfigId string `json:"config_id"`
+	}{
+		ConfigId: configId,
+	}
+
+	var result struct {
+		corp.Error
+		ContactWay ContactWay `json:"contact_way"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	cw = &result.ContactWay
+	return
+}
+
+// 更新企业已配置的「联系我」方式.
+//  cw.ConfigId 必须设置; Type, Scene, IsTemp 不能修改, 会被忽略.
+func (clt *Client) UpdateContactWay(cw *ContactWay) (err error) {
+	if cw == nil {
+		return errors.New("nil ContactWay")
+	}
+	if cw.ConfigId == "" {
+		return errors.New("empty ConfigId")
+	}
+
+	var request = struct {
+		ConfigId      string       `json:"config_id"`
+		Remark        string       `json:"remark,omitempty"`
+		SkipVerify    *bool        `json:"skip_verify,omitempty"`
+		Style         int          `json:"style,omitempty"`
+		State         string       `json:"state,omitempty"`
+		User          []string     `json:"user,omitempty"`
+		Party         []int64      `json:"party,omitempty"`
+		ExpiresIn     int          `json:"expires_in,omitempty"`
+		ChatExpiresIn int          `json:"chat_expires_in,omitempty"`
+		UnionId       string       `json:"unionid,omitempty"`
+		Conclusions   *Conclusions `json:"conclusions,omitempty"`
+	}{
+		ConfigId:      cw.ConfigId,
+		Remark:        cw.Remark,
+		SkipVerify:    cw.SkipVerify,
+		Style:         cw.Style,
+		State:         cw.State,
+		User:          cw.User,
+		Party:         cw.Party,
+		ExpiresIn:     cw.ExpiresIn,
+		ChatExpiresIn: cw.ChatExpiresIn,
+		UnionId:       cw.UnionId,
+		Conclusions:   cw.Conclusions,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/update_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除企业已配置的「联系我」方式.
+func (clt *Client) DeleteContactWay(configId string) (err error) {
+	var request = struct {
+		ConfigId string `json:"config_id"`
+	}{
+		ConfigId: configId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/del_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 联系方式列表
+type ContactWayList struct {
+	ConfigIdList []string // 联系方式的配置id
+	NextCursor   string   // 分页参数, 用于查询下一个分页的数据, 为空时表示没有更多的分页
+}
+
+// 获取企业配置的「联系我」二维码和「联系我」小程序插件列表, 不包含临时会话.
+//  startTime, endTime: 「联系我」创建起止时间戳, 为 0 时表示不限制, 最多 90 天
+//  cursor:             分页查询使用的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+//  limit:              每次查询的分页大小, 不超过 ContactWayListLimit
+func (clt *Client) GetContactWayList(startTime, endTime int64, cursor string, limit int) (list *ContactWayList, err error) {
+	if limit < 0 || limit > ContactWayListLimit {
+		err = fmt.Errorf("limit 必须在 0 和 %d 之间, 现在为 %d", ContactWayListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		StartTime int64  `json:"start_time,omitempty"`
+		EndTime   int64  `json:"end_time,omitempty"`
+		Cursor    string `json:"cursor,omitempty"`
+		Limit     int    `json:"limit,omitempty"`
+	}{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Cursor:    cursor,
+		Limit:     limit,
+	}
+
+	var result struct {
+		corp.Error
+		ContactWay []struct {
+			ConfigId string `json:"config_id"`
+		} `json:"contact_way"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/list_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	list = &ContactWayList{
+		ConfigIdList: make([]string, 0, len(result.ContactWay)),
+		NextCursor:   result.NextCursor,
+	}
+	for _, v := range result.ContactWay {
+		list.ConfigIdList = append(list.ConfigIdList, v.ConfigId)
+	}
+	return
+}
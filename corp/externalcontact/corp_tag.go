@@ -0,0 +1,242 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 获取企业标签库时每次最多指定的标签id个数
+const CorpTagListLimit = 100
+
+// 企业客户标签
+type CorpTag struct {
+	Id         string `json:"id,omitempty"`          // 标签id, 添加时不用填
+	Name       string `json:"name"`                  // 标签名称
+	CreateTime int64  `json:"create_time,omitempty"` // 标签创建时间, 获取时返回
+	Order      uint32 `json:"order,omitempty"`       // 标签排序的次序值, order 值大的排序靠前
+	Deleted    bool   `json:"deleted,omitempty"`     // 标签是否已经被删除, 只在指定 tag_id 进行查询时返回
+}
+
+// 企业客户标签组
+type CorpTagGroup struct {
+	GroupId    string    `json:"group_id"`    // 标签组id
+	GroupName  string    `json:"group_name"`  // 标签组名称
+	CreateTime int64     `json:"create_time"` // 标签组创建时间
+	Order      uint32    `json:"order"`       // 标签组排序的次序值, order 值大的排序靠前
+	Deleted    bool      `json:"deleted"`     // 标签组是否已经被删除, 只在指定 tag_id 进行查询时返回
+	Tags       []CorpTag `json:"tag"`         // 标签组内的标签列表
+}
+
+// 获取企业标签库.
+//  tagIds 和 groupIds 都为空时返回所有的标签; 同时指定时忽略 groupIds.
+//  tagIds 最多 CorpTagListLimit 个.
+func (clt *Client) GetCorpTagList(tagIds, groupIds []string) (groups []CorpTagGroup, err error) {
+	if len(tagIds) > CorpTagListLimit {
+		err = fmt.Errorf("tagIds 最多 %d 个, 现在为 %d", CorpTagListLimit, len(tagIds))
+		return
+	}
+
+	var request = struct {
+		TagId   []string `json:"tag_id,omitempty"`
+		GroupId []string `json:"group_id,omitempty"`
+	}{
+		TagId:   tagIds,
+		GroupId: groupIds,
+	}
+
+	var result struct {
+		corp.Error
+		TagGroup []CorpTagGroup `json:"tag_group"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_corp_tag_list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	groups = result.TagGroup
+	return
+}
+
+// 在已有的标签组 groupId 里添加企业客户标签, 返回添加之后的标签组(只包含新添加的标签).
+//  tags: 只需要填写 Name 和 Order(可选)
+func (clt *Client) AddCorpTags(groupId string, tags []CorpTag) (group *CorpTagGroup, err error) {
+	if groupId == "" {
+		err = errors.New("empty groupId")
+		return
+	}
+	if len(tags) == 0 {
+		err = errors.New("empty tags")
+		return
+	}
+
+	var request = struct {
+		GroupId string    `json:"group_id"`
+		Tag     []CorpTag `json:"tag"`
+	}{
+		GroupId: groupId,
+		Tag:     tags,
+	}
+	return clt.addCorpTag(&request)
+}
+
+// 添加企业客户标签组, 同时添加组内的标签.
+//  groupName: 标签组名称, 如果已经存在同名的标签组, 那么会在该标签组内添加 tags
+//  order:     标签组排序的次序值, order 值大的排序靠前, 0 表示不设置
+//  tags:      只需要填写 Name 和 Order(可选), 至少一个
+func (clt *Client) AddCorpTagGroup(groupName string, order uint32, tags []CorpTag) (group *CorpTagGroup, err error) {
+	if groupName == "" {
+		err = errors.New("empty groupName")
+		return
+	}
+	if len(tags) == 0 {
+		err = errors.New("empty tags")
+		return
+	}
+
+	var request = struct {
+		GroupName string    `json:"group_name"`
+		Order     uint32    `json:"order,omitempty"`
+		Tag       []CorpTag `json:"tag"`
+	}{
+		GroupName: groupName,
+		Order:     order,
+		Tag:       tags,
+	}
+	return clt.addCorpTag(&request)
+}
+
+func (clt *Client) addCorpTag(request interface{}) (group *CorpTagGroup, err error) {
+	var result struct {
+		corp.Error
+		TagGroup CorpTagGroup `json:"tag_group"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/add_corp_tag?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	group = &result.TagGroup
+	return
+}
+
+// 编辑企业客户标签或者标签组的名称和次序值.
+//  id:    标签id或者标签组id
+//  name:  新的名称, 为空时不修改
+//  order: 新的次序值, 0 表示不修改
+func (clt *Client) EditCorpTag(id, name string, order uint32) (err error) {
+	if id == "" {
+		return errors.New("empty id")
+	}
+
+	var request = struct {
+		Id    string `json:"id"`
+		Name  string `json:"name,omitempty"`
+		Order uint32 `json:"order,omitempty"`
+	}{
+		Id:    id,
+		Name:  name,
+		Order: order,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/edit_corp_tag?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除企业客户标签或者标签组.
+//  tagIds 和 groupIds 不能同时为空; 删除标签组时组内的标签也会被删除, 删除组内所有的标签时标签组也会被删除.
+func (clt *Client) DeleteCorpTag(tagIds, groupIds []string) (err error) {
+	if len(tagIds) == 0 && len(groupIds) == 0 {
+		return errors.New("empty tagIds and groupIds")
+	}
+
+	var request = struct {
+		TagId   []string `json:"tag_id,omitempty"`
+		GroupId []string `json:"group_id,omitempty"`
+	}{
+		TagId:   tagIds,
+		GroupId: groupIds,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/del_corp_tag?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 编辑客户企业标签.
+//  userId:         添加外部联系人的 userid
+//  externalUserId: 外部联系人 userid
+//  addTagIds:      要标记的标签列表
+//  removeTagIds:   要移除的标签列表
+//  NOTE: addTagIds 和 removeTagIds 不能同时为空.
+func (clt *Client) MarkTag(userId, externalUserId string, addTagIds, removeTagIds []string) (err error) {
+	if userId == "" {
+		return errors.New("empty userId")
+	}
+	if externalUserId == "" {
+		return errors.New("empty externalUserId")
+	}
+	if len(addTagIds) == 0 && len(removeTagIds) == 0 {
+		return errors.New("empty addTagIds and removeTagIds")
+	}
+
+	var request = struct {
+		UserId         string   `json:"userid"`
+		ExternalUserId string   `json:"external_userid"`
+		AddTag         []string `json:"add_tag,omitempty"`
+		RemoveTag      []string `json:"remove_tag,omitempty"`
+	}{
+		UserId:         userId,
+		ExternalUserId: externalUserId,
+		AddTag:         addTagIds,
+		RemoveTag:      removeTagIds,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/mark_tag?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
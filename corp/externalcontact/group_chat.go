@@ -0,0 +1,154 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 客户群的跟进状态
+const (
+	GroupChatStatusNormal          = 0 // 跟进人正常
+	GroupChatStatusOwnerResigned   = 1 // 跟进人离职
+	GroupChatStatusTransferring    = 2 // 离职继承中
+	GroupChatStatusTransferredDone = 3 // 离职继承完成
+)
+
+// 客户群成员的类型
+const (
+	GroupChatMemberTypeUser     = 1 // 企业成员
+	GroupChatMemberTypeExternal = 2 // 外部联系人
+)
+
+// 客户群成员的入群方式
+const (
+	JoinSceneDirectInvite = 1 // 由群成员邀请入群(直接邀请入群)
+	JoinSceneInviteLink   = 2 // 由群成员邀请入群(通过邀请链接入群)
+	JoinSceneQRCode       = 3 // 通过扫描群二维码入群
+)
+
+const GroupChatListLimit = 1000
+
+// 客户群成员
+type GroupChatMember struct {
+	UserId        string `json:"userid"`         // 群成员id, 企业成员为 userid, 外部联系人为 external_userid
+	Type          int    `json:"type"`           // 成员类型, GroupChatMemberTypeUser 或者 GroupChatMemberTypeExternal
+	UnionId       string `json:"unionid"`        // 外部联系人在微信开放平台的唯一身份标识
+	JoinTime      int64  `json:"join_time"`      // 入群时间
+	JoinScene     int    `json:"join_scene"`     // 入群方式, JoinSceneDirectInvite 等
+	GroupNickname string `json:"group_nickname"` // 在群里的昵称
+	Name          string `json:"name"`           // 名字, 需要 GetGroupChat 的 needName 为 true 才返回
+	Invitor       struct {
+		UserId string `json:"userid"`
+	} `json:"invitor"` // 邀请者, 只有通过邀请入群的才有
+}
+
+// 客户群详情
+type GroupChat struct {
+	ChatId     string            `json:"chat_id"`     // 客户群id
+	Name       string            `json:"name"`        // 群名
+	Owner      string            `json:"owner"`       // 群主id
+	CreateTime int64             `json:"create_time"` // 群的创建时间
+	Notice     string            `json:"notice"`      // 群公告
+	MemberList []GroupChatMember `json:"member_list"` // 群成员列表
+	AdminList  []struct {
+		UserId string `json:"userid"`
+	} `json:"admin_list"` // 群管理员列表
+}
+
+// 获取客户群详情.
+//  needName: 是否需要返回群成员的名字 GroupChatMember.Name
+func (clt *Client) GetGroupChat(chatId string, needName bool) (chat *GroupChat, err error) {
+	if chatId == "" {
+		err = errors.New("empty chatId")
+		return
+	}
+
+	var request = struct {
+		ChatId   string `json:"chat_id"`
+		NeedName int    `json:"need_name"`
+	}{
+		ChatId: chatId,
+	}
+	if needName {
+		request.NeedName = 1
+	}
+
+	var result struct {
+		corp.Error
+		GroupChat GroupChat `json:"group_chat"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/get?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	chat = &result.GroupChat
+	return
+}
+
+// 客户群列表
+type GroupChatList struct {
+	GroupChatList []struct {
+		ChatId string `json:"chat_id"` // 客户群id
+		Status int    `json:"status"`  // 跟进状态, GroupChatStatusNormal 等
+	} `json:"group_chat_list"`
+	NextCursor string `json:"next_cursor"` // 分页游标, 为空时表示没有更多的分页
+}
+
+// 获取配置了客户群管理的成员的客户群列表.
+//  statusFilter: 按照跟进状态过滤, 0 为所有列表(即不过滤), 1 为离职待继承, 2 为离职继承中, 3 为离职继承完成
+//  ownerUserIds: 按照群主过滤, 为空时表示不过滤, 最多100个
+//  cursor:       分页查询使用的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+//  limit:        每次查询的分页大小, 1 到 GroupChatListLimit
+func (clt *Client) ListGroupChats(statusFilter int, ownerUserIds []string, cursor string, limit int) (list *GroupChatList, err error) {
+	if limit < 1 || limit > GroupChatListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", GroupChatListLimit, limit)
+		return
+	}
+
+	type ownerFilter struct {
+		UserIdList []string `json:"userid_list"`
+	}
+	var request = struct {
+		StatusFilter int          `json:"status_filter,omitempty"`
+		OwnerFilter  *ownerFilter `json:"owner_filter,omitempty"`
+		Cursor       string       `json:"cursor,omitempty"`
+		Limit        int          `json:"limit"`
+	}{
+		StatusFilter: statusFilter,
+		Cursor:       cursor,
+		Limit:        limit,
+	}
+	if len(ownerUserIds) > 0 {
+		request.OwnerFilter = &ownerFilter{UserIdList: ownerUserIds}
+	}
+
+	var result struct {
+		corp.Error
+		GroupChatList
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = &result.GroupChatList
+	return
+}
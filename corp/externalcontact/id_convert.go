@@ -0,0 +1,144 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/corp/addresslist"
+)
+
+// 外部联系人 unionid 转换成 external_userid.
+//  unionId: 微信客户的 unionid
+//  openId:  微信客户的 openid, 和 unionId 属于同一个公众号或者小程序
+//  NOTE: 客户需要已经添加了企业的成员, 否则返回错误.
+func (clt *Client) UnionIdToExternalUserId(unionId, openId string) (externalUserId string, err error) {
+	if unionId == "" {
+		err = errors.New("empty unionId")
+		return
+	}
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var request = struct {
+		UnionId string `json:"unionid"`
+		OpenId  string `json:"openid"`
+	}{
+		UnionId: unionId,
+		OpenId:  openId,
+	}
+
+	var result struct {
+		corp.Error
+		ExternalUserId string `json:"external_userid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/unionid_to_external_userid?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	externalUserId = result.ExternalUserId
+	return
+}
+
+// IdConverter 缓存 userid, openid, unionid 和 external_userid 之间的转换结果, 这些对应关系在关系存续期间不会变化.
+//  缓存使用 LRU 淘汰, 转换失败的结果不缓存.
+type IdConverter struct {
+	addressList *addresslist.Client
+	contact     *Client
+	maxSize     int
+
+	mutex sync.Mutex
+	ll    *list.List
+	cache map[string]*list.Element
+}
+
+type idCacheEntry struct {
+	key   string
+	value string
+}
+
+// 创建一个新的 IdConverter, maxSize 为缓存的最大条目数.
+func NewIdConverter(addressList *addresslist.Client, contact *Client, maxSize int) *IdConverter {
+	if addressList == nil {
+		panic("nil addresslist.Client")
+	}
+	if contact == nil {
+		panic("nil Client")
+	}
+	if maxSize <= 0 {
+		panic("maxSize must be positive")
+	}
+	return &IdConverter{
+		addressList: addressList,
+		contact:     contact,
+		maxSize:     maxSize,
+		ll:          list.New(),
+		cache:       make(map[string]*list.Element),
+	}
+}
+
+// 成员 userid 转换成 openid, 参考 addresslist.Client.ConvertToOpenId.
+func (converter *IdConverter) UserIdToOpenId(userId string) (openId string, err error) {
+	return converter.convert("userid:"+userId, func() (string, error) {
+		return converter.addressList.ConvertToOpenId(userId)
+	})
+}
+
+// openid 转换成成员 userid, 参考 addresslist.Client.ConvertToUserId.
+func (converter *IdConverter) OpenIdToUserId(openId string) (userId string, err error) {
+	return converter.convert("openid:"+openId, func() (string, error) {
+		return converter.addressList.ConvertToUserId(openId)
+	})
+}
+
+// 外部联系人 unionid 转换成 external_userid, 参考 Client.UnionIdToExternalUserId.
+func (converter *IdConverter) UnionIdToExternalUserId(unionId, openId string) (externalUserId string, err error) {
+	return converter.convert("unionid:"+unionId+":"+openId, func() (string, error) {
+		return converter.contact.UnionIdToExternalUserId(unionId, openId)
+	})
+}
+
+func (converter *IdConverter) convert(key string, fn func() (string, error)) (value string, err error) {
+	converter.mutex.Lock()
+	if elem, ok := converter.cache[key]; ok {
+		converter.ll.MoveToFront(elem)
+		value = elem.Value.(*idCacheEntry).value
+		converter.mutex.Unlock()
+		return
+	}
+	converter.mutex.Unlock()
+
+	if value, err = fn(); err != nil {
+		return
+	}
+
+	converter.mutex.Lock()
+	defer converter.mutex.Unlock()
+
+	if elem, ok := converter.cache[key]; ok {
+		converter.ll.MoveToFront(elem)
+		elem.Value.(*idCacheEntry).value = value
+		return
+	}
+	converter.cache[key] = converter.ll.PushFront(&idCacheEntry{key: key, value: value})
+	if converter.ll.Len() > converter.maxSize {
+		elem := converter.ll.Back()
+		converter.ll.Remove(elem)
+		delete(converter.cache, elem.Value.(*idCacheEntry).key)
+	}
+	return
+}
@@ -0,0 +1,138 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 统计数据最大的查询跨度, 单位为秒
+const StatisticMaxRange = 30 * 24 * 60 * 60
+
+func checkStatisticRange(startTime, endTime int64) error {
+	if startTime <= 0 || endTime < startTime {
+		return fmt.Errorf("invalid time range: %d - %d", startTime, endTime)
+	}
+	if endTime-startTime > StatisticMaxRange {
+		return fmt.Errorf("查询跨度不能超过 30 天, 现在为 %d 秒", endTime-startTime)
+	}
+	return nil
+}
+
+// 成员一天的联系客户统计数据
+type UserBehavior struct {
+	StatTime            int64   `json:"stat_time"`             // 数据日期, 为当日0点的时间戳
+	ChatCount           int     `json:"chat_cnt"`              // 聊天总数, 成员有主动发送过消息的单聊总数
+	MessageCount        int     `json:"message_cnt"`           // 发送消息数, 成员在单聊中发送的消息总数
+	ReplyPercentage     float64 `json:"reply_percentage"`      // 已回复聊天占比, 客户主动发起的聊天里 20 小时内成员回复过的占比, 单位为百分比
+	AvgReplyTime        int     `json:"avg_reply_time"`        // 平均首次回复时长, 单位为分钟
+	NegativeFeedbackCnt int     `json:"negative_feedback_cnt"` // 删除/拉黑成员的客户数
+	NewApplyCount       int     `json:"new_apply_cnt"`         // 发起申请数, 成员通过「搜索手机号」, 「扫一扫」等主动向客户发起的好友申请数
+	NewContactCount     int     `json:"new_contact_cnt"`       // 新增客户数, 成员新添加的客户数量
+}
+
+// 获取「联系客户统计」数据.
+//  userIds:   成员 userid 列表, 最多 100 个
+//  partyIds:  部门 id 列表, 最多 100 个, userIds 和 partyIds 不能同时为空
+//  startTime: 开始时间, 为当日0点的时间戳
+//  endTime:   结束时间, 为当日0点的时间戳, 和 startTime 的跨度不能超过 30 天
+func (clt *Client) GetUserBehaviorData(userIds []string, partyIds []int64, startTime, endTime int64) (data []UserBehavior, err error) {
+	if len(userIds) == 0 && len(partyIds) == 0 {
+		err = errors.New("empty userIds and partyIds")
+		return
+	}
+	if err = checkStatisticRange(startTime, endTime); err != nil {
+		return
+	}
+
+	var request = struct {
+		UserId    []string `json:"userid,omitempty"`
+		PartyId   []int64  `json:"partyid,omitempty"`
+		StartTime int64    `json:"start_time"`
+		EndTime   int64    `json:"end_time"`
+	}{
+		UserId:    userIds,
+		PartyId:   partyIds,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+
+	var result struct {
+		corp.Error
+		BehaviorData []UserBehavior `json:"behavior_data"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/get_user_behavior_data?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = result.BehaviorData
+	return
+}
+
+// 客户群一天的统计数据
+type GroupChatStatistic struct {
+	StatTime int64 `json:"stat_time"` // 数据日期, 为当日0点的时间戳
+	Data     struct {
+		NewChatCount   int `json:"new_chat_cnt"`   // 新增客户群数量
+		ChatTotal      int `json:"chat_total"`     // 截至当天客户群总数量
+		ChatHasMsg     int `json:"chat_has_msg"`   // 截至当天有发过消息的客户群数量
+		NewMemberCount int `json:"new_member_cnt"` // 客户群新增群人数
+		MemberTotal    int `json:"member_total"`   // 截至当天客户群总人数
+		MemberHasMsg   int `json:"member_has_msg"` // 截至当天有发过消息的群成员数
+		MsgTotal       int `json:"msg_total"`      // 截至当天客户群消息总数
+	} `json:"data"`
+}
+
+// 按自然日聚合的方式获取「群聊数据统计」数据.
+//  dayBeginTime: 开始日期, 为当日0点的时间戳
+//  dayEndTime:   结束日期, 为当日0点的时间戳, 和 dayBeginTime 的跨度不能超过 30 天
+//  ownerUserIds: 群主 userid 列表, 为空时统计所有群主的客户群, 最多 100 个
+func (clt *Client) GetGroupChatStatisticByDay(dayBeginTime, dayEndTime int64, ownerUserIds []string) (items []GroupChatStatistic, err error) {
+	if err = checkStatisticRange(dayBeginTime, dayEndTime); err != nil {
+		return
+	}
+
+	type ownerFilter struct {
+		UserIdList []string `json:"userid_list"`
+	}
+	var request = struct {
+		DayBeginTime int64        `json:"day_begin_time"`
+		DayEndTime   int64        `json:"day_end_time"`
+		OwnerFilter  *ownerFilter `json:"owner_filter,omitempty"`
+	}{
+		DayBeginTime: dayBeginTime,
+		DayEndTime:   dayEndTime,
+	}
+	if len(ownerUserIds) > 0 {
+		request.OwnerFilter = &ownerFilter{UserIdList: ownerUserIds}
+	}
+
+	var result struct {
+		corp.Error
+		Items []GroupChatStatistic `json:"items"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/statistic_group_by_day?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	items = result.Items
+	return
+}
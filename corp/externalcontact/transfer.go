@@ -0,0 +1,224 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package externalcontact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 每次最多分配的客户数
+const TransferCustomerLimit = 100
+
+// 每次最多分配的客户群数
+const TransferGroupChatLimit = 100
+
+// 客户的接替状态
+const (
+	TransferStatusFinished = 1 // 接替完毕
+	TransferStatusWaiting  = 2 // 等待接替
+	TransferStatusRefused  = 3 // 客户拒绝
+	TransferStatusLimited  = 4 // 接替成员客户达到上限
+	TransferStatusNoRecord = 5 // 无接替记录
+)
+
+// 分配单个客户的结果, ErrCode 为 0 表示分配成功
+type TransferResult struct {
+	ExternalUserId string `json:"external_userid"`
+	ErrCode        int    `json:"errcode"`
+}
+
+// 分配在职成员的客户.
+//  handoverUserId:     原跟进成员的 userid
+//  takeoverUserId:     接替成员的 userid
+//  externalUserIds:    客户的 external_userid 列表, 最多 TransferCustomerLimit 个
+//  transferSuccessMsg: 转移成功后发给客户的消息, 最多200个字符, 为空时使用默认文案
+//
+//  NOTE: 分配之后客户会收到通知, 24 小时内可以拒绝, 没有拒绝的自动接替;
+//  同一个客户 90 天内只能被分配两次, 可以通过 GetTransferResult 查询接替状态.
+func (clt *Client) TransferCustomer(handoverUserId, takeoverUserId string, externalUserIds []string, transferSuccessMsg string) (results []TransferResult, err error) {
+	if err = checkTransferCustomer(handoverUserId, takeoverUserId, externalUserIds); err != nil {
+		return
+	}
+
+	var request = struct {
+		HandoverUserId     string   `json:"handover_userid"`
+		TakeoverUserId     string   `json:"takeover_userid"`
+		ExternalUserId     []string `json:"external_userid"`
+		TransferSuccessMsg string   `json:"transfer_success_msg,omitempty"`
+	}{
+		HandoverUserId:     handoverUserId,
+		TakeoverUserId:     takeoverUserId,
+		ExternalUserId:     externalUserIds,
+		TransferSuccessMsg: transferSuccessMsg,
+	}
+	return clt.transferCustomer("https://qyapi.weixin.qq.com/cgi-bin/externalcontact/transfer_customer?access_token=", &request)
+}
+
+// 分配离职成员的客户.
+//  参数参考 TransferCustomer; 离职成员的客户不会收到通知, 也不能拒绝.
+func (clt *Client) TransferResignedCustomer(handoverUserId, takeoverUserId string, externalUserIds []string) (results []TransferResult, err error) {
+	if err = checkTransferCustomer(handoverUserId, takeoverUserId, externalUserIds); err != nil {
+		return
+	}
+
+	var request = struct {
+		HandoverUserId string   `json:"handover_userid"`
+		TakeoverUserId string   `json:"takeover_userid"`
+		ExternalUserId []string `json:"external_userid"`
+	}{
+		HandoverUserId: handoverUserId,
+		TakeoverUserId: takeoverUserId,
+		ExternalUserId: externalUserIds,
+	}
+	return clt.transferCustomer("https://qyapi.weixin.qq.com/cgi-bin/externalcontact/resigned/transfer_customer?access_token=", &request)
+}
+
+func checkTransferCustomer(handoverUserId, takeoverUserId string, externalUserIds []string) error {
+	if handoverUserId == "" {
+		return errors.New("empty handoverUserId")
+	}
+	if takeoverUserId == "" {
+		return errors.New("empty takeoverUserId")
+	}
+	if len(externalUserIds) == 0 {
+		return errors.New("empty externalUserIds")
+	}
+	if len(externalUserIds) > TransferCustomerLimit {
+		return fmt.Errorf("externalUserIds 最多 %d 个, 现在为 %d", TransferCustomerLimit, len(externalUserIds))
+	}
+	return nil
+}
+
+func (clt *Client) transferCustomer(incompleteURL string, request interface{}) (results []TransferResult, err error) {
+	var result struct {
+		corp.Error
+		Customer []TransferResult `json:"customer"`
+	}
+
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.Customer
+	return
+}
+
+// 客户的接替状态
+type TransferState struct {
+	Customer []struct {
+		ExternalUserId string `json:"external_userid"`
+		Status         int    `json:"status"`        // 接替状态, TransferStatusFinished 等
+		TakeoverTime   int64  `json:"takeover_time"` // 接替客户的时间, 如果是等待接替状态, 则为未来的自动接替时间
+	} `json:"customer"`
+	NextCursor string `json:"next_cursor"` // 分页游标, 为空时表示没有更多的分页
+}
+
+// 查询在职成员客户的接替状态.
+//  cursor: 分页查询的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+func (clt *Client) GetTransferResult(handoverUserId, takeoverUserId, cursor string) (state *TransferState, err error) {
+	return clt.getTransferResult("https://qyapi.weixin.qq.com/cgi-bin/externalcontact/transfer_result?access_token=", handoverUserId, takeoverUserId, cursor)
+}
+
+// 查询离职成员客户的接替状态, 参考 GetTransferResult.
+func (clt *Client) GetResignedTransferResult(handoverUserId, takeoverUserId, cursor string) (state *TransferState, err error) {
+	return clt.getTransferResult("https://qyapi.weixin.qq.com/cgi-bin/externalcontact/resigned/transfer_result?access_token=", handoverUserId, takeoverUserId, cursor)
+}
+
+func (clt *Client) getTransferResult(incompleteURL, handoverUserId, takeoverUserId, cursor string) (state *TransferState, err error) {
+	if handoverUserId == "" {
+		err = errors.New("empty handoverUserId")
+		return
+	}
+	if takeoverUserId == "" {
+		err = errors.New("empty takeoverUserId")
+		return
+	}
+
+	var request = struct {
+		HandoverUserId string `json:"handover_userid"`
+		TakeoverUserId string `json:"takeover_userid"`
+		Cursor         string `json:"cursor,omitempty"`
+	}{
+		HandoverUserId: handoverUserId,
+		TakeoverUserId: takeoverUserId,
+		Cursor:         cursor,
+	}
+
+	var result struct {
+		corp.Error
+		TransferState
+	}
+
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	state = &result.TransferState
+	return
+}
+
+// 分配失败的客户群
+type TransferGroupChatFailure struct {
+	ChatId  string `json:"chat_id"`
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// 分配离职成员的客户群, 返回分配失败的客户群.
+//  chatIds:  客户群id列表, 最多 TransferGroupChatLimit 个
+//  newOwner: 新群主的 userid
+//
+//  NOTE: 群主离职了的客户群才可以分配, 继承成功后原群主不再是群成员.
+func (clt *Client) TransferGroupChat(chatIds []string, newOwner string) (failed []TransferGroupChatFailure, err error) {
+	if len(chatIds) == 0 {
+		err = errors.New("empty chatIds")
+		return
+	}
+	if len(chatIds) > TransferGroupChatLimit {
+		err = fmt.Errorf("chatIds 最多 %d 个, 现在为 %d", TransferGroupChatLimit, len(chatIds))
+		return
+	}
+	if newOwner == "" {
+		err = errors.New("empty newOwner")
+		return
+	}
+
+	var request = struct {
+		ChatIdList []string `json:"chat_id_list"`
+		NewOwner   string   `json:"new_owner"`
+	}{
+		ChatIdList: chatIds,
+		NewOwner:   newOwner,
+	}
+
+	var result struct {
+		corp.Error
+		FailedChatList []TransferGroupChatFailure `json:"failed_chat_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/externalcontact/groupchat/transfer?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	failed = result.FailedChatList
+	return
+}
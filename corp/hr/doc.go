@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 人事助手接口.
+//  NOTE: 需要使用人事助手应用的 secret 获取的 access_token, 或者在人事助手里授权了接口权限的自建应用.
+package hr
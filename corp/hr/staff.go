@@ -0,0 +1,115 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package hr
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 字段的值类型
+const (
+	ValueTypeString = 1 // 文本
+	ValueTypeUint64 = 2 // 无符号整数
+	ValueTypeUint32 = 3 // 选项, 比如性别
+	ValueTypeInt64  = 4 // 时间, unixtime
+	ValueTypeMobile = 5 // 手机号
+	ValueTypeFile   = 6 // 附件, value_string 为文件的 media_id
+)
+
+// 花名册的字段
+type Field struct {
+	FieldId   uint32 `json:"fieldid"`    // 字段id
+	FieldName string `json:"field_name"` // 字段名称
+	FieldType int    `json:"field_type"` // 字段的值类型, ValueTypeString 等
+	IsMust    bool   `json:"is_must"`    // 是否必填
+}
+
+// 花名册的字段分组
+type FieldGroup struct {
+	GroupId   uint32  `json:"group_id"`   // 分组id
+	GroupName string  `json:"group_name"` // 分组名称
+	FieldList []Field `json:"field_list"`
+}
+
+// 获取员工花名册的字段配置.
+func (clt *Client) GetFields() (groups []FieldGroup, err error) {
+	var result struct {
+		corp.Error
+		GroupList []FieldGroup `json:"group_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/hr/get_fields?access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	groups = result.GroupList
+	return
+}
+
+// 需要获取的字段, 多值字段(比如教育经历)用 SubIdx 指定第几个, 从 0 开始
+type FieldId struct {
+	FieldId uint32 `json:"fieldid"`
+	SubIdx  uint32 `json:"sub_idx"`
+}
+
+// 员工的一个字段的值, 根据 ValueType 只有对应的 ValueXXX 有效
+type FieldInfo struct {
+	FieldId     uint32 `json:"fieldid"`
+	SubIdx      uint32 `json:"sub_idx"`
+	Result      int    `json:"result"`     // 获取的结果, 1 为成功, 其他为失败
+	ValueType   int    `json:"value_type"` // 值类型, ValueTypeString 等
+	ValueString string `json:"value_string"`
+	ValueUint64 uint64 `json:"value_uint64"`
+	ValueUint32 uint32 `json:"value_uint32"`
+	ValueInt64  int64  `json:"value_int64"`
+	ValueMobile struct {
+		CountryCode string `json:"value_country_code"`
+		Mobile      string `json:"value_mobile"`
+	} `json:"value_mobile"`
+}
+
+// 获取员工花名册信息.
+//  fieldIds: 需要获取的字段, 为空时获取所有字段
+func (clt *Client) GetStaffInfo(userId string, fieldIds []FieldId) (fields []FieldInfo, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var request = struct {
+		UserId   string    `json:"userid"`
+		GetAll   bool      `json:"get_all,omitempty"`
+		FieldIds []FieldId `json:"fieldids,omitempty"`
+	}{
+		UserId:   userId,
+		GetAll:   len(fieldIds) == 0,
+		FieldIds: fieldIds,
+	}
+
+	var result struct {
+		corp.Error
+		FieldInfo []FieldInfo `json:"field_info"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/hr/get_staff_info?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	fields = result.FieldInfo
+	return
+}
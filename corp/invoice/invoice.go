@@ -0,0 +1,200 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package invoice
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 发票的报销状态
+const (
+	ReimburseStatusInit    = "INVOICE_REIMBURSE_INIT"    // 发票初始状态, 未锁定
+	ReimburseStatusLock    = "INVOICE_REIMBURSE_LOCK"    // 发票已锁定, 无法重复提交报销
+	ReimburseStatusClosure = "INVOICE_REIMBURSE_CLOSURE" // 发票已核销, 从用户卡包中移除
+)
+
+// 批量更新发票状态一次最多的发票数
+const BatchUpdateInvoiceCountLimit = 25
+
+// 发票的标识
+type InvoiceItem struct {
+	CardId      string `json:"card_id"`      // 发票id
+	EncryptCode string `json:"encrypt_code"` // 加密 code
+}
+
+// 发票的详细信息
+type InvoiceUserInfo struct {
+	Fee             int    `json:"fee"`              // 发票加税合计金额, 以分为单位
+	Title           string `json:"title"`            // 发票的抬头
+	BillingTime     int64  `json:"billing_time"`     // 开票时间, 为十位时间戳(utc+8)
+	BillingNo       string `json:"billing_no"`       // 发票代码
+	BillingCode     string `json:"billing_code"`     // 发票号码
+	FeeWithoutTax   int    `json:"fee_without_tax"`  // 不含税金额, 以分为单位
+	Tax             int    `json:"tax"`              // 税额, 以分为单位
+	Detail          string `json:"detail"`           // 发票详情, 一般描述的是发票的使用说明
+	PdfURL          string `json:"pdf_url"`          // 这张发票对应的 PDF_URL
+	ReimburseStatus string `json:"reimburse_status"` // 报销状态
+	CheckCode       string `json:"check_code"`       // 校验码
+
+	Info []struct {
+		Name  string `json:"name"`  // 项目的名称
+		Num   int    `json:"num"`   // 项目的数量
+		Unit  string `json:"unit"`  // 项目的单位
+		Fee   int    `json:"fee"`   // 项目的金额, 以分为单位
+		Price int    `json:"price"` // 项目的单价, 以分为单位
+	} `json:"info"` // 商品信息结构
+}
+
+// 电子发票
+type Invoice struct {
+	CardId    string          `json:"card_id"`    // 发票id
+	BeginTime int64           `json:"begin_time"` // 发票的有效期起始时间
+	EndTime   int64           `json:"end_time"`   // 发票的有效期截止时间
+	OpenId    string          `json:"openid"`     // 用户标识
+	Type      string          `json:"type"`       // 发票的类型
+	Payee     string          `json:"payee"`      // 发票的收款方
+	Detail    string          `json:"detail"`     // 发票详情
+	UserInfo  InvoiceUserInfo `json:"user_info"`  // 发票的用户信息
+}
+
+// 查询电子发票.
+//
+//  cardId:      发票id
+//  encryptCode: 加密 code
+func (clt *Client) GetInvoiceInfo(cardId, encryptCode string) (invoice *Invoice, err error) {
+	request := InvoiceItem{
+		CardId:      cardId,
+		EncryptCode: encryptCode,
+	}
+
+	var result struct {
+		corp.Error
+		Invoice
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/card/invoice/reimburse/getinvoiceinfo?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	invoice = &result.Invoice
+	return
+}
+
+// 批量查询电子发票.
+func (clt *Client) BatchGetInvoiceInfo(items []InvoiceItem) (invoices []Invoice, err error) {
+	if len(items) == 0 {
+		err = errors.New("empty items")
+		return
+	}
+
+	var request = struct {
+		ItemList []InvoiceItem `json:"item_list"`
+	}{
+		ItemList: items,
+	}
+
+	var result struct {
+		corp.Error
+		ItemList []Invoice `json:"item_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/card/invoice/reimburse/getinvoiceinfobatch?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	invoices = result.ItemList
+	return
+}
+
+// 更新发票状态.
+//
+//  reimburseStatus: 发票的报销状态, ReimburseStatusInit, ReimburseStatusLock, ReimburseStatusClosure
+func (clt *Client) UpdateInvoiceStatus(cardId, encryptCode, reimburseStatus string) (err error) {
+	var request = struct {
+		CardId          string `json:"card_id"`
+		EncryptCode     string `json:"encrypt_code"`
+		ReimburseStatus string `json:"reimburse_status"`
+	}{
+		CardId:          cardId,
+		EncryptCode:     encryptCode,
+		ReimburseStatus: reimburseStatus,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/card/invoice/reimburse/updateinvoicestatus?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 批量更新同一个用户的发票状态.
+//
+//  openId:          用户的 openid
+//  reimburseStatus: 发票的报销状态, ReimburseStatusInit, ReimburseStatusLock, ReimburseStatusClosure
+//
+//  NOTE: 微信一次最多处理 BatchUpdateInvoiceCountLimit 张发票, invoices 超过这个数目会分多次请求,
+//  某一次请求失败后会停止后续的请求并返回错误, 之前的请求已经更新的发票不会回滚.
+func (clt *Client) BatchUpdateInvoiceStatus(openId, reimburseStatus string, invoices []InvoiceItem) (err error) {
+	if len(invoices) == 0 {
+		err = errors.New("empty invoices")
+		return
+	}
+
+	for start := 0; start < len(invoices); start += BatchUpdateInvoiceCountLimit {
+		end := start + BatchUpdateInvoiceCountLimit
+		if end > len(invoices) {
+			end = len(invoices)
+		}
+		if err = clt.batchUpdateInvoiceStatus(openId, reimburseStatus, invoices[start:end]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (clt *Client) batchUpdateInvoiceStatus(openId, reimburseStatus string, invoices []InvoiceItem) (err error) {
+	var request = struct {
+		OpenId          string        `json:"openid"`
+		ReimburseStatus string        `json:"reimburse_status"`
+		InvoiceList     []InvoiceItem `json:"invoice_list"`
+	}{
+		OpenId:          openId,
+		ReimburseStatus: reimburseStatus,
+		InvoiceList:     invoices,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/card/invoice/reimburse/updatestatusbatch?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -31,3 +31,42 @@ func WXConfigSign(jsapiTicket, nonceStr, timestamp, url string) (signature strin
 	hashsum := sha1.Sum(buf)
 	return hex.EncodeToString(hashsum[:])
 }
+
+// wx.config 的参数
+type WXConfig struct {
+	AppId     string `json:"appId"`     // 企业的 corpid
+	Timestamp string `json:"timestamp"` // 生成签名的时间戳
+	NonceStr  string `json:"nonceStr"`  // 生成签名的随机串
+	Signature string `json:"signature"` // 签名
+}
+
+// 生成 wx.config 的参数, jsapiTicket 为 DefaultTicketServer 获取的企业的 jsapi_ticket.
+func NewWXConfig(corpId, jsapiTicket, nonceStr, timestamp, url string) *WXConfig {
+	return &WXConfig{
+		AppId:     corpId,
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: WXConfigSign(jsapiTicket, nonceStr, timestamp, url),
+	}
+}
+
+// wx.agentConfig 的参数
+type WXAgentConfig struct {
+	CorpId    string `json:"corpid"`    // 企业的 corpid
+	AgentId   string `json:"agentid"`   // 应用的 agentid
+	Timestamp string `json:"timestamp"` // 生成签名的时间戳
+	NonceStr  string `json:"nonceStr"`  // 生成签名的随机串
+	Signature string `json:"signature"` // 签名
+}
+
+// 生成 wx.agentConfig 的参数, jsapiTicket 为 NewAgentTicketServer 创建的 TicketServer 获取的应用的 jsapi_ticket.
+//  签名算法和 wx.config 一样, 只是用的 jsapi_ticket 不同.
+func NewWXAgentConfig(corpId, agentId, jsapiTicket, nonceStr, timestamp, url string) *WXAgentConfig {
+	return &WXAgentConfig{
+		CorpId:    corpId,
+		AgentId:   agentId,
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: WXConfigSign(jsapiTicket, nonceStr, timestamp, url),
+	}
+}
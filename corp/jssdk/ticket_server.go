@@ -40,7 +40,8 @@ var _ TicketServer = (*DefaultTicketServer)(nil)
 //  2. 因为 DefaultTicketServer 同时也是一个简单的中控服务器, 而不是仅仅实现 TicketServer 接口,
 //     所以整个系统只能存在一个 DefaultTicketServer 实例!
 type DefaultTicketServer struct {
-	corpClient *corp.Client
+	corpClient    *corp.Client
+	incompleteURL string // 获取 jsapi_ticket 的 url, 不包含 access_token 的值
 
 	resetTickerChan chan time.Duration // 用于重置 ticketDaemon 里的 ticker
 
@@ -58,12 +59,26 @@ type DefaultTicketServer struct {
 
 // 创建一个新的 DefaultTicketServer.
 func NewDefaultTicketServer(clt *corp.Client) (srv *DefaultTicketServer) {
+	return newTicketServer(clt, "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token=")
+}
+
+// 创建一个获取应用的 jsapi_ticket(type=agent_config) 的 DefaultTicketServer, 用于 wx.agentConfig 的签名;
+// NewDefaultTicketServer 获取的是企业的 jsapi_ticket, 用于 wx.config 的签名.
+//  NOTE:
+//  1. 应用的 jsapi_ticket 和应用绑定, clt 必须使用这个应用的 Secret 获取的 access_token;
+//  2. 和 NewDefaultTicketServer 一样, 整个系统每个应用只能存在一个这样的实例!
+func NewAgentTicketServer(clt *corp.Client) (srv *DefaultTicketServer) {
+	return newTicketServer(clt, "https://qyapi.weixin.qq.com/cgi-bin/ticket/get?type=agent_config&access_token=")
+}
+
+func newTicketServer(clt *corp.Client, incompleteURL string) (srv *DefaultTicketServer) {
 	if clt == nil {
 		panic("nil corp.Client")
 	}
 
 	srv = &DefaultTicketServer{
 		corpClient:      clt,
+		incompleteURL:   incompleteURL,
 		resetTickerChan: make(chan time.Duration),
 	}
 
@@ -152,8 +167,7 @@ func (srv *DefaultTicketServer) getTicket() (ticket ticketInfo, cached bool, err
 		ticketInfo
 	}
 
-	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/get_jsapi_ticket?access_token="
-	if err = srv.corpClient.GetJSON(incompleteURL, &result); err != nil {
+	if err = srv.corpClient.GetJSON(srv.incompleteURL, &result); err != nil {
 		srv.ticketCache.Lock()
 		srv.ticketCache.Ticket = ""
 		srv.ticketCache.Unlock()
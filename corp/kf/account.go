@@ -0,0 +1,191 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package kf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 获取客服帐号列表每次最多的个数
+const AccountListLimit = 100
+
+// 客服帐号
+type Account struct {
+	OpenKfId string `json:"open_kfid"` // 客服帐号ID
+	Name     string `json:"name"`      // 客服名称
+	Avatar   string `json:"avatar"`    // 客服头像URL
+}
+
+// 获取客服帐号列表.
+//  offset: 分页的偏移量, 从 0 开始
+//  limit:  本次获取的个数, 1 到 AccountListLimit 之间
+func (clt *Client) AccountList(offset, limit int) (list []Account, err error) {
+	if offset < 0 {
+		err = fmt.Errorf("invalid offset: %d", offset)
+		return
+	}
+	if limit < 1 || limit > AccountListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", AccountListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	var result struct {
+		corp.Error
+		AccountList []Account `json:"account_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.AccountList
+	return
+}
+
+// 添加客服帐号.
+//  name:    客服名称, 不多于 16 个字符
+//  mediaId: 客服头像临时素材, 用上传临时素材接口获取, 见 media.Client.UploadImage
+func (clt *Client) AccountAdd(name, mediaId string) (openKfId string, err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		Name    string `json:"name"`
+		MediaId string `json:"media_id"`
+	}{
+		Name:    name,
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		corp.Error
+		OpenKfId string `json:"open_kfid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/add?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openKfId = result.OpenKfId
+	return
+}
+
+// 修改客服帐号, name 和 mediaId 为空的不修改.
+func (clt *Client) AccountUpdate(openKfId, name, mediaId string) (err error) {
+	if openKfId == "" {
+		return errors.New("empty openKfId")
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+		Name     string `json:"name,omitempty"`
+		MediaId  string `json:"media_id,omitempty"`
+	}{
+		OpenKfId: openKfId,
+		Name:     name,
+		MediaId:  mediaId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/update?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除客服帐号.
+func (clt *Client) AccountDelete(openKfId string) (err error) {
+	if openKfId == "" {
+		return errors.New("empty openKfId")
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+	}{
+		OpenKfId: openKfId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/del?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取客服帐号链接, 客户点击链接即可进入会话.
+//  scene: 场景值, 不多于 32 字节, 只能是英文字母, 数字, 下划线和中划线; 会在 enter_session 事件里回调, 可以为空
+func (clt *Client) AddContactWay(openKfId, scene string) (url string, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+		Scene    string `json:"scene,omitempty"`
+	}{
+		OpenKfId: openKfId,
+		Scene:    scene,
+	}
+
+	var result struct {
+		corp.Error
+		URL string `json:"url"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/add_contact_way?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	url = result.URL
+	return
+}
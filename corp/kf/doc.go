@@ -0,0 +1,10 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信客服接口.
+//
+//  微信客服的消息和事件不会直接推送, 微信服务器只推送 kf_msg_or_event 事件通知有新的消息,
+//  收到事件之后需要用事件里的 Token 调用 kf/sync_msg 接口拉取, 见 request.GetKfMsgOrEventEvent.
+package kf
@@ -0,0 +1,113 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package kf
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 每次添加(删除)接待人员最多的个数
+const ServicerLimit = 100
+
+// 接待人员的接待状态
+const (
+	ServicerStatusReceiving = 0 // 接待中
+	ServicerStatusStopped   = 1 // 停止接待
+)
+
+// 接待人员
+type Servicer struct {
+	UserId string `json:"userid"` // 接待人员的 userid
+	Status int    `json:"status"` // 接待人员的接待状态, ServicerStatusReceiving, ServicerStatusStopped
+}
+
+// 添加(删除)接待人员的结果
+type ServicerResult struct {
+	UserId  string `json:"userid"`
+	ErrCode int    `json:"errcode"` // 该 userid 的操作结果, 0 表示成功
+	ErrMsg  string `json:"errmsg"`
+}
+
+// 获取客服帐号的接待人员列表.
+func (clt *Client) ServicerList(openKfId string) (list []Servicer, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		ServicerList []Servicer `json:"servicer_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/list?open_kfid=" +
+		url.QueryEscape(openKfId) + "&access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.ServicerList
+	return
+}
+
+// 添加接待人员, 接待人员必须在客服应用的可见范围内.
+//  NOTE: 整体调用成功时部分 userid 仍然可能失败, 需要检查 results 里每个 userid 的 ErrCode.
+func (clt *Client) ServicerAdd(openKfId string, userIdList []string) (results []ServicerResult, err error) {
+	return clt.postServicer("https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/add?access_token=", openKfId, userIdList)
+}
+
+// 删除接待人员.
+//  NOTE: 整体调用成功时部分 userid 仍然可能失败, 需要检查 results 里每个 userid 的 ErrCode.
+func (clt *Client) ServicerDelete(openKfId string, userIdList []string) (results []ServicerResult, err error) {
+	return clt.postServicer("https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/del?access_token=", openKfId, userIdList)
+}
+
+func (clt *Client) postServicer(incompleteURL, openKfId string, userIdList []string) (results []ServicerResult, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+	if len(userIdList) == 0 {
+		err = errors.New("empty userIdList")
+		return
+	}
+	if len(userIdList) > ServicerLimit {
+		err = fmt.Errorf("userIdList 最多 %d 个, 现在为 %d", ServicerLimit, len(userIdList))
+		return
+	}
+
+	var request = struct {
+		OpenKfId   string   `json:"open_kfid"`
+		UserIdList []string `json:"userid_list"`
+	}{
+		OpenKfId:   openKfId,
+		UserIdList: userIdList,
+	}
+
+	var result struct {
+		corp.Error
+		ResultList []ServicerResult `json:"result_list"`
+	}
+
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.ResultList
+	return
+}
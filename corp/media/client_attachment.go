@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	AttachmentMediaTypeMoment = 1 // 朋友圈
+)
+
+const (
+	AttachmentTypeImage = 1 // 图片, 10MB, 支持 JPG, PNG 格式
+	AttachmentTypeVideo = 2 // 视频, 10MB, 支持 MP4 格式
+)
+
+// 上传附件资源返回的数据结构
+type AttachmentInfo struct {
+	MediaType string `json:"type"`       // 媒体文件类型, 分别有图片(image), 视频(video)
+	MediaId   string `json:"media_id"`   // 媒体文件上传后获取的唯一标识, 3天内有效
+	CreatedAt int64  `json:"created_at"` // 媒体文件上传时间戳
+}
+
+// 上传附件资源, 比如客户朋友圈的图片和视频.
+//  mediaType:      附件资源使用场景, 参考 AttachmentMediaTypeXXX;
+//  attachmentType: 附件类型, 参考 AttachmentTypeXXX.
+func (clt *Client) UploadAttachment(mediaType, attachmentType int, _filepath string) (info *AttachmentInfo, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.uploadAttachmentFromReader(mediaType, attachmentType, filepath.Base(_filepath), file)
+}
+
+// 上传附件资源, 参考 UploadAttachment.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadAttachmentFromReader(mediaType, attachmentType int, filename string, reader io.Reader) (info *AttachmentInfo, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.uploadAttachmentFromReader(mediaType, attachmentType, filename, reader)
+}
+
+func (clt *Client) uploadAttachmentFromReader(mediaType, attachmentType int, filename string, reader io.Reader) (info *AttachmentInfo, err error) {
+	var limit int64
+	switch attachmentType {
+	case AttachmentTypeImage:
+		limit = MediaImageSizeLimit
+	case AttachmentTypeVideo:
+		limit = MediaVideoSizeLimit
+	default:
+		err = errors.New("unknown attachmentType: " + strconv.Itoa(attachmentType))
+		return
+	}
+	reader = &sizeLimitedReader{
+		reader: reader,
+		limit:  limit,
+	}
+
+	var result struct {
+		corp.Error
+		AttachmentInfo
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/media/upload_attachment?media_type=" +
+		strconv.Itoa(mediaType) + "&attachment_type=" + strconv.Itoa(attachmentType) + "&access_token="
+	fields := []corp.MultipartFormField{{
+		ContentType: 0,
+		FieldName:   "media",
+		FileName:    filename,
+		Value:       reader,
+	}}
+	if err = ((*corp.Client)(clt)).PostMultipartForm(incompleteURL, fields, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.AttachmentInfo
+	return
+}
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 
 	"github.com/chanxuehong/wechat/corp"
 )
@@ -45,6 +46,28 @@ func (clt *Client) DownloadMediaToWriter(mediaId string, writer io.Writer) (writ
 
 // 下载多媒体到 io.Writer.
 func (clt *Client) downloadMediaToWriter(mediaId string, writer io.Writer) (written int64, err error) {
+	body, err := clt.OpenMedia(mediaId)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return io.Copy(writer, body)
+}
+
+// 获取多媒体的数据流, 调用者读取完毕后需要关闭 body.
+func (clt *Client) OpenMedia(mediaId string) (body io.ReadCloser, err error) {
+	httpResp, err := clt.openMedia(mediaId, 0)
+	if err != nil {
+		return
+	}
+	body = httpResp.Body
+	return
+}
+
+// 获取多媒体的 http 响应, offset > 0 时带上 Range 头从 offset 处开始下载,
+// 返回的 httpResp.StatusCode 是 http.StatusOK 或者 http.StatusPartialContent.
+func (clt *Client) openMedia(mediaId string, offset int64) (httpResp *http.Response, err error) {
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -55,32 +78,45 @@ RETRY:
 	finalURL := "https://qyapi.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) +
 		"&access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpReq, err := http.NewRequest("GET", finalURL, nil)
 	if err != nil {
 		return
 	}
-	defer httpResp.Body.Close()
+	if offset > 0 {
+		httpReq.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
 
-	if httpResp.StatusCode != http.StatusOK {
+	httpResp, err = clt.HttpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusPartialContent {
+		httpResp.Body.Close()
 		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		httpResp = nil
 		return
 	}
 
 	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
 	if ContentType != "text/plain" && ContentType != "application/json" { // 返回的是媒体流
-		return io.Copy(writer, httpResp.Body)
+		return
 	}
 
 	// 返回的是错误信息
 	var result corp.Error
-	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+	err = json.NewDecoder(httpResp.Body).Decode(&result)
+	httpResp.Body.Close()
+	httpResp = nil
+	if err != nil {
 		return
 	}
 
 	switch result.ErrCode {
 	case corp.ErrCodeOK:
-		return // 基本不会出现
-	case corp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
+		err = errors.New("no media data") // 基本不会出现
+		return
+	case corp.ErrCodeInvalidAccessToken, corp.ErrCodeAccessTokenExpired: // 失效(过期)重试一次
 		corp.LogInfoln("[WECHAT_RETRY] err_code:", result.ErrCode, ", err_msg:", result.ErrMsg)
 		corp.LogInfoln("[WECHAT_RETRY] current token:", token)
 
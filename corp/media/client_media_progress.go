@@ -0,0 +1,89 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 下载多媒体到文件, 下载的过程中调用 progress 报告进度, 适合几百 MB 的视频, 文件这样的大文件.
+//  progress(downloaded, total): downloaded 是文件里已经有的字节数, total 是文件的总大小,
+//  微信服务器没有返回 Content-Length 的时候 total 为 -1; progress 可以为 nil.
+//
+//  如果 filepath 已经存在(比如上一次下载中断了), 会带上 Range 头从文件末尾继续下载;
+//  微信服务器不支持 Range 的时候重新下载整个文件. 下载失败的时候保留已经下载的部分, 方便下一次继续.
+//  NOTE: 文件已经完整下载的时候再次调用, 微信服务器一般返回 416 Range Not Satisfiable, 这时返回 err.
+func (clt *Client) DownloadMediaWithProgress(mediaId, filepath string, progress func(downloaded, total int64)) (written int64, err error) {
+	file, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return
+	}
+	offset := fi.Size()
+
+	httpResp, err := clt.openMedia(mediaId, offset)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	total := httpResp.ContentLength
+	if httpResp.StatusCode == http.StatusPartialContent {
+		if total = contentRangeTotal(httpResp.Header.Get("Content-Range")); total < 0 && httpResp.ContentLength >= 0 {
+			total = offset + httpResp.ContentLength
+		}
+	} else { // 不支持 Range, 从头开始下载
+		if err = file.Truncate(0); err != nil {
+			return
+		}
+		offset = 0
+	}
+
+	var reader io.Reader = httpResp.Body
+	if progress != nil {
+		progress(offset, total)
+		reader = io.TeeReader(reader, &progressWriter{
+			downloaded: offset,
+			total:      total,
+			progress:   progress,
+		})
+	}
+	return io.Copy(file, reader)
+}
+
+type progressWriter struct {
+	downloaded int64
+	total      int64
+	progress   func(downloaded, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (n int, err error) {
+	w.downloaded += int64(len(p))
+	w.progress(w.downloaded, w.total)
+	return len(p), nil
+}
+
+// 解析 Content-Range: bytes 200-1023/1024 里的文件总大小, 没有的时候返回 -1.
+func contentRangeTotal(contentRange string) int64 {
+	i := strings.LastIndexByte(contentRange, '/')
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil || total < 0 {
+		return -1
+	}
+	return total
+}
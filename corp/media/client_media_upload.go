@@ -7,6 +7,7 @@ package media
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
@@ -103,6 +104,12 @@ func (clt *Client) UploadFileFromReader(filename string, reader io.Reader) (info
 }
 
 func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.Reader) (info *MediaInfo, err error) {
+	// PostMultipartForm 会先把整个文件读到缓冲区再上传, 所以超过大小限制的文件不会被发送出去
+	reader = &sizeLimitedReader{
+		reader: reader,
+		limit:  MediaSizeLimit(mediaType),
+	}
+
 	var result struct {
 		corp.Error
 		MediaInfo
@@ -127,3 +134,22 @@ func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.R
 	info = &result.MediaInfo
 	return
 }
+
+// 读取的数据超过 limit 字节时返回错误, 读取结束时如果不足 MediaSizeMin 字节也返回错误
+type sizeLimitedReader struct {
+	reader io.Reader
+	limit  int64 // <= 0 表示不限制
+	n      int64 // 已经读取的字节数
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.n += int64(n)
+	if r.limit > 0 && r.n > r.limit {
+		return n, fmt.Errorf("媒体文件的大小不能超过 %d 字节", r.limit)
+	}
+	if err == io.EOF && r.n < MediaSizeMin {
+		return n, fmt.Errorf("媒体文件的大小不能小于 %d 字节, 现在为 %d", MediaSizeMin, r.n)
+	}
+	return
+}
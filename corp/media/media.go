@@ -12,6 +12,31 @@ const (
 	MediaTypeFile  = "file"
 )
 
+// 上传的媒体文件大小限制, 所有文件都必须大于 5 个字节
+const (
+	MediaSizeMin        = 5
+	MediaImageSizeLimit = 10 << 20 // 图片(image): 10MB, 支持 JPG, PNG 格式
+	MediaVoiceSizeLimit = 2 << 20  // 语音(voice): 2MB, 播放长度不超过60s, 仅支持 AMR 格式
+	MediaVideoSizeLimit = 10 << 20 // 视频(video): 10MB, 支持 MP4 格式
+	MediaFileSizeLimit  = 20 << 20 // 普通文件(file): 20MB
+)
+
+// 获取 mediaType 类型的媒体文件的大小限制, 未知的类型返回 0
+func MediaSizeLimit(mediaType string) int64 {
+	switch mediaType {
+	case MediaTypeImage:
+		return MediaImageSizeLimit
+	case MediaTypeVoice:
+		return MediaVoiceSizeLimit
+	case MediaTypeVideo:
+		return MediaVideoSizeLimit
+	case MediaTypeFile:
+		return MediaFileSizeLimit
+	default:
+		return 0
+	}
+}
+
 type MediaInfo struct {
 	MediaType string `json:"type"`       // 媒体文件类型, 分别有图片(image), 语音(voice), 视频(video),普通文件(file)
 	MediaId   string `json:"media_id"`   // 媒体文件上传后获取的唯一标识
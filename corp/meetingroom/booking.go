@@ -0,0 +1,146 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package meetingroom
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 预定会议室的参数
+type Booking struct {
+	MeetingRoomId int64    `json:"meetingroom_id"`      // 必须;  会议室id
+	Subject       string   `json:"subject,omitempty"`   // 非必须; 会议主题
+	StartTime     int64    `json:"start_time"`          // 必须;  预定开始时间, unixtime
+	EndTime       int64    `json:"end_time"`            // 必须;  预定结束时间, unixtime
+	Booker        string   `json:"booker"`              // 必须;  预定人的userid
+	Attendees     []string `json:"attendees,omitempty"` // 非必须; 参与人的userid列表
+}
+
+// 预定会议室的结果
+type BookingResult struct {
+	BookingId  string `json:"booking_id"`  // 会议室的预定id
+	ScheduleId string `json:"schedule_id"` // 会议关联日程的id
+}
+
+// 预定会议室.
+func (clt *Client) BookMeetingRoom(booking *Booking) (ret *BookingResult, err error) {
+	if booking == nil {
+		err = errors.New("nil Booking")
+		return
+	}
+	if booking.StartTime >= booking.EndTime {
+		err = errors.New("StartTime must be before EndTime")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		BookingResult
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/book?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, booking, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	ret = &result.BookingResult
+	return
+}
+
+// 会议室预定的状态
+const (
+	ScheduleStatusBooked    = 0 // 已预定
+	ScheduleStatusOccupied  = 2 // 已占用
+	ScheduleStatusCancelled = 3 // 已释放
+	ScheduleStatusApproving = 4 // 预定审批中
+)
+
+// 会议室的预定记录
+type Schedule struct {
+	MeetingId  string `json:"meeting_id"`  // 会议id
+	ScheduleId string `json:"schedule_id"` // 会议关联日程的id
+	StartTime  int64  `json:"start_time"`  // 开始时间
+	EndTime    int64  `json:"end_time"`    // 结束时间
+	Booker     string `json:"booker"`      // 预定人的userid
+	Status     int    `json:"status"`      // 预定的状态, ScheduleStatusBooked 等
+}
+
+// 会议室的预定信息
+type BookingRecord struct {
+	MeetingRoomId int64      `json:"meetingroom_id"`
+	Schedule      []Schedule `json:"schedule"`
+}
+
+// 查询会议室在 [startTime, endTime] 之间的预定信息.
+//  meetingRoomId: 会议室id, 为 0 时查询所有会议室
+//  startTime:     查询预定的起始时间, unixtime, 为 0 时默认为当前时间
+//  endTime:       查询预定的结束时间, unixtime, 为 0 时默认为明日0时
+func (clt *Client) GetBookingList(meetingRoomId, startTime, endTime int64) (records []BookingRecord, err error) {
+	var request = struct {
+		MeetingRoomId int64 `json:"meetingroom_id,omitempty"`
+		StartTime     int64 `json:"start_time,omitempty"`
+		EndTime       int64 `json:"end_time,omitempty"`
+	}{
+		MeetingRoomId: meetingRoomId,
+		StartTime:     startTime,
+		EndTime:       endTime,
+	}
+
+	var result struct {
+		corp.Error
+		BookingList []BookingRecord `json:"booking_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/get_booking_info?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	records = result.BookingList
+	return
+}
+
+// 取消预定会议室.
+//  keepSchedule: 是否保留日程, 为 true 时只释放会议室, 保留会议关联的日程
+func (clt *Client) CancelBooking(bookingId string, keepSchedule bool) (err error) {
+	if bookingId == "" {
+		err = errors.New("empty bookingId")
+		return
+	}
+
+	var request = struct {
+		BookingId    string `json:"booking_id"`
+		KeepSchedule int    `json:"keep_schedule"`
+	}{
+		BookingId: bookingId,
+	}
+	if keepSchedule {
+		request.KeepSchedule = 1
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/cancel_book?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
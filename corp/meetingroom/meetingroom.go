@@ -0,0 +1,145 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package meetingroom
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 会议室的设备
+const (
+	EquipmentTV              = 1 // 电视
+	EquipmentTelephone       = 2 // 电话
+	EquipmentProjector       = 3 // 投影
+	EquipmentWhiteboard      = 4 // 白板
+	EquipmentVideoConference = 5 // 视频
+)
+
+type Coordinate struct {
+	Latitude  string `json:"latitude"`  // 纬度
+	Longitude string `json:"longitude"` // 经度
+}
+
+// 会议室
+type MeetingRoom struct {
+	MeetingRoomId int64       `json:"meetingroom_id,omitempty"` // 会议室id, 添加会议室时不需要填写
+	Name          string      `json:"name"`                     // 会议室名称, 最多30个字符
+	Capacity      int         `json:"capacity"`                 // 会议室所能容纳的人数
+	City          string      `json:"city,omitempty"`           // 会议室所在的城市
+	Building      string      `json:"building,omitempty"`       // 会议室所在的楼宇
+	Floor         string      `json:"floor,omitempty"`          // 会议室所在的楼层
+	Equipment     []int       `json:"equipment,omitempty"`      // 会议室支持的设备列表, EquipmentTV 等
+	Coordinate    *Coordinate `json:"coordinate,omitempty"`     // 会议室所在建筑的经纬度
+	NeedApproval  int         `json:"need_approval,omitempty"`  // 是否需要审批, 0: 无需审批, 1: 需要审批; 只在获取会议室列表时返回
+}
+
+// 添加会议室.
+func (clt *Client) AddMeetingRoom(room *MeetingRoom) (meetingRoomId int64, err error) {
+	if room == nil {
+		err = errors.New("nil MeetingRoom")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		MeetingRoomId int64 `json:"meetingroom_id"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/add?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, room, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	meetingRoomId = result.MeetingRoomId
+	return
+}
+
+// 编辑会议室, room.MeetingRoomId 必须填写.
+func (clt *Client) UpdateMeetingRoom(room *MeetingRoom) (err error) {
+	if room == nil {
+		err = errors.New("nil MeetingRoom")
+		return
+	}
+	if room.MeetingRoomId == 0 {
+		err = errors.New("empty MeetingRoomId")
+		return
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/edit?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, room, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除会议室.
+func (clt *Client) DelMeetingRoom(meetingRoomId int64) (err error) {
+	var request = struct {
+		MeetingRoomId int64 `json:"meetingroom_id"`
+	}{
+		MeetingRoomId: meetingRoomId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/del?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询会议室的条件, 都为空时返回所有会议室.
+//  同时填写了 Building 和 Floor 时, Floor 才生效.
+type ListFilter struct {
+	City      string `json:"city,omitempty"`
+	Building  string `json:"building,omitempty"`
+	Floor     string `json:"floor,omitempty"`
+	Equipment []int  `json:"equipment,omitempty"`
+}
+
+// 查询会议室.
+//  filter 可以为 nil.
+func (clt *Client) GetMeetingRoomList(filter *ListFilter) (rooms []MeetingRoom, err error) {
+	if filter == nil {
+		filter = &ListFilter{}
+	}
+
+	var result struct {
+		corp.Error
+		MeetingRoomList []MeetingRoom `json:"meetingroom_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/oa/meetingroom/list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, filter, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rooms = result.MeetingRoomList
+	return
+}
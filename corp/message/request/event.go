@@ -14,6 +14,9 @@ const (
 	EventTypeSubscribe   = "subscribe"   // 订阅, 包括点击订阅和扫描二维码
 	EventTypeUnsubscribe = "unsubscribe" // 取消订阅
 	EventTypeLocation    = "LOCATION"    // 上报地理位置事件
+
+	EventTypeTaskCardClick = "taskcard_click"  // 点击任务卡片按钮
+	EventTypeKfMsgOrEvent  = "kf_msg_or_event" // 微信客服有新的消息或者事件
 )
 
 // 关注事件
@@ -67,3 +70,45 @@ func GetLocationEvent(msg *corp.MixedMessage) *LocationEvent {
 		Precision:     msg.Precision,
 	}
 }
+
+// 点击任务卡片按钮事件
+//  收到事件之后应该尽快调用 send.Client.UpdateTaskCard 更新卡片的状态, 否则成员看到的还是原来的按钮.
+type TaskCardClickEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event    string `xml:"Event"    json:"Event"`    // 事件类型, 此时固定为: taskcard_click
+	EventKey string `xml:"EventKey" json:"EventKey"` // 成员点击的按钮的 key
+	TaskId   string `xml:"TaskId"   json:"TaskId"`   // 发送任务卡片时指定的 task_id
+	AgentId  int64  `xml:"AgentID"  json:"AgentID"`  // 企业应用的id
+}
+
+func GetTaskCardClickEvent(msg *corp.MixedMessage) *TaskCardClickEvent {
+	return &TaskCardClickEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		EventKey:      msg.EventKey,
+		TaskId:        msg.TaskId,
+		AgentId:       msg.AgentId,
+	}
+}
+
+// 微信客服消息(事件)通知
+//  事件里没有消息的内容, 需要在 10 分钟内用 Token 调用 kf/sync_msg 接口拉取, Token 过期以后拉取的频率会受到限制.
+type KfMsgOrEventEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	corp.MessageHeader
+
+	Event    string `xml:"Event"    json:"Event"`    // 事件类型, 此时固定为: kf_msg_or_event
+	Token    string `xml:"Token"    json:"Token"`    // 调用拉取消息接口时需要传此 token
+	OpenKfId string `xml:"OpenKfId" json:"OpenKfId"` // 有新消息的客服帐号
+}
+
+func GetKfMsgOrEventEvent(msg *corp.MixedMessage) *KfMsgOrEventEvent {
+	return &KfMsgOrEventEvent{
+		MessageHeader: msg.MessageHeader,
+		Event:         msg.Event,
+		Token:         msg.Token,
+		OpenKfId:      msg.OpenKfId,
+	}
+}
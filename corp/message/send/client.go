@@ -23,6 +23,10 @@ type Result struct {
 	InvalidUser  string `json:"invaliduser"`
 	InvalidParty string `json:"invalidparty"`
 	InvalidTag   string `json:"invalidtag"`
+
+	UnlicensedUser string `json:"unlicenseduser"` // 没有基础接口许可(包含已过期)的userid
+	MsgId          string `json:"msgid"`          // 消息id, 用于撤回应用消息
+	ResponseCode   string `json:"response_code"`  // 仅消息类型为"按钮交互型", "投票选择型"和"多项选择型"的模板卡片消息返回, 用于更新模版卡片消息
 }
 
 func (clt *Client) SendText(msg *Text) (r *Result, err error) {
@@ -87,6 +91,121 @@ func (clt *Client) SendMPNews(msg *MPNews) (r *Result, err error) {
 	return clt.send(msg)
 }
 
+func (clt *Client) SendTextCard(msg *TextCard) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt *Client) SendMarkdown(msg *Markdown) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt *Client) SendMiniProgramNotice(msg *MiniProgramNotice) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	if err = msg.CheckValid(); err != nil {
+		return
+	}
+	return clt.send(msg)
+}
+
+func (clt *Client) SendTemplateCard(msg *TemplateCard) (r *Result, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	if err = msg.CheckValid(); err != nil {
+		return
+	}
+	return clt.send(msg)
+}
+
+// 撤回应用消息, 只能撤回24小时内通过发送应用消息接口推送的消息.
+//  msgId: 发送应用消息时返回的 Result.MsgId
+func (clt *Client) Recall(msgId string) (err error) {
+	if msgId == "" {
+		err = errors.New("empty msgId")
+		return
+	}
+
+	var request = struct {
+		MsgId string `json:"msgid"`
+	}{
+		MsgId: msgId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/message/recall?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 更新任务卡片消息的状态, 一般在收到 request.EventTypeTaskCardClick 事件之后调用.
+//  agentId:     企业应用的id
+//  taskId:      发送任务卡片消息时指定的 task_id
+//  userIds:     要更新卡片状态的成员 UserID 列表, 最多 1000 个
+//  replaceName: 替换卡片按钮的文字, 比如 "已批准"
+func (clt *Client) UpdateTaskCard(agentId int64, taskId string, userIds []string, replaceName string) (invalidUser []string, err error) {
+	if taskId == "" {
+		err = errors.New("empty taskId")
+		return
+	}
+	if len(userIds) == 0 {
+		err = errors.New("empty userIds")
+		return
+	}
+	if replaceName == "" {
+		err = errors.New("empty replaceName")
+		return
+	}
+
+	var request = struct {
+		UserIds     []string `json:"userids"`
+		AgentId     int64    `json:"agentid"`
+		TaskId      string   `json:"task_id"`
+		ReplaceName string   `json:"replace_name"`
+	}{
+		UserIds:     userIds,
+		AgentId:     agentId,
+		TaskId:      taskId,
+		ReplaceName: replaceName,
+	}
+
+	var result struct {
+		corp.Error
+		InvalidUser []string `json:"invaliduser"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/message/update_taskcard?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	invalidUser = result.InvalidUser
+	return
+}
+
 func (clt *Client) send(msg interface{}) (r *Result, err error) {
 	var result struct {
 		corp.Error
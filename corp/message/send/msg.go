@@ -18,6 +18,11 @@ const (
 	MsgTypeFile   = "file"
 	MsgTypeNews   = "news"
 	MsgTypeMPNews = "mpnews"
+
+	MsgTypeTextCard          = "textcard"
+	MsgTypeMarkdown          = "markdown"
+	MsgTypeMiniProgramNotice = "miniprogram_notice"
+	MsgTypeTemplateCard      = "template_card"
 )
 
 type MessageHeader struct {
@@ -144,3 +149,161 @@ func (this *MPNews) CheckValid() (err error) {
 	}
 	return
 }
+
+// 文本卡片消息
+type TextCard struct {
+	MessageHeader
+
+	TextCard struct {
+		Title       string `json:"title"`            // 标题, 不超过128个字节
+		Description string `json:"description"`      // 描述, 不超过512个字节, 支持 div 标签的 gray, normal, highlight 三种 class
+		URL         string `json:"url"`              // 点击后跳转的链接
+		BtnText     string `json:"btntxt,omitempty"` // 按钮文字, 默认为"详情", 不超过4个文字
+	} `json:"textcard"`
+}
+
+// markdown 消息, 目前仅支持 markdown 语法的子集, 注意沒有 Safe 字段.
+type Markdown struct {
+	MessageHeader
+
+	Markdown struct {
+		Content string `json:"content"` // markdown 内容, 最长不超过2048个字节, 必须是utf8编码
+	} `json:"markdown"`
+}
+
+type MiniProgramNoticeItem struct {
+	Key   string `json:"key"`   // 长度10个汉字以内
+	Value string `json:"value"` // 长度30个汉字以内
+}
+
+const MiniProgramNoticeItemCountLimit = 10
+
+// 小程序通知消息, 仅支持关联了小程序的应用发送, 注意沒有 Safe 字段.
+type MiniProgramNotice struct {
+	MessageHeader
+
+	MiniProgramNotice struct {
+		AppId             string                  `json:"appid"`                         // 小程序appid, 必须是与当前应用关联的小程序
+		Page              string                  `json:"page,omitempty"`                // 点击消息卡片后的小程序页面
+		Title             string                  `json:"title"`                         // 消息标题, 长度限制4-12个汉字
+		Description       string                  `json:"description,omitempty"`         // 消息描述, 长度限制4-12个汉字
+		EmphasisFirstItem bool                    `json:"emphasis_first_item,omitempty"` // 是否放大第一个 content_item
+		ContentItem       []MiniProgramNoticeItem `json:"content_item,omitempty"`        // 消息内容键值对, 最多允许10个 item
+	} `json:"miniprogram_notice"`
+}
+
+// 检查 MiniProgramNotice 是否有效, 有效返回 nil, 否则返回错误信息
+func (this *MiniProgramNotice) CheckValid() (err error) {
+	if this.MiniProgramNotice.AppId == "" {
+		err = errors.New("小程序通知消息的 appid 不能为空")
+		return
+	}
+	if n := len(this.MiniProgramNotice.ContentItem); n > MiniProgramNoticeItemCountLimit {
+		err = fmt.Errorf("小程序通知消息的 content_item 个数不能超过 %d, 现在为 %d", MiniProgramNoticeItemCountLimit, n)
+		return
+	}
+	return
+}
+
+// 模版卡片的类型
+const (
+	TemplateCardTypeTextNotice          = "text_notice"
+	TemplateCardTypeNewsNotice          = "news_notice"
+	TemplateCardTypeButtonInteraction   = "button_interaction"
+	TemplateCardTypeVoteInteraction     = "vote_interaction"
+	TemplateCardTypeMultipleInteraction = "multiple_interaction"
+)
+
+type TemplateCardSource struct {
+	IconURL   string `json:"icon_url,omitempty"`   // 来源图片的url
+	Desc      string `json:"desc,omitempty"`       // 来源图片的描述, 建议不超过13个字
+	DescColor int    `json:"desc_color,omitempty"` // 来源文字的颜色, 0(默认): 灰色, 1: 黑色, 2: 红色, 3: 绿色
+}
+
+type TemplateCardMainTitle struct {
+	Title string `json:"title,omitempty"` // 一级标题, 建议不超过36个字
+	Desc  string `json:"desc,omitempty"`  // 标题辅助信息, 建议不超过44个字
+}
+
+type TemplateCardEmphasisContent struct {
+	Title string `json:"title,omitempty"` // 关键数据样式的数据内容, 建议不超过14个字
+	Desc  string `json:"desc,omitempty"`  // 关键数据样式的数据描述内容, 建议不超过22个字
+}
+
+type TemplateCardHorizontalContent struct {
+	Type    int    `json:"type,omitempty"`     // 链接类型, 0: 普通文本, 1: url, 2: 附件, 3: 成员详情
+	KeyName string `json:"keyname"`            // 二级标题, 建议不超过5个字
+	Value   string `json:"value,omitempty"`    // 二级文本, 建议不超过30个字
+	URL     string `json:"url,omitempty"`      // type 为 1 时必填
+	MediaId string `json:"media_id,omitempty"` // type 为 2 时必填
+	UserId  string `json:"userid,omitempty"`   // type 为 3 时必填
+}
+
+type TemplateCardJump struct {
+	Type     int    `json:"type,omitempty"`     // 跳转链接类型, 0: 不跳转, 1: url, 2: 小程序
+	Title    string `json:"title"`              // 跳转链接样式的文案内容, 建议不超过18个字
+	URL      string `json:"url,omitempty"`      // type 为 1 时必填
+	AppId    string `json:"appid,omitempty"`    // type 为 2 时必填
+	PagePath string `json:"pagepath,omitempty"` // type 为 2 时选填
+}
+
+type TemplateCardAction struct {
+	Type     int    `json:"type"`               // 跳转事件类型, 1: url, 2: 小程序
+	URL      string `json:"url,omitempty"`      // type 为 1 时必填
+	AppId    string `json:"appid,omitempty"`    // type 为 2 时必填
+	PagePath string `json:"pagepath,omitempty"` // type 为 2 时选填
+}
+
+type TemplateCardButton struct {
+	Text  string `json:"text"`            // 按钮文案, 建议不超过10个字
+	Style int    `json:"style,omitempty"` // 按钮样式, 目前可填1~4, 不填或错填默认1
+	Key   string `json:"key"`             // 按钮 key 值, 用户点击后会产生回调事件将本参数作为 EventKey 返回, 最长支持1024字节, 不可重复
+}
+
+type TemplateCardImage struct {
+	URL         string  `json:"url"`                    // 图片的url
+	AspectRatio float64 `json:"aspect_ratio,omitempty"` // 图片的宽高比, 宽高比要小于2.25, 大于1.3, 不填该参数默认1.3
+}
+
+// 模版卡片, 不同的 CardType 使用的字段不同, 具体参考微信文档.
+type TemplateCardContent struct {
+	CardType              string                          `json:"card_type"`                         // 模板卡片类型
+	Source                *TemplateCardSource             `json:"source,omitempty"`                  // 卡片来源样式信息
+	MainTitle             TemplateCardMainTitle           `json:"main_title"`                        // 一级标题
+	EmphasisContent       *TemplateCardEmphasisContent    `json:"emphasis_content,omitempty"`        // 关键数据样式, text_notice 才有
+	CardImage             *TemplateCardImage              `json:"card_image,omitempty"`              // 图片样式, news_notice 才有
+	SubTitleText          string                          `json:"sub_title_text,omitempty"`          // 二级普通文本, 建议不超过160个字
+	HorizontalContentList []TemplateCardHorizontalContent `json:"horizontal_content_list,omitempty"` // 二级标题+文本列表, 列表长度不超过6
+	JumpList              []TemplateCardJump              `json:"jump_list,omitempty"`               // 跳转指引样式的列表, 列表长度不超过3
+	CardAction            *TemplateCardAction             `json:"card_action,omitempty"`             // 整体卡片的点击跳转事件, text_notice 和 news_notice 必填
+	TaskId                string                          `json:"task_id,omitempty"`                 // 任务id, 同一个应用任务id不能重复, 交互类的卡片必填
+	ButtonList            []TemplateCardButton            `json:"button_list,omitempty"`             // 按钮列表, 列表长度不超过6, button_interaction 才有
+}
+
+// 模板卡片消息, 注意沒有 Safe 字段.
+type TemplateCard struct {
+	MessageHeader
+
+	TemplateCard TemplateCardContent `json:"template_card"`
+}
+
+// 检查 TemplateCard 是否有效, 有效返回 nil, 否则返回错误信息
+func (this *TemplateCard) CheckValid() (err error) {
+	card := &this.TemplateCard
+	switch card.CardType {
+	case TemplateCardTypeTextNotice, TemplateCardTypeNewsNotice:
+		if card.CardAction == nil {
+			err = fmt.Errorf("%s 模板卡片的 card_action 不能为空", card.CardType)
+			return
+		}
+	case TemplateCardTypeButtonInteraction, TemplateCardTypeVoteInteraction, TemplateCardTypeMultipleInteraction:
+		if card.TaskId == "" {
+			err = fmt.Errorf("%s 模板卡片的 task_id 不能为空", card.CardType)
+			return
+		}
+	default:
+		err = fmt.Errorf("未知的模板卡片类型: %s", card.CardType)
+		return
+	}
+	return
+}
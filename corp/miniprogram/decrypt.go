@@ -0,0 +1,125 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 加密数据里的水印, 用于校验数据的来源
+type Watermark struct {
+	AppId     string `json:"appid"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// wx.getUserInfo 解密后的用户信息
+type UserInfo struct {
+	OpenId    string    `json:"openId"`
+	UnionId   string    `json:"unionId"`
+	NickName  string    `json:"nickName"`
+	Gender    int       `json:"gender"`
+	City      string    `json:"city"`
+	Province  string    `json:"province"`
+	Country   string    `json:"country"`
+	AvatarURL string    `json:"avatarUrl"`
+	Language  string    `json:"language"`
+	Watermark Watermark `json:"watermark"`
+}
+
+// getPhoneNumber 解密后的手机号
+type PhoneNumber struct {
+	PhoneNumber     string    `json:"phoneNumber"`     // 用户绑定的手机号(国外手机号会有区号)
+	PurePhoneNumber string    `json:"purePhoneNumber"` // 没有区号的手机号
+	CountryCode     string    `json:"countryCode"`     // 区号
+	Watermark       Watermark `json:"watermark"`
+}
+
+// 解密小程序的加密数据, 算法为 AES-128-CBC, PKCS#7 填充, 和公众平台小程序一样.
+//  sessionKey, encryptedData, iv 都是 base64 编码的.
+func decryptData(sessionKey, encryptedData, iv string) (plaintext []byte, err error) {
+	key, err := base64.StdEncoding.DecodeString(sessionKey)
+	if err != nil {
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return
+	}
+	ivBytes, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return
+	}
+	if len(ivBytes) != block.BlockSize() {
+		err = fmt.Errorf("the length of iv must be equal to %d", block.BlockSize())
+		return
+	}
+	if len(ciphertext) == 0 || len(ciphertext)%block.BlockSize() != 0 {
+		err = errors.New("the length of encryptedData is not a multiple of the block size")
+		return
+	}
+
+	plaintext = make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, ivBytes).CryptBlocks(plaintext, ciphertext)
+
+	// PKCS#7 去除补位
+	amountToPad := int(plaintext[len(plaintext)-1])
+	if amountToPad < 1 || amountToPad > block.BlockSize() {
+		err = fmt.Errorf("the amount to pad is invalid: %d", amountToPad)
+		return
+	}
+	plaintext = plaintext[:len(plaintext)-amountToPad]
+	return
+}
+
+// 解密 wx.getUserInfo 返回的加密数据.
+//  appId 不为空时校验水印里的 appid.
+func DecryptUserInfo(appId, sessionKey, encryptedData, iv string) (info *UserInfo, err error) {
+	plaintext, err := decryptData(sessionKey, encryptedData, iv)
+	if err != nil {
+		return
+	}
+
+	var result UserInfo
+	if err = json.Unmarshal(plaintext, &result); err != nil {
+		return
+	}
+	if appId != "" && result.Watermark.AppId != appId {
+		err = fmt.Errorf("the watermark's appid mismatch, have: %s, want: %s", result.Watermark.AppId, appId)
+		return
+	}
+	info = &result
+	return
+}
+
+// 解密 getPhoneNumber 返回的加密数据.
+//  appId 不为空时校验水印里的 appid.
+func DecryptPhoneNumber(appId, sessionKey, encryptedData, iv string) (phone *PhoneNumber, err error) {
+	plaintext, err := decryptData(sessionKey, encryptedData, iv)
+	if err != nil {
+		return
+	}
+
+	var result PhoneNumber
+	if err = json.Unmarshal(plaintext, &result); err != nil {
+		return
+	}
+	if appId != "" && result.Watermark.AppId != appId {
+		err = fmt.Errorf("the watermark's appid mismatch, have: %s, want: %s", result.Watermark.AppId, appId)
+		return
+	}
+	phone = &result
+	return
+}
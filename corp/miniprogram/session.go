@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/corp/addresslist"
+)
+
+// 企业微信小程序的登录态
+type Session struct {
+	CorpId     string `json:"corpid"`      // 用户所属企业的 corpid
+	UserId     string `json:"userid"`      // 用户在企业内的 userid, 和公众平台小程序的主要区别
+	SessionKey string `json:"session_key"` // 会话密钥, 用于解密 wx.getUserInfo 等接口返回的加密数据
+	OpenId     string `json:"openid"`      // 非企业成员时返回
+	UnionId    string `json:"unionid"`     // 满足 unionid 下发条件时返回
+}
+
+// 用 wx.qy.login 获取的 code 换取登录态.
+//  NOTE: 和公众平台小程序的 jscode2session 不同, 这个接口使用企业的 access_token 而不是 appid 和 secret.
+func (clt *Client) Code2Session(jsCode string) (session *Session, err error) {
+	if jsCode == "" {
+		err = errors.New("empty jsCode")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		Session
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/miniprogram/jscode2session?grant_type=authorization_code&js_code=" +
+		url.QueryEscape(jsCode) + "&access_token="
+	if err = ((*corp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	session = &result.Session
+	return
+}
+
+// 用 wx.qy.login 获取的 code 换取登录态, 然后获取成员的详细信息.
+//  非企业成员(没有 userid)时返回错误.
+func (clt *Client) GetUserInfo(jsCode string) (session *Session, info *addresslist.UserInfo, err error) {
+	if session, err = clt.Code2Session(jsCode); err != nil {
+		return
+	}
+	if session.UserId == "" {
+		err = errors.New("the user is not a member of the corp")
+		return
+	}
+	info, err = ((*addresslist.Client)(clt)).UserInfo(session.UserId)
+	return
+}
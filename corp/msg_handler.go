@@ -69,6 +69,10 @@ type MixedMessage struct {
 
 	Event    string `xml:"Event"    json:"Event"`
 	EventKey string `xml:"EventKey" json:"EventKey"`
+	TaskId   string `xml:"TaskId"   json:"TaskId"`
+
+	Token    string `xml:"Token"    json:"Token"`
+	OpenKfId string `xml:"OpenKfId" json:"OpenKfId"`
 
 	ScanCodeInfo struct {
 		ScanType   string `xml:"ScanType"   json:"ScanType"`
@@ -0,0 +1,14 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 会话内容存档接口.
+//
+//  NOTE: 拉取会话记录(GetChatData), 解密会话内容(DecryptData)和拉取媒体文件(GetMediaData)
+//  只能通过企业微信提供的 C 语言 SDK(libWeWorkFinanceSdk) 调用, 没有公开的 http 接口和解密算法.
+//  SDK 的封装需要用 -tags msgaudit 并且开启 cgo 编译, 否则 SDK 的方法都返回 ErrNativeSDKRequired.
+//
+//  音视频通话没有单独的通话记录接口, 通话记录和录音(需要开通音频存档)都作为会话记录拉取, 用 ParseCallMessage 解析.
+//  会话记录在企业微信只保存有限的时间, 需要及时拉取并自己保存.
+package msgaudit
@@ -0,0 +1,46 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package msgaudit
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 会话内容存档的版本
+const (
+	PermitTypeAll      = 0 // 所有版本
+	PermitTypeOffice   = 1 // 办公版
+	PermitTypeService  = 2 // 服务版
+	PermitTypeBusiness = 3 // 企业版
+)
+
+// 获取开启了会话内容存档的成员列表.
+//  permitType: 会话内容存档的版本, PermitTypeAll ~ PermitTypeBusiness
+//  NOTE: 需要使用会话内容存档的 Secret 获取的 access_token.
+func (clt *Client) GetPermitUserList(permitType int) (UserIdList []string, err error) {
+	var request = struct {
+		Type int `json:"type,omitempty"`
+	}{
+		Type: permitType,
+	}
+
+	var result struct {
+		corp.Error
+		Ids []string `json:"ids"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/msgaudit/get_permit_user_list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	UserIdList = result.Ids
+	return
+}
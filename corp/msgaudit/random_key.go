@@ -0,0 +1,60 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package msgaudit
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+)
+
+// 解密会话记录里的 encrypt_random_key.
+//  encrypt_random_key 是用企业在管理后台配置的 RSA 公钥加密(PKCS#1 v1.5)后 base64 编码的,
+//  解密后得到的明文交给 SDK 的 DecryptData 解密 encrypt_chat_msg.
+func DecryptRandomKey(privateKey *rsa.PrivateKey, encryptRandomKey string) (randomKey string, err error) {
+	if privateKey == nil {
+		err = errors.New("nil privateKey")
+		return
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptRandomKey)
+	if err != nil {
+		return
+	}
+	plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, privateKey, ciphertext)
+	if err != nil {
+		return
+	}
+	randomKey = string(plaintext)
+	return
+}
+
+// 解析 PEM 格式的 RSA 私钥, 支持 PKCS#1 和 PKCS#8.
+func ParsePrivateKey(pemBlock []byte) (privateKey *rsa.PrivateKey, err error) {
+	block, _ := pem.Decode(pemBlock)
+	if block == nil {
+		err = errors.New("invalid PEM data")
+		return
+	}
+
+	if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		err = errors.New("not a RSA private key")
+		return
+	}
+	return
+}
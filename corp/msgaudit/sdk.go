@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package msgaudit
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 没有用 msgaudit 和 cgo 编译时, SDK 的方法都返回这个错误.
+var ErrNativeSDKRequired = errors.New("msgaudit: native SDK is not available, build with -tags msgaudit and cgo enabled")
+
+// 拉取的一条会话记录, encrypt_chat_msg 需要用 SDK.DecryptData 解密.
+type ChatRecord struct {
+	Seq              uint64 `json:"seq"`                // 消息的序号, 下次拉取时传入上次拉取的最大 seq
+	MsgId            string `json:"msgid"`              // 消息id
+	PublicKeyVer     int    `json:"publickey_ver"`      // 加密此条消息使用的公钥版本号
+	EncryptRandomKey string `json:"encrypt_random_key"` // 使用公钥加密的 key
+	EncryptChatMsg   string `json:"encrypt_chat_msg"`   // 加密的消息内容
+}
+
+// 一次最多拉取的会话记录数
+const ChatDataLimit = 1000
+
+// 解析 SDK GetChatData 返回的 JSON.
+func parseChatData(data []byte) (records []ChatRecord, err error) {
+	var result struct {
+		ErrCode  int          `json:"errcode"`
+		ErrMsg   string       `json:"errmsg"`
+		ChatData []ChatRecord `json:"chatdata"`
+	}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return
+	}
+	if result.ErrCode != 0 {
+		err = fmt.Errorf("errcode: %d, errmsg: %s", result.ErrCode, result.ErrMsg)
+		return
+	}
+	records = result.ChatData
+	return
+}
+
+func checkChatDataLimit(limit uint64) error {
+	if limit == 0 || limit > ChatDataLimit {
+		return fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", ChatDataLimit, limit)
+	}
+	return nil
+}
+
+// 解密会话记录, 先用 privateKey 解密 encryptRandomKey, 再用 SDK 解密 encryptChatMsg, 返回消息的 JSON.
+func (sdk *SDK) DecryptData(privateKey *rsa.PrivateKey, encryptRandomKey, encryptChatMsg string) (msg []byte, err error) {
+	randomKey, err := DecryptRandomKey(privateKey, encryptRandomKey)
+	if err != nil {
+		return
+	}
+	return sdk.decryptData(randomKey, encryptChatMsg)
+}
@@ -0,0 +1,152 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build msgaudit,cgo
+
+package msgaudit
+
+/*
+#cgo LDFLAGS: -lWeWorkFinanceSdk_C
+#include <stdlib.h>
+#include "WeWorkFinanceSdk_C.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// 企业微信会话内容存档 SDK(libWeWorkFinanceSdk_C.so) 的封装.
+//  NOTE: 需要用 -tags msgaudit 编译, 并且 cgo 能找到 WeWorkFinanceSdk_C.h 和 libWeWorkFinanceSdk_C.so,
+//  比如设置 CGO_CFLAGS=-I/path/to/sdk CGO_LDFLAGS=-L/path/to/sdk, 运行时设置 LD_LIBRARY_PATH.
+type SDK struct {
+	ptr *C.WeWorkFinanceSdk_t
+}
+
+// 创建并初始化 SDK, 不再使用时需要调用 Close 释放.
+//  secret: 会话内容存档的 Secret
+func NewSDK(corpId, secret string) (sdk *SDK, err error) {
+	ptr := C.NewSdk()
+
+	cCorpId := C.CString(corpId)
+	defer C.free(unsafe.Pointer(cCorpId))
+	cSecret := C.CString(secret)
+	defer C.free(unsafe.Pointer(cSecret))
+
+	if ret := C.Init(ptr, cCorpId, cSecret); ret != 0 {
+		C.DestroySdk(ptr)
+		err = fmt.Errorf("msgaudit: Init failed, ret: %d", int(ret))
+		return
+	}
+	sdk = &SDK{ptr: ptr}
+	return
+}
+
+// 释放 SDK.
+func (sdk *SDK) Close() {
+	if sdk.ptr != nil {
+		C.DestroySdk(sdk.ptr)
+		sdk.ptr = nil
+	}
+}
+
+// 拉取会话记录.
+//  seq:     从指定的 seq 开始拉取消息, 首次使用请使用 0, 之后使用上次拉取的最大 seq
+//  limit:   一次拉取的消息条数, 最大值为 ChatDataLimit
+//  proxy:   代理, 比如 socks5://10.0.0.1:8081, 不需要时为空
+//  passwd:  代理的账号密码, 比如 user_name:passwd_123, 不需要时为空
+//  timeout: 超时时间, 单位秒
+func (sdk *SDK) GetChatData(seq, limit uint64, proxy, passwd string, timeout int) (records []ChatRecord, err error) {
+	if err = checkChatDataLimit(limit); err != nil {
+		return
+	}
+
+	cProxy := C.CString(proxy)
+	defer C.free(unsafe.Pointer(cProxy))
+	cPasswd := C.CString(passwd)
+	defer C.free(unsafe.Pointer(cPasswd))
+
+	slice := C.NewSlice()
+	defer C.FreeSlice(slice)
+
+	if ret := C.GetChatData(sdk.ptr, C.ulonglong(seq), C.uint(limit), cProxy, cPasswd, C.int(timeout), slice); ret != 0 {
+		err = fmt.Errorf("msgaudit: GetChatData failed, ret: %d", int(ret))
+		return
+	}
+	return parseChatData(sliceBytes(slice))
+}
+
+func (sdk *SDK) decryptData(randomKey, encryptChatMsg string) (msg []byte, err error) {
+	cKey := C.CString(randomKey)
+	defer C.free(unsafe.Pointer(cKey))
+	cMsg := C.CString(encryptChatMsg)
+	defer C.free(unsafe.Pointer(cMsg))
+
+	slice := C.NewSlice()
+	defer C.FreeSlice(slice)
+
+	if ret := C.DecryptData(cKey, cMsg, slice); ret != 0 {
+		err = fmt.Errorf("msgaudit: DecryptData failed, ret: %d", int(ret))
+		return
+	}
+	msg = sliceBytes(slice)
+	return
+}
+
+// 拉取媒体文件(图片, 语音, 音视频通话的录音等)写入 w, 大文件会分多次拉取.
+//  sdkFileId: 消息里的 sdkfileid
+//  proxy, passwd, timeout 和 GetChatData 一样
+func (sdk *SDK) GetMediaData(sdkFileId, proxy, passwd string, timeout int, w io.Writer) (written int64, err error) {
+	if sdkFileId == "" {
+		err = errors.New("empty sdkFileId")
+		return
+	}
+
+	cFileId := C.CString(sdkFileId)
+	defer C.free(unsafe.Pointer(cFileId))
+	cProxy := C.CString(proxy)
+	defer C.free(unsafe.Pointer(cProxy))
+	cPasswd := C.CString(passwd)
+	defer C.free(unsafe.Pointer(cPasswd))
+
+	var indexBuf string
+	for {
+		var finished bool
+		if indexBuf, finished, err = sdk.getMediaChunk(indexBuf, cFileId, cProxy, cPasswd, timeout, w, &written); err != nil || finished {
+			return
+		}
+	}
+}
+
+func (sdk *SDK) getMediaChunk(indexBuf string, cFileId, cProxy, cPasswd *C.char, timeout int,
+	w io.Writer, written *int64) (nextIndexBuf string, finished bool, err error) {
+
+	cIndexBuf := C.CString(indexBuf)
+	defer C.free(unsafe.Pointer(cIndexBuf))
+
+	media := C.NewMediaData()
+	defer C.FreeMediaData(media)
+
+	if ret := C.GetMediaData(sdk.ptr, cIndexBuf, cFileId, cProxy, cPasswd, C.int(timeout), media); ret != 0 {
+		err = fmt.Errorf("msgaudit: GetMediaData failed, ret: %d", int(ret))
+		return
+	}
+
+	n, err := w.Write(C.GoBytes(unsafe.Pointer(C.GetData(media)), C.GetDataLen(media)))
+	*written += int64(n)
+	if err != nil {
+		return
+	}
+	nextIndexBuf = C.GoStringN(C.GetOutIndexBuf(media), C.GetIndexLen(media))
+	finished = C.IsMediaDataFinish(media) == 1
+	return
+}
+
+func sliceBytes(slice *C.Slice_t) []byte {
+	return C.GoBytes(unsafe.Pointer(C.GetContentFromSlice(slice)), C.GetSliceLen(slice))
+}
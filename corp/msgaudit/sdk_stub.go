@@ -0,0 +1,39 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build !msgaudit !cgo
+
+package msgaudit
+
+import "io"
+
+// 没有用 msgaudit 和 cgo 编译时的 SDK, 所有方法都返回 ErrNativeSDKRequired,
+// 这样不需要会话内容存档的程序不用依赖 libWeWorkFinanceSdk_C.so 也能编译.
+type SDK struct{}
+
+func NewSDK(corpId, secret string) (sdk *SDK, err error) {
+	err = ErrNativeSDKRequired
+	return
+}
+
+func (sdk *SDK) Close() {}
+
+func (sdk *SDK) GetChatData(seq, limit uint64, proxy, passwd string, timeout int) (records []ChatRecord, err error) {
+	if err = checkChatDataLimit(limit); err != nil {
+		return
+	}
+	err = ErrNativeSDKRequired
+	return
+}
+
+func (sdk *SDK) GetMediaData(sdkFileId, proxy, passwd string, timeout int, w io.Writer) (written int64, err error) {
+	err = ErrNativeSDKRequired
+	return
+}
+
+func (sdk *SDK) decryptData(randomKey, encryptChatMsg string) (msg []byte, err error) {
+	err = ErrNativeSDKRequired
+	return
+}
@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package msgaudit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 解密后的消息类型
+const (
+	MsgTypeVoIPText         = "voiptext"           // 音视频通话
+	MsgTypeMeetingVoiceCall = "meeting_voice_call" // 音频存档(通话录音)
+)
+
+// 音视频通话的邀请类型
+const (
+	InviteTypeSingleAudio = 1 // 单人音频
+	InviteTypeSingleVideo = 2 // 单人视频
+	InviteTypeGroupAudio  = 3 // 多人音频
+	InviteTypeGroupVideo  = 4 // 多人视频
+)
+
+// 音视频通话的记录
+type VoIPText struct {
+	CallDuration int64 `json:"callduration"` // 通话时长, 单位秒
+	InviteType   int   `json:"invitetype"`   // 邀请类型, InviteTypeSingleAudio 等
+}
+
+// 音频存档里共享的文档
+type MeetingVoiceCallDemoFile struct {
+	FileName     string `json:"filename"`     // 文档名
+	DemoOperator string `json:"demooperator"` // 共享者
+	StartTime    int64  `json:"starttime"`    // 开始共享的时间
+	EndTime      int64  `json:"endtime"`      // 结束共享的时间
+}
+
+// 音频存档里的屏幕共享
+type MeetingVoiceCallShareScreen struct {
+	Share     string `json:"share"`     // 共享者
+	StartTime int64  `json:"starttime"` // 开始共享的时间
+	EndTime   int64  `json:"endtime"`   // 结束共享的时间
+}
+
+// 音频存档, 录音文件可以用 SDK.GetMediaData(SdkFileId, ...) 拉取
+type MeetingVoiceCall struct {
+	EndTime         int64                         `json:"endtime"`   // 结束时间
+	SdkFileId       string                        `json:"sdkfileid"` // 录音文件的 sdkfileid
+	DemoFileData    []MeetingVoiceCallDemoFile    `json:"demofiledata"`
+	ShareScreenData []MeetingVoiceCallShareScreen `json:"sharescreendata"`
+}
+
+// 解密后的通话相关消息, 根据 MsgType 只有一个字段不为 nil.
+type CallMessage struct {
+	MsgId   string   `json:"msgid"`
+	Action  string   `json:"action"`  // send, recall, switch
+	From    string   `json:"from"`    // 发起者
+	ToList  []string `json:"tolist"`  // 接收者
+	RoomId  string   `json:"roomid"`  // 群聊的 id, 单聊为空
+	MsgTime int64    `json:"msgtime"` // 消息的时间, 单位毫秒
+	MsgType string   `json:"msgtype"` // MsgTypeVoIPText 或者 MsgTypeMeetingVoiceCall
+	VoiceId string   `json:"voiceid"` // 音频存档的 id, 只有 MsgTypeMeetingVoiceCall 有
+
+	VoIPText         *VoIPText         `json:"voiptext,omitempty"`
+	MeetingVoiceCall *MeetingVoiceCall `json:"meeting_voice_call,omitempty"`
+}
+
+// 解析 SDK.DecryptData 返回的通话相关消息, 不是 MsgTypeVoIPText 和 MsgTypeMeetingVoiceCall 时返回错误.
+func ParseCallMessage(msg []byte) (call *CallMessage, err error) {
+	var result CallMessage
+	if err = json.Unmarshal(msg, &result); err != nil {
+		return
+	}
+	switch result.MsgType {
+	case MsgTypeVoIPText:
+		if result.VoIPText == nil {
+			err = fmt.Errorf("missing %s in message %s", MsgTypeVoIPText, result.MsgId)
+			return
+		}
+	case MsgTypeMeetingVoiceCall:
+		if result.MeetingVoiceCall == nil {
+			err = fmt.Errorf("missing %s in message %s", MsgTypeMeetingVoiceCall, result.MsgId)
+			return
+		}
+	default:
+		err = fmt.Errorf("not a call message: %s", result.MsgType)
+		return
+	}
+	call = &result
+	return
+}
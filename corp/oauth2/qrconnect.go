@@ -0,0 +1,45 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp/addresslist"
+)
+
+// 构造企业微信扫码登录(网站登录)的 URL, 用于在企业微信以外的浏览器登录第三方网站.
+//  corpId:      企业的CorpID
+//  agentId:     授权方的网页应用ID
+//  redirectURL: 扫码授权后重定向的回调链接地址, 页面将跳转至 redirect_uri?code=CODE&state=STATE,
+//               域名必须是应用设置的可信域名
+//  state:       用于防止 csrf 攻击, 重定向后原样带回
+//
+//  NOTE: 回调得到的 code 和网页授权的 code 一样用 UserInfo 或者 LoginUserInfo 换取成员信息,
+//  使用的是企业的 access_token, 没有单独的用户 access_token.
+func QRConnectURL(corpId string, agentId int64, redirectURL, state string) string {
+	return "https://open.work.weixin.qq.com/wwopen/sso/qrConnect" +
+		"?appid=" + url.QueryEscape(corpId) +
+		"&agentid=" + strconv.FormatInt(agentId, 10) +
+		"&redirect_uri=" + url.QueryEscape(redirectURL) +
+		"&state=" + url.QueryEscape(state)
+}
+
+// 根据扫码登录或者网页授权得到的 code 获取成员的详细信息.
+//  非企业成员(没有 UserId)时返回错误.
+func (clt *Client) LoginUserInfo(agentId int64, code string) (info *addresslist.UserInfo, err error) {
+	user, err := clt.UserInfo(agentId, code)
+	if err != nil {
+		return
+	}
+	if user.UserId == "" {
+		err = errors.New("the user is not a member of the corp")
+		return
+	}
+	return ((*addresslist.Client)(clt)).UserInfo(user.UserId)
+}
@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 应用授权作用域
+const (
+	ScopeBase        = "snsapi_base"        // 静默授权, 只能获取成员的基础信息(UserId, DeviceId)
+	ScopePrivateInfo = "snsapi_privateinfo" // 手动授权, 可以获取成员的详细信息, 包括头像, 二维码等敏感信息
+)
+
+// 成员在当前会话里已经授权的作用域
+type ScopeStore interface {
+	// 返回 r 所在会话已经授权的 scope, 没有授权过返回空字符串.
+	Scope(r *http.Request) (scope string, err error)
+}
+
+func scopeLevel(scope string) int {
+	switch scope {
+	case ScopeBase:
+		return 1
+	case ScopePrivateInfo:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// 返回一个检查授权作用域的 http.Handler, 会话里授权的 scope 不满足 requiredScope 时重定向到 redirectURL(r),
+// 一般是用 AuthCodeURL(corpId, redirectURL, requiredScope, state) 重新发起授权的地址:
+//  http.Handle("/profile", oauth2.RequireScope(profileHandler, store, oauth2.ScopePrivateInfo, func(r *http.Request) string {
+//      return oauth2.AuthCodeURL(corpId, callbackURL, oauth2.ScopePrivateInfo, state)
+//  }))
+//
+//  snsapi_privateinfo 包含 snsapi_base; 这里的 http.Handler 是网页授权的页面, 不是接收消息的 corp.Server.
+//  NOTE: 读取 store 出错时返回 500.
+func RequireScope(handler http.Handler, store ScopeStore, requiredScope string, redirectURL func(r *http.Request) string) http.Handler {
+	if handler == nil {
+		panic("nil http.Handler")
+	}
+	if store == nil {
+		panic("nil ScopeStore")
+	}
+	if scopeLevel(requiredScope) == 0 {
+		panic("invalid requiredScope: " + requiredScope)
+	}
+	if redirectURL == nil {
+		panic("nil redirectURL")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, err := store.Scope(r)
+		if err != nil {
+			corp.LogInfoln("[WECHAT_OAUTH2_SCOPE]", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if scopeLevel(scope) < scopeLevel(requiredScope) {
+			http.Redirect(w, r, redirectURL(r), http.StatusFound)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
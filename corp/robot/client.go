@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package robot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// 群机器人 webhook 客户端.
+//  NOTE: webhook 地址里面已经带有 key, 所以不需要 AccessTokenServer.
+type Client struct {
+	WebhookURL string // 群机器人的 webhook 地址, 比如 https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=xxx
+	HttpClient *http.Client
+}
+
+// 创建一个新的 Client.
+//  如果 clt == nil 则默认用 http.DefaultClient
+func NewClient(webhookURL string, clt *http.Client) *Client {
+	if webhookURL == "" {
+		panic("empty webhookURL")
+	}
+	if clt == nil {
+		clt = http.DefaultClient
+	}
+
+	return &Client{
+		WebhookURL: webhookURL,
+		HttpClient: clt,
+	}
+}
+
+// 发送文本消息.
+//  mentionedList:       userid 的列表, 提醒群中的指定成员, @all 表示提醒所有人, 可以为 nil;
+//  mentionedMobileList: 手机号列表, 提醒手机号对应的群成员, @all 表示提醒所有人, 可以为 nil.
+func (clt *Client) SendText(content string, mentionedList, mentionedMobileList []string) (err error) {
+	var msg Text
+	msg.MsgType = MsgTypeText
+	msg.Text.Content = content
+	msg.Text.MentionedList = mentionedList
+	msg.Text.MentionedMobileList = mentionedMobileList
+	return clt.send(&msg)
+}
+
+// 发送 markdown 消息, content 最长不超过 4096 个字节.
+func (clt *Client) SendMarkdown(content string) (err error) {
+	if n := len(content); n > MarkdownContentLengthLimit {
+		err = fmt.Errorf("markdown 内容的长度不能超过 %d 个字节, 现在为 %d", MarkdownContentLengthLimit, n)
+		return
+	}
+
+	var msg Markdown
+	msg.MsgType = MsgTypeMarkdown
+	msg.Markdown.Content = content
+	return clt.send(&msg)
+}
+
+// 发送图片消息.
+//  base64Data: 图片内容的 base64 编码;
+//  md5sum:     图片内容(base64 编码前)的 md5 值, 十六进制小写.
+func (clt *Client) SendImage(base64Data, md5sum string) (err error) {
+	if base64Data == "" {
+		err = errors.New("empty base64Data")
+		return
+	}
+	if md5sum == "" {
+		err = errors.New("empty md5sum")
+		return
+	}
+
+	var msg Image
+	msg.MsgType = MsgTypeImage
+	msg.Image.Base64 = base64Data
+	msg.Image.MD5 = md5sum
+	return clt.send(&msg)
+}
+
+// 发送图文消息, 一个图文消息支持 1 到 8 条图文.
+func (clt *Client) SendNews(articles []*Article) (err error) {
+	n := len(articles)
+	if n <= 0 {
+		err = errors.New("没有有效的图文消息")
+		return
+	}
+	if n > NewsArticleCountLimit {
+		err = fmt.Errorf("图文消息的文章个数不能超过 %d, 现在为 %d", NewsArticleCountLimit, n)
+		return
+	}
+
+	var msg News
+	msg.MsgType = MsgTypeNews
+	msg.News.Articles = articles
+	return clt.send(&msg)
+}
+
+// 发送文件消息, mediaId 通过群机器人的文件上传接口获取.
+func (clt *Client) SendFile(mediaId string) (err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var msg File
+	msg.MsgType = MsgTypeFile
+	msg.File.MediaId = mediaId
+	return clt.send(&msg)
+}
+
+// 发送模版卡片消息.
+func (clt *Client) SendTemplateCard(card *TemplateCard) (err error) {
+	if card == nil {
+		err = errors.New("nil card")
+		return
+	}
+
+	msg := TemplateCardMessage{
+		MsgType:      MsgTypeTemplateCard,
+		TemplateCard: card,
+	}
+	return clt.send(&msg)
+}
+
+func (clt *Client) send(msg interface{}) (err error) {
+	buf := textBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer textBufferPool.Put(buf)
+
+	if err = wechatjson.NewEncoder(buf).Encode(msg); err != nil {
+		return
+	}
+
+	httpResp, err := clt.HttpClient.Post(clt.WebhookURL, "application/json; charset=utf-8", buf)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+
+	var result corp.Error
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
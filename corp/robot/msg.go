@@ -0,0 +1,146 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package robot
+
+const (
+	MsgTypeText         = "text"
+	MsgTypeMarkdown     = "markdown"
+	MsgTypeImage        = "image"
+	MsgTypeNews         = "news"
+	MsgTypeFile         = "file"
+	MsgTypeTemplateCard = "template_card"
+)
+
+const (
+	MarkdownContentLengthLimit = 4096 // markdown 内容的最大长度, 单位字节, 必须是 utf8 编码
+	NewsArticleCountLimit      = 8    // 图文消息最多支持 8 条图文
+)
+
+type Text struct {
+	MsgType string `json:"msgtype"`
+
+	Text struct {
+		Content             string   `json:"content"`                         // 文本内容, 最长不超过2048个字节, 必须是utf8编码
+		MentionedList       []string `json:"mentioned_list,omitempty"`        // userid的列表, 提醒群中的指定成员(@某个成员), @all表示提醒所有人
+		MentionedMobileList []string `json:"mentioned_mobile_list,omitempty"` // 手机号列表, 提醒手机号对应的群成员(@某个成员), @all表示提醒所有人
+	} `json:"text"`
+}
+
+type Markdown struct {
+	MsgType string `json:"msgtype"`
+
+	Markdown struct {
+		Content string `json:"content"` // markdown内容, 最长不超过4096个字节, 必须是utf8编码
+	} `json:"markdown"`
+}
+
+type Image struct {
+	MsgType string `json:"msgtype"`
+
+	Image struct {
+		Base64 string `json:"base64"` // 图片内容的base64编码, 图片(base64编码前)最大不能超过2M, 支持JPG,PNG格式
+		MD5    string `json:"md5"`    // 图片内容(base64编码前)的md5值
+	} `json:"image"`
+}
+
+type Article struct {
+	Title       string `json:"title"`                 // 标题, 不超过128个字节, 超过会自动截断
+	Description string `json:"description,omitempty"` // 描述, 不超过512个字节, 超过会自动截断
+	URL         string `json:"url"`                   // 点击后跳转的链接
+	PicURL      string `json:"picurl,omitempty"`      // 图文消息的图片链接, 支持JPG, PNG格式, 较好的效果为大图 1068*455, 小图150*150
+}
+
+type News struct {
+	MsgType string `json:"msgtype"`
+
+	News struct {
+		Articles []*Article `json:"articles"` // 图文消息, 一个图文消息支持1到8条图文
+	} `json:"news"`
+}
+
+type File struct {
+	MsgType string `json:"msgtype"`
+
+	File struct {
+		MediaId string `json:"media_id"` // 文件id, 通过群机器人的文件上传接口获取
+	} `json:"file"`
+}
+
+// 模版卡片的来源样式信息
+type TemplateCardSource struct {
+	IconURL   string `json:"icon_url,omitempty"`   // 来源图片的url
+	Desc      string `json:"desc,omitempty"`       // 来源图片的描述, 建议不超过13个字
+	DescColor int    `json:"desc_color,omitempty"` // 来源文字的颜色, 目前支持: 0(默认) 灰色, 1 黑色, 2 红色, 3 绿色
+}
+
+// 模版卡片的标题信息
+type TemplateCardTitle struct {
+	Title string `json:"title,omitempty"` // 一级标题, 建议不超过26个字
+	Desc  string `json:"desc,omitempty"`  // 标题辅助信息, 建议不超过30个字
+}
+
+// 模版卡片的二级标题+文本列表
+type TemplateCardHorizontalContent struct {
+	KeyName string `json:"keyname"`            // 二级标题, 建议不超过5个字
+	Value   string `json:"value,omitempty"`    // 二级文本, 建议不超过26个字
+	Type    int    `json:"type,omitempty"`     // 链接类型, 0或不填代表是普通文本, 1 代表跳转url, 2 代表下载附件, 3 代表@员工
+	URL     string `json:"url,omitempty"`      // 链接跳转的url, type 是1时必填
+	MediaId string `json:"media_id,omitempty"` // 附件的media_id, type 是2时必填
+	UserId  string `json:"userid,omitempty"`   // 被@的成员的userid, type 是3时必填
+}
+
+// 模版卡片的跳转指引样式
+type TemplateCardJump struct {
+	Type     int    `json:"type,omitempty"`     // 跳转链接类型, 0或不填代表不是链接, 1 代表跳转url, 2 代表跳转小程序
+	Title    string `json:"title"`              // 跳转链接样式的文案内容, 建议不超过13个字
+	URL      string `json:"url,omitempty"`      // 跳转链接的url, type 是1时必填
+	AppId    string `json:"appid,omitempty"`    // 跳转链接的小程序的appid, type 是2时必填
+	PagePath string `json:"pagepath,omitempty"` // 跳转链接的小程序的pagepath, type 是2时选填
+}
+
+// 模版卡片的整体卡片点击跳转事件
+type TemplateCardAction struct {
+	Type     int    `json:"type"`               // 卡片跳转类型, 1 代表跳转url, 2 代表打开小程序
+	URL      string `json:"url,omitempty"`      // 跳转事件的url, type 是1时必填
+	AppId    string `json:"appid,omitempty"`    // 跳转事件的小程序的appid, type 是2时必填
+	PagePath string `json:"pagepath,omitempty"` // 跳转事件的小程序的pagepath, type 是2时选填
+}
+
+// 模版卡片的关键数据样式
+type TemplateCardEmphasisContent struct {
+	Title string `json:"title,omitempty"` // 关键数据样式的数据内容, 建议不超过10个字
+	Desc  string `json:"desc,omitempty"`  // 关键数据样式的数据描述内容, 建议不超过15个字
+}
+
+// 模版卡片的图片样式, 只有 news_notice 类型的卡片有
+type TemplateCardImage struct {
+	URL         string  `json:"url"`                    // 图片的url
+	AspectRatio float64 `json:"aspect_ratio,omitempty"` // 图片的宽高比, 宽高比要小于2.25, 大于1.3, 不填该参数默认1.3
+}
+
+const (
+	TemplateCardTypeTextNotice = "text_notice" // 文本通知模版卡片
+	TemplateCardTypeNewsNotice = "news_notice" // 图文展示模版卡片
+)
+
+// 模版卡片
+type TemplateCard struct {
+	CardType              string                           `json:"card_type"`                         // 模版卡片的模版类型, text_notice 或 news_notice
+	Source                *TemplateCardSource              `json:"source,omitempty"`                  // 卡片来源样式信息, 不需要来源样式可不填写
+	MainTitle             TemplateCardTitle                `json:"main_title"`                        // 模版卡片的主要内容, 包括一级标题和标题辅助信息
+	EmphasisContent       *TemplateCardEmphasisContent     `json:"emphasis_content,omitempty"`        // 关键数据样式, 只有 text_notice 类型的卡片有
+	CardImage             *TemplateCardImage               `json:"card_image,omitempty"`              // 图片样式, 只有 news_notice 类型的卡片有
+	SubTitleText          string                           `json:"sub_title_text,omitempty"`          // 二级普通文本, 建议不超过112个字
+	HorizontalContentList []*TemplateCardHorizontalContent `json:"horizontal_content_list,omitempty"` // 二级标题+文本列表, 列表长度不超过6
+	JumpList              []*TemplateCardJump              `json:"jump_list,omitempty"`               // 跳转指引样式的列表, 列表长度不超过3
+	CardAction            TemplateCardAction               `json:"card_action"`                       // 整体卡片的点击跳转事件, text_notice 和 news_notice 类型的卡片该字段为必填项
+}
+
+type TemplateCardMessage struct {
+	MsgType string `json:"msgtype"`
+
+	TemplateCard *TemplateCard `json:"template_card"`
+}
@@ -0,0 +1,9 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 安全管理接口.
+//  NOTE: 调用这些接口的 access_token 需要通过管理后台 "安全与管理" 授权的应用 secret 获取,
+//  普通应用的 access_token 调用会返回无权限的错误.
+package security
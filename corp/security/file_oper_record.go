@@ -0,0 +1,88 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package security
+
+import (
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const FileOperRecordListLimit = 1000
+
+// 文件操作的类型和来源
+type FileOperation struct {
+	Type   int `json:"type"`   // 操作类型, 比如下载, 转发, 参考企业微信文档
+	Source int `json:"source"` // 操作来源, 比如聊天, 微盘, 参考企业微信文档
+}
+
+// 文件防泄漏的操作记录
+type FileOperRecord struct {
+	Time          int64         `json:"time"`           // 操作时间
+	UserId        string        `json:"userid"`         // 操作的成员
+	ExternalUser  string        `json:"external_user"`  // 操作的外部用户
+	Operation     FileOperation `json:"operation"`      // 操作的类型和来源
+	FileInfo      string        `json:"file_info"`      // 文件名
+	FileMd5       string        `json:"file_md5"`       // 文件的 md5
+	FileSize      int64         `json:"file_size"`      // 文件大小
+	ApplicantName string        `json:"applicant_name"` // 应用名称
+	DeviceType    int           `json:"device_type"`    // 设备类型, 1: 企业可信设备, 2: 个人可信设备
+	DeviceCode    string        `json:"device_code"`    // 设备编码
+}
+
+type FileOperRecordList struct {
+	RecordList []FileOperRecord `json:"record_list"`
+	HasMore    bool             `json:"has_more"`
+	NextCursor string           `json:"next_cursor"` // 分页游标, 下一次查询使用
+}
+
+// 获取文件防泄漏的操作记录.
+//  startTime, endTime: 查询的时间范围, 最长为 14 天
+//  userIds:            需要查询的成员, 为空时查询所有成员
+//  operation:          按照操作类型和来源过滤, 为 nil 时不过滤
+//  cursor:             分页查询使用的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+//  limit:              每次查询的分页大小, 1 到 FileOperRecordListLimit
+func (clt *Client) GetFileOperRecord(startTime, endTime int64, userIds []string, operation *FileOperation,
+	cursor string, limit int) (list *FileOperRecordList, err error) {
+
+	if limit < 1 || limit > FileOperRecordListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", FileOperRecordListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		StartTime  int64          `json:"start_time"`
+		EndTime    int64          `json:"end_time"`
+		UserIdList []string       `json:"userid_list,omitempty"`
+		Operation  *FileOperation `json:"operation,omitempty"`
+		Cursor     string         `json:"cursor,omitempty"`
+		Limit      int            `json:"limit"`
+	}{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		UserIdList: userIds,
+		Operation:  operation,
+		Cursor:     cursor,
+		Limit:      limit,
+	}
+
+	var result struct {
+		corp.Error
+		FileOperRecordList
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/security/get_file_oper_record?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = &result.FileOperRecordList
+	return
+}
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package security
+
+import (
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const MemberOperLogListLimit = 100
+
+// 成员的操作记录, 包括登录等
+type MemberOperLog struct {
+	Time       int64  `json:"time"`        // 操作时间
+	UserId     string `json:"userid"`      // 操作的成员
+	OperType   int    `json:"oper_type"`   // 操作类型, 参考企业微信文档
+	DetailInfo string `json:"detail_info"` // 操作的详细信息, 比如登录的设备
+	IP         string `json:"ip"`          // 操作的来源 ip
+}
+
+type MemberOperLogList struct {
+	RecordList []MemberOperLog `json:"record_list"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor string          `json:"next_cursor"` // 分页游标, 下一次查询使用
+}
+
+// 获取成员的操作记录.
+//  startTime, endTime: 查询的时间范围, 最长为 7 天
+//  userId:             需要查询的成员, 为空时查询所有成员
+//  operType:           操作类型, 0 时不过滤
+//  cursor:             分页查询使用的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+//  limit:              每次查询的分页大小, 1 到 MemberOperLogListLimit
+func (clt *Client) GetMemberOperLog(startTime, endTime int64, userId string, operType int,
+	cursor string, limit int) (list *MemberOperLogList, err error) {
+
+	if limit < 1 || limit > MemberOperLogListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", MemberOperLogListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		StartTime int64  `json:"start_time"`
+		EndTime   int64  `json:"end_time"`
+		UserId    string `json:"userid,omitempty"`
+		OperType  int    `json:"oper_type,omitempty"`
+		Cursor    string `json:"cursor,omitempty"`
+		Limit     int    `json:"limit"`
+	}{
+		StartTime: startTime,
+		EndTime:   endTime,
+		UserId:    userId,
+		OperType:  operType,
+		Cursor:    cursor,
+		Limit:     limit,
+	}
+
+	var result struct {
+		corp.Error
+		MemberOperLogList
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/security/member_oper_log/list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = &result.MemberOperLogList
+	return
+}
@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package security
+
+import (
+	"fmt"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 截屏/录屏的内容类型
+const (
+	ScreenShotTypeChat     = 1 // 聊天
+	ScreenShotTypeContact  = 2 // 通讯录
+	ScreenShotTypeMail     = 3 // 邮箱
+	ScreenShotTypeFile     = 4 // 文件
+	ScreenShotTypeSchedule = 5 // 日程
+	ScreenShotTypeOther    = 6 // 其他
+)
+
+const ScreenOperRecordListLimit = 100
+
+// 截屏/录屏记录
+type ScreenOperRecord struct {
+	Time              int64  `json:"time"`                // 操作时间
+	UserId            string `json:"userid"`              // 操作的成员
+	DepartmentId      int64  `json:"department_id"`       // 成员所在的部门
+	ScreenShotType    int    `json:"screen_shot_type"`    // 截屏的内容类型, ScreenShotTypeChat 等
+	ScreenShotContent string `json:"screen_shot_content"` // 截屏内容, 比如聊天的会话名称
+	System            string `json:"system"`              // 操作系统, 比如 Windows, iOS
+}
+
+type ScreenOperRecordList struct {
+	RecordList []ScreenOperRecord `json:"record_list"`
+	HasMore    bool               `json:"has_more"`
+	NextCursor string             `json:"next_cursor"` // 分页游标, 下一次查询使用
+}
+
+// 获取成员的截屏/录屏记录.
+//  startTime, endTime: 查询的时间范围, 最长为 14 天
+//  userIds:            需要查询的成员, 为空时不过滤
+//  departmentIds:      需要查询的部门, 为空时不过滤
+//  screenShotType:     截屏的内容类型, 0 时不过滤
+//  cursor:             分页查询使用的游标, 首次查询为空, 后续使用上一次返回的 NextCursor
+//  limit:              每次查询的分页大小, 1 到 ScreenOperRecordListLimit
+func (clt *Client) GetScreenOperRecord(startTime, endTime int64, userIds []string, departmentIds []int64,
+	screenShotType int, cursor string, limit int) (list *ScreenOperRecordList, err error) {
+
+	if limit < 1 || limit > ScreenOperRecordListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", ScreenOperRecordListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		StartTime      int64    `json:"start_time"`
+		EndTime        int64    `json:"end_time"`
+		UserIdList     []string `json:"userid_list,omitempty"`
+		DepartmentList []int64  `json:"department_list,omitempty"`
+		ScreenShotType int      `json:"screen_shot_type,omitempty"`
+		Cursor         string   `json:"cursor,omitempty"`
+		Limit          int      `json:"limit"`
+	}{
+		StartTime:      startTime,
+		EndTime:        endTime,
+		UserIdList:     userIds,
+		DepartmentList: departmentIds,
+		ScreenShotType: screenShotType,
+		Cursor:         cursor,
+		Limit:          limit,
+	}
+
+	var result struct {
+		corp.Error
+		ScreenOperRecordList
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/security/get_screen_oper_record?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = &result.ScreenOperRecordList
+	return
+}
@@ -51,4 +51,5 @@ type MixedMessage struct {
 
 	SuiteTicket string `xml:"SuiteTicket" json:"SuiteTicket"`
 	AuthCorpId  string `xml:"AuthCorpId"  json:"AuthCorpId"`
+	AuthCode    string `xml:"AuthCode"    json:"AuthCode"`
 }
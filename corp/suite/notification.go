@@ -10,6 +10,7 @@ const (
 	MsgTypeSuiteTicket = "suite_ticket" // 推送suite_ticket协议
 	MsgTypeChangeAuth  = "change_auth"  // 变更授权的通知
 	MsgTypeCancelAuth  = "cancel_auth"  // 取消授权的通知
+	MsgTypeCreateAuth  = "create_auth"  // 授权成功的通知, 带有临时授权码
 )
 
 type TicketMessage struct {
@@ -68,3 +69,22 @@ func GetCancelAuthMessage(msg *MixedMessage) *CancelAuthMessage {
 		AuthCorpId: msg.AuthCorpId,
 	}
 }
+
+type CreateAuthMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	SuiteId   string `xml:"SuiteId"   json:"SuiteId"`
+	InfoType  string `xml:"InfoType"  json:"InfoType"`
+	Timestamp int64  `xml:"TimeStamp" json:"TimeStamp"`
+
+	AuthCode string `xml:"AuthCode" json:"AuthCode"` // 临时授权码, 用 GetPermanentCode 换取永久授权码
+}
+
+func GetCreateAuthMessage(msg *MixedMessage) *CreateAuthMessage {
+	return &CreateAuthMessage{
+		SuiteId:   msg.SuiteId,
+		InfoType:  msg.InfoType,
+		Timestamp: msg.Timestamp,
+		AuthCode:  msg.AuthCode,
+	}
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package suite
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 授权配置
+type SessionInfo struct {
+	AppIdList []int64 `json:"appid,omitempty"` // 允许进行授权的应用id, 不填或者填空数组都表示允许授权套件内所有应用
+	AuthType  int     `json:"auth_type"`       // 授权类型: 0 正式授权, 1 测试授权, 默认值为0
+}
+
+// 设置授权配置.
+//  对某次授权进行配置, 可支持测试授权, 需要在用户授权之前调用.
+func (clt *Client) SetSessionInfo(preAuthCode string, info *SessionInfo) (err error) {
+	if info == nil {
+		return errors.New("nil SessionInfo")
+	}
+
+	request := struct {
+		PreAuthCode string       `json:"pre_auth_code"`
+		SessionInfo *SessionInfo `json:"session_info"`
+	}{
+		PreAuthCode: preAuthCode,
+		SessionInfo: info,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/service/set_session_info?suite_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
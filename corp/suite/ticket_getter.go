@@ -7,7 +7,11 @@ package suite
 
 import (
 	"errors"
+	"io"
+	"net/http"
 	"sync"
+
+	"github.com/chanxuehong/wechat/corp"
 )
 
 var ErrNotFound = errors.New("item not found")
@@ -91,3 +95,27 @@ func (cache *TicketCache2) GetSuiteTicket(suiteId string) (ticket string, err er
 	cache.rwmutex.RUnlock()
 	return
 }
+
+// 保存微信服务器推送过来的 suiteTicket, TicketCache 和 TicketCache2 都实现了这个接口.
+//  如果需要多个进程共享 suiteTicket, 可以实现一个保存到数据库或者缓存服务的 TicketSetter/TicketGetter.
+type TicketSetter interface {
+	SetSuiteTicket(suiteId string, ticket string) (err error)
+}
+
+// 返回一个处理 suite_ticket 推送的 MessageHandler, 把 suiteTicket 保存到 setter 后回复 "success".
+//
+//  mux.MessageHandle(MsgTypeSuiteTicket, NewTicketMessageHandler(ticketCache))
+func NewTicketMessageHandler(setter TicketSetter) MessageHandler {
+	if setter == nil {
+		panic("nil TicketSetter")
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		msg := GetTicketMessage(r.MixedMsg)
+		if err := setter.SetSuiteTicket(msg.SuiteId, msg.SuiteTicket); err != nil {
+			corp.LogInfoln("[WECHAT_SUITE_TICKET]", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		io.WriteString(w, "success")
+	})
+}
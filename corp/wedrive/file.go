@@ -0,0 +1,292 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wedrive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 文件列表的排序方式
+const (
+	SortTypeNameAsc  = 1 // 名字升序
+	SortTypeNameDesc = 2 // 名字降序
+	SortTypeSizeAsc  = 3 // 大小升序
+	SortTypeSizeDesc = 4 // 大小降序
+	SortTypeTimeAsc  = 5 // 修改时间升序
+	SortTypeTimeDesc = 6 // 修改时间降序
+)
+
+// 文件类型
+const (
+	FileTypeFolder = 1 // 文件夹
+	FileTypeFile   = 2 // 文件
+	FileTypeDoc    = 3 // 文档
+	FileTypeSheet  = 4 // 表格
+)
+
+// 每次获取文件列表最多的文件数
+const FileListLimit = 1000
+
+// 文件信息
+type FileInfo struct {
+	FileId       string `json:"fileid"`        // 文件id
+	FileName     string `json:"file_name"`     // 文件名字
+	SpaceId      string `json:"spaceid"`       // 文件所在的空间id
+	FatherId     string `json:"fatherid"`      // 文件所在的目录id
+	FileSize     int64  `json:"file_size"`     // 文件大小
+	CreateTime   int64  `json:"ctime"`         // 文件创建时间
+	ModifyTime   int64  `json:"mtime"`         // 文件最后修改时间
+	FileType     int    `json:"file_type"`     // 文件类型, FileTypeFolder, FileTypeFile, FileTypeDoc, FileTypeSheet
+	FileStatus   int    `json:"file_status"`   // 文件状态, 1: 正常, 2: 删除, 3: 封禁, 4: 关闭
+	CreateUserId string `json:"create_userid"` // 文件创建者的 userid
+	UpdateUserId string `json:"update_userid"` // 文件最后修改者的 userid
+	SHA          string `json:"sha"`           // 文件的 sha
+	MD5          string `json:"md5"`           // 文件的 md5
+	URL          string `json:"url"`           // 仅在文件类型为文档或者表格时有效, 文档或者表格的访问 url
+}
+
+// 文件列表
+type FileList struct {
+	HasMore   bool       `json:"has_more"`   // 是否还有下一页
+	NextStart int        `json:"next_start"` // 下一次请求的 start
+	Items     []FileInfo `json:"-"`
+}
+
+// 获取文件列表.
+//
+//  userId:   操作者的 userid
+//  fatherId: 目录的 fileid, 获取根目录时为 spaceId
+//  sortType: 排序方式, SortTypeNameAsc ~ SortTypeTimeDesc
+//  start:    开始的位置, 从 0 开始, 后续请求使用上一次返回的 NextStart
+//  limit:    拉取的文件数, 不超过 FileListLimit
+func (clt *Client) GetFileList(userId, spaceId, fatherId string, sortType, start, limit int) (list *FileList, err error) {
+	if limit <= 0 || limit > FileListLimit {
+		err = fmt.Errorf("limit 必须在 1 和 %d 之间, 现在为 %d", FileListLimit, limit)
+		return
+	}
+
+	var request = struct {
+		UserId   string `json:"userid"`
+		SpaceId  string `json:"spaceid"`
+		FatherId string `json:"fatherid"`
+		SortType int    `json:"sort_type"`
+		Start    int    `json:"start"`
+		Limit    int    `json:"limit"`
+	}{
+		UserId:   userId,
+		SpaceId:  spaceId,
+		FatherId: fatherId,
+		SortType: sortType,
+		Start:    start,
+		Limit:    limit,
+	}
+
+	var result struct {
+		corp.Error
+		FileList
+		List struct {
+			Item []FileInfo `json:"item"`
+		} `json:"file_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_list?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.FileList.Items = result.List.Item
+	list = &result.FileList
+	return
+}
+
+// 获取文件信息.
+//
+//  userId: 操作者的 userid
+func (clt *Client) GetFileInfo(userId, fileId string) (info *FileInfo, err error) {
+	var request = struct {
+		UserId string `json:"userid"`
+		FileId string `json:"fileid"`
+	}{
+		UserId: userId,
+		FileId: fileId,
+	}
+
+	var result struct {
+		corp.Error
+		FileInfo FileInfo `json:"file_info"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_info?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.FileInfo
+	return
+}
+
+// 下载文件.
+//
+//  userId: 操作者的 userid
+//  NOTE: 调用者负责关闭 body.
+func (clt *Client) DownloadFile(userId, fileId string) (body io.ReadCloser, err error) {
+	var request = struct {
+		UserId string `json:"userid"`
+		FileId string `json:"fileid"`
+	}{
+		UserId: userId,
+		FileId: fileId,
+	}
+
+	var result struct {
+		corp.Error
+		DownloadURL string `json:"download_url"`
+		CookieName  string `json:"cookie_name"`
+		CookieValue string `json:"cookie_value"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_download?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	// 下载地址需要带上返回的 cookie 才能访问
+	httpReq, err := http.NewRequest("GET", result.DownloadURL, nil)
+	if err != nil {
+		return
+	}
+	if result.CookieName != "" {
+		httpReq.AddCookie(&http.Cookie{Name: result.CookieName, Value: result.CookieValue})
+	}
+
+	httpResp, err := clt.HttpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+	body = httpResp.Body
+	return
+}
+
+// 移动文件.
+//
+//  userId:   操作者的 userid
+//  fatherId: 目标目录的 fileid, 移动到根目录时为 spaceId
+//  replace:  目标目录下有同名文件时是否覆盖, false 时会重命名
+func (clt *Client) MoveFile(userId, fatherId string, replace bool, fileIdList []string) (err error) {
+	if len(fileIdList) == 0 {
+		return errors.New("empty fileIdList")
+	}
+
+	var request = struct {
+		UserId     string   `json:"userid"`
+		FatherId   string   `json:"fatherid"`
+		Replace    bool     `json:"replace"`
+		FileIdList []string `json:"fileid"`
+	}{
+		UserId:     userId,
+		FatherId:   fatherId,
+		Replace:    replace,
+		FileIdList: fileIdList,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_move?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 删除文件.
+//
+//  userId: 操作者的 userid
+func (clt *Client) DeleteFile(userId string, fileIdList []string) (err error) {
+	if len(fileIdList) == 0 {
+		return errors.New("empty fileIdList")
+	}
+
+	var request = struct {
+		UserId     string   `json:"userid"`
+		FileIdList []string `json:"fileid"`
+	}{
+		UserId:     userId,
+		FileIdList: fileIdList,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_delete?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 重命名文件.
+//
+//  userId: 操作者的 userid
+func (clt *Client) RenameFile(userId, fileId, newName string) (err error) {
+	if newName == "" {
+		return errors.New("empty newName")
+	}
+
+	var request = struct {
+		UserId  string `json:"userid"`
+		FileId  string `json:"fileid"`
+		NewName string `json:"new_name"`
+	}{
+		UserId:  userId,
+		FileId:  fileId,
+		NewName: newName,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_rename?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,57 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wedrive
+
+import (
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 空间的成员或者部门的权限信息
+type SpaceAuthInfo struct {
+	Type         int    `json:"type"`         // 成员类型, 1: 个人, 2: 部门
+	UserId       string `json:"userid"`       // 成员的 userid, Type 为 1 时有效
+	DepartmentId int64  `json:"departmentid"` // 部门id, Type 为 2 时有效
+	Auth         int    `json:"auth"`         // 成员权限, 1: 可下载, 4: 仅预览, 7: 管理员
+}
+
+// 空间信息
+type SpaceInfo struct {
+	SpaceId   string `json:"spaceid"`    // 空间id
+	SpaceName string `json:"space_name"` // 空间名称
+	AuthList  struct {
+		AuthInfo []SpaceAuthInfo `json:"auth_info"`
+	} `json:"auth_list"` // 空间成员权限列表
+	SpaceSubType int `json:"space_sub_type"` // 空间类型, 0: 普通, 1: 相册
+}
+
+// 获取空间信息.
+//  userId: 操作者的 userid
+func (clt *Client) GetSpaceInfo(userId, spaceId string) (info *SpaceInfo, err error) {
+	var request = struct {
+		UserId  string `json:"userid"`
+		SpaceId string `json:"spaceid"`
+	}{
+		UserId:  userId,
+		SpaceId: spaceId,
+	}
+
+	var result struct {
+		corp.Error
+		SpaceInfo SpaceInfo `json:"space_info"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/space_info?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.SpaceInfo
+	return
+}
@@ -0,0 +1,261 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wedrive
+
+import (
+	"crypto/sha1"
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	UploadFileSizeLimit = 10 << 20 // 普通上传的文件大小上限, 超过这个大小使用分块上传
+	UploadBlockSize     = 2 << 20  // 分块上传每个分块的大小, 最后一个分块可以小于这个大小
+)
+
+// 上传文件, 返回文件的 fileid.
+//  userId:   操作者的 userid
+//  fatherId: 目标目录的 fileid, 上传到根目录时为 spaceId
+//
+//  NOTE: 文件大小超过 UploadFileSizeLimit 时使用分块上传; 如果 reader 不是 io.ReadSeeker,
+//  分块上传会先把数据保存到临时文件, 因为分块上传需要先计算每个分块的 sha.
+func (clt *Client) UploadFile(userId, spaceId, fatherId, fileName string, reader io.Reader) (fileId string, err error) {
+	if fileName == "" {
+		err = errors.New("empty fileName")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	// 先读取至多 UploadFileSizeLimit+1 字节判断走哪种上传方式
+	head, err := ioutil.ReadAll(io.LimitReader(reader, UploadFileSizeLimit+1))
+	if err != nil {
+		return
+	}
+	if len(head) <= UploadFileSizeLimit {
+		return clt.uploadFile(userId, spaceId, fatherId, fileName, head)
+	}
+
+	seeker, ok := reader.(io.ReadSeeker)
+	if ok {
+		if _, err = seeker.Seek(-int64(len(head)), io.SeekCurrent); err != nil {
+			return
+		}
+	} else {
+		var file *os.File
+		if file, err = ioutil.TempFile("", "wedrive-upload-"); err != nil {
+			return
+		}
+		defer func() {
+			file.Close()
+			os.Remove(file.Name())
+		}()
+
+		if _, err = file.Write(head); err != nil {
+			return
+		}
+		if _, err = io.Copy(file, reader); err != nil {
+			return
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		seeker = file
+	}
+	return clt.uploadFileByBlock(userId, spaceId, fatherId, fileName, seeker)
+}
+
+func (clt *Client) uploadFile(userId, spaceId, fatherId, fileName string, content []byte) (fileId string, err error) {
+	var request = struct {
+		UserId            string `json:"userid"`
+		SpaceId           string `json:"spaceid"`
+		FatherId          string `json:"fatherid"`
+		FileName          string `json:"file_name"`
+		FileBase64Content string `json:"file_base64_content"`
+	}{
+		UserId:            userId,
+		SpaceId:           spaceId,
+		FatherId:          fatherId,
+		FileName:          fileName,
+		FileBase64Content: base64.StdEncoding.EncodeToString(content),
+	}
+
+	var result struct {
+		corp.Error
+		FileId string `json:"fileid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_upload?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	fileId = result.FileId
+	return
+}
+
+// 计算分块上传需要的每个分块的累积 sha, 返回文件大小和 block_sha 列表.
+//  前面分块的累积 sha 是 sha1 处理完这个分块后的中间状态(没有做最后的填充), 最后一个分块是整个文件的 sha1.
+func blockSHAList(reader io.Reader) (size int64, shaList []string, err error) {
+	h := sha1.New()
+	buf := make([]byte, UploadBlockSize)
+	for {
+		var n int
+		n, err = io.ReadFull(reader, buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return
+		}
+		h.Write(buf[:n])
+		size += int64(n)
+
+		if err == io.ErrUnexpectedEOF {
+			break // 最后一个分块
+		}
+
+		// UploadBlockSize 是 64 的整数倍, 这里 sha1 内部没有缓存的数据, 中间状态就是 h0~h4.
+		// 格式: magic(4B) + h0~h4(20B, 大端序) + ...
+		var state []byte
+		if state, err = h.(encoding.BinaryMarshaler).MarshalBinary(); err != nil {
+			return
+		}
+		shaList = append(shaList, hex.EncodeToString(state[4:24]))
+	}
+	err = nil
+
+	// 最后一个分块用完整的 sha1 替换(或者追加)
+	sum := hex.EncodeToString(h.Sum(nil))
+	if size%UploadBlockSize == 0 && len(shaList) > 0 {
+		shaList[len(shaList)-1] = sum
+	} else {
+		shaList = append(shaList, sum)
+	}
+	return
+}
+
+func (clt *Client) uploadFileByBlock(userId, spaceId, fatherId, fileName string, seeker io.ReadSeeker) (fileId string, err error) {
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	size, shaList, err := blockSHAList(seeker)
+	if err != nil {
+		return
+	}
+	if _, err = seeker.Seek(start, io.SeekStart); err != nil {
+		return
+	}
+
+	var initRequest = struct {
+		UserId   string   `json:"userid"`
+		SpaceId  string   `json:"spaceid"`
+		FatherId string   `json:"fatherid"`
+		FileName string   `json:"file_name"`
+		Size     int64    `json:"size"`
+		BlockSHA []string `json:"block_sha"`
+	}{
+		UserId:   userId,
+		SpaceId:  spaceId,
+		FatherId: fatherId,
+		FileName: fileName,
+		Size:     size,
+		BlockSHA: shaList,
+	}
+
+	var initResult struct {
+		corp.Error
+		HitExist  bool   `json:"hit_exist"`
+		UploadKey string `json:"upload_key"`
+		FileId    string `json:"fileid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_upload_init?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &initRequest, &initResult); err != nil {
+		return
+	}
+	if initResult.ErrCode != corp.ErrCodeOK {
+		err = &initResult.Error
+		return
+	}
+	if initResult.HitExist { // 秒传
+		fileId = initResult.FileId
+		return
+	}
+
+	buf := make([]byte, UploadBlockSize)
+	for index := 1; index <= len(shaList); index++ {
+		var n int
+		n, err = io.ReadFull(seeker, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return
+		}
+		if err = clt.uploadPart(initResult.UploadKey, index, buf[:n]); err != nil {
+			return
+		}
+	}
+
+	var finishRequest = struct {
+		UploadKey string `json:"upload_key"`
+	}{
+		UploadKey: initResult.UploadKey,
+	}
+
+	var finishResult struct {
+		corp.Error
+		FileId string `json:"fileid"`
+	}
+
+	incompleteURL = "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_upload_finish?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &finishRequest, &finishResult); err != nil {
+		return
+	}
+	if finishResult.ErrCode != corp.ErrCodeOK {
+		err = &finishResult.Error
+		return
+	}
+	fileId = finishResult.FileId
+	return
+}
+
+func (clt *Client) uploadPart(uploadKey string, index int, content []byte) (err error) {
+	var request = struct {
+		UploadKey         string `json:"upload_key"`
+		Index             int    `json:"index"`
+		FileBase64Content string `json:"file_base64_content"`
+	}{
+		UploadKey:         uploadKey,
+		Index:             index,
+		FileBase64Content: base64.StdEncoding.EncodeToString(content),
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/wedrive/file_upload_part?access_token="
+	if err = ((*corp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
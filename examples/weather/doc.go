@@ -0,0 +1,13 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// weather 是一个查询天气的插件, 用来演示 MessageHandler 的组合方式:
+// mp.StrictValidator 校验消息, command.CommandRouter 路由命令, mp.ConversationContext 记住用户上一次查询的城市.
+//
+//  mux := mp.NewMessageServeMux()
+//  mux.MessageHandle(request.MsgTypeText, weather.NewWeatherPlugin(api, conversation))
+//
+//  用户发送 "/weather 北京" 查询北京的天气, 之后发送 "/update" 重新查询上一次的城市.
+package weather
@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package weather
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/command"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 天气信息
+type Weather struct {
+	City        string
+	Condition   string // 天气状况, 比如 "晴"
+	Temperature string // 温度, 比如 "25℃"
+	IconURL     string // 天气图标, 作为图文消息的图片
+	DetailURL   string // 天气详情页面, 作为图文消息的链接
+}
+
+// 查询天气的接口, 由使用者对接具体的天气服务.
+type WeatherAPIClient interface {
+	Query(city string) (*Weather, error)
+}
+
+// 上一次查询的城市在 ConversationContext 里的 key 和有效期
+const (
+	lastCityKey = "weather.last_city"
+	lastCityTTL = 24 * time.Hour
+)
+
+// 创建天气插件, 返回的 mp.MessageHandler 处理文本消息, 支持下面的命令:
+//  /weather <城市>  查询城市的天气
+//  /update         重新查询上一次的城市
+func NewWeatherPlugin(api WeatherAPIClient, conversation *mp.ConversationContext) mp.MessageHandler {
+	if api == nil {
+		panic("nil WeatherAPIClient")
+	}
+	if conversation == nil {
+		panic("nil ConversationContext")
+	}
+
+	plugin := &plugin{
+		api:          api,
+		conversation: conversation,
+	}
+	router := command.NewCommandRouter().
+		RegisterFunc("weather", plugin.serveWeather).
+		RegisterFunc("update", plugin.serveUpdate)
+	return mp.NewStrictValidator(router)
+}
+
+type plugin struct {
+	api          WeatherAPIClient
+	conversation *mp.ConversationContext
+}
+
+func (p *plugin) serveWeather(w http.ResponseWriter, r *mp.Request) {
+	_, args, _ := command.ParseCommand(r.MixedMsg.Content)
+	city := strings.Join(args, " ")
+	if city == "" {
+		replyText(w, r, "用法: /weather <城市>")
+		return
+	}
+	p.query(w, r, city)
+}
+
+func (p *plugin) serveUpdate(w http.ResponseWriter, r *mp.Request) {
+	v, ok, err := p.conversation.ForRequest(r).Get(r.MixedMsg.FromUserName, lastCityKey)
+	if err != nil {
+		mp.LogInfoln("[WECHAT_WEATHER]", err)
+	}
+	city, _ := v.(string)
+	if !ok || city == "" {
+		replyText(w, r, "请先发送 /weather <城市> 查询天气")
+		return
+	}
+	p.query(w, r, city)
+}
+
+func (p *plugin) query(w http.ResponseWriter, r *mp.Request, city string) {
+	weather, err := p.api.Query(city)
+	if err != nil {
+		mp.LogInfoln("[WECHAT_WEATHER]", err)
+		replyText(w, r, "查询 "+city+" 的天气失败, 请稍后再试")
+		return
+	}
+
+	if err = p.conversation.ForRequest(r).Set(r.MixedMsg.FromUserName, lastCityKey, city, lastCityTTL); err != nil {
+		mp.LogInfoln("[WECHAT_WEATHER]", err)
+	}
+
+	articles := []response.Article{
+		{
+			Title:       weather.City + ": " + weather.Condition + " " + weather.Temperature,
+			Description: "发送 /update 可以重新查询 " + weather.City + " 的天气",
+			PicURL:      weather.IconURL,
+			URL:         weather.DetailURL,
+		},
+	}
+	reply(w, r, response.NewNews(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, articles))
+}
+
+func replyText(w http.ResponseWriter, r *mp.Request, content string) {
+	reply(w, r, response.NewText(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, content))
+}
+
+func reply(w http.ResponseWriter, r *mp.Request, msg interface{}) {
+	if err := response.Write(w, r, msg); err != nil {
+		mp.LogInfoln("[WECHAT_WEATHER]", err)
+	}
+}
@@ -0,0 +1,159 @@
+package weather
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	testOriId = "gh_1234567890ab"
+	testToken = "weather_token"
+	testUser  = "o_test_user"
+)
+
+type mockWeatherAPI struct {
+	queries []string
+}
+
+func (api *mockWeatherAPI) Query(city string) (*Weather, error) {
+	api.queries = append(api.queries, city)
+	if city == "火星" {
+		return nil, errors.New("unknown city")
+	}
+	return &Weather{
+		City:        city,
+		Condition:   "晴",
+		Temperature: "25℃",
+		IconURL:     "http://example.com/sunny.png",
+		DetailURL:   "http://example.com/weather?city=" + url.QueryEscape(city),
+	}, nil
+}
+
+type testReply struct {
+	MsgType  string `xml:"MsgType"`
+	Content  string `xml:"Content"`
+	Articles []struct {
+		Title  string `xml:"Title"`
+		PicURL string `xml:"PicUrl"`
+	} `xml:"Articles>item"`
+}
+
+// 用明文模式把文本消息 content 发送给 handler, 返回被动回复的消息.
+func postText(t *testing.T, handler mp.MessageHandler, content string, createTime int64) (reply *testReply, code int) {
+	srv := mp.NewDefaultServer(testOriId, testToken, "", nil, handler)
+	frontend := mp.NewServerFrontend(srv, nil, nil)
+
+	timestamp := strconv.FormatInt(createTime, 10)
+	nonce := "1234567890"
+	query := url.Values{
+		"signature": {util.Sign(testToken, timestamp, nonce)},
+		"timestamp": {timestamp},
+		"nonce":     {nonce},
+	}
+	body := fmt.Sprintf(`<xml><ToUserName><![CDATA[%s]]></ToUserName><FromUserName><![CDATA[%s]]></FromUserName>`+
+		`<CreateTime>%d</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[%s]]></Content><MsgId>%d</MsgId></xml>`,
+		testOriId, testUser, createTime, content, createTime)
+
+	r := httptest.NewRequest("POST", "/wechat?"+query.Encode(), strings.NewReader(body))
+	w := httptest.NewRecorder()
+	frontend.ServeHTTP(w, r)
+
+	if code = w.Code; code != http.StatusOK {
+		return
+	}
+	reply = new(testReply)
+	if err := xml.Unmarshal(w.Body.Bytes(), reply); err != nil {
+		t.Fatalf("invalid reply %q: %v", w.Body.String(), err)
+	}
+	return
+}
+
+func newTestPlugin() (mp.MessageHandler, *mockWeatherAPI, *mp.MemoryContextStore) {
+	api := new(mockWeatherAPI)
+	store := mp.NewMemoryContextStore(0)
+	return NewWeatherPlugin(api, mp.NewConversationContext(store)), api, store
+}
+
+func TestWeather(t *testing.T) {
+	plugin, api, _ := newTestPlugin()
+
+	reply, code := postText(t, plugin, "/weather 北京", time.Now().Unix())
+	if code != http.StatusOK {
+		t.Fatalf("http status %d", code)
+	}
+	if reply.MsgType != "news" || len(reply.Articles) != 1 {
+		t.Fatalf("want a news reply with one article, got %+v", reply)
+	}
+	if want := "北京: 晴 25℃"; reply.Articles[0].Title != want {
+		t.Errorf("Title: have %q, want %q", reply.Articles[0].Title, want)
+	}
+	if reply.Articles[0].PicURL != "http://example.com/sunny.png" {
+		t.Errorf("PicUrl: have %q", reply.Articles[0].PicURL)
+	}
+	if len(api.queries) != 1 || api.queries[0] != "北京" {
+		t.Errorf("queries: %q", api.queries)
+	}
+}
+
+func TestUpdateUsesLastCity(t *testing.T) {
+	plugin, api, _ := newTestPlugin()
+	now := time.Now().Unix()
+
+	reply, _ := postText(t, plugin, "/update", now)
+	if reply == nil || reply.MsgType != "text" || !strings.Contains(reply.Content, "/weather") {
+		t.Fatalf("want usage hint before any query, got %+v", reply)
+	}
+
+	postText(t, plugin, "/weather 上海", now)
+	reply, _ = postText(t, plugin, "/update", now+60)
+	if reply == nil || reply.MsgType != "news" || reply.Articles[0].Title != "上海: 晴 25℃" {
+		t.Fatalf("want weather of the last city, got %+v", reply)
+	}
+	if len(api.queries) != 2 || api.queries[1] != "上海" {
+		t.Errorf("queries: %q", api.queries)
+	}
+
+	// 每次查询都会刷新有效期, 超过最后一次查询的有效期之后忘记上一次的城市
+	reply, _ = postText(t, plugin, "/update", now+60+int64(lastCityTTL/time.Second))
+	if reply == nil || reply.MsgType != "text" {
+		t.Fatalf("want usage hint after the ttl, got %+v", reply)
+	}
+}
+
+func TestWeatherErrors(t *testing.T) {
+	plugin, _, store := newTestPlugin()
+	now := time.Now().Unix()
+
+	reply, _ := postText(t, plugin, "/weather", now)
+	if reply == nil || reply.MsgType != "text" || !strings.Contains(reply.Content, "用法") {
+		t.Errorf("want usage reply, got %+v", reply)
+	}
+
+	reply, _ = postText(t, plugin, "/weather 火星", now)
+	if reply == nil || reply.MsgType != "text" || !strings.Contains(reply.Content, "失败") {
+		t.Errorf("want failure reply, got %+v", reply)
+	}
+	if _, ok, _ := store.Get(testUser, lastCityKey, now); ok {
+		t.Error("failed query should not be remembered")
+	}
+
+	reply, _ = postText(t, plugin, "/unknown", now)
+	if reply == nil || reply.MsgType != "text" {
+		t.Errorf("want unknown command reply, got %+v", reply)
+	}
+
+	if _, code := postText(t, plugin, "/weather 北京", 0); code != http.StatusBadRequest {
+		t.Errorf("want 400 for an invalid CreateTime, got %d", code)
+	}
+}
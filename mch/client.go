@@ -45,9 +45,24 @@ func NewProxy(appId, mchId, apiKey string, httpClient *http.Client) *Proxy {
 	}
 }
 
+// 用 Proxy 的 API密钥 对 parameters 做 MD5 签名.
+func (pxy *Proxy) Sign(parameters map[string]string) string {
+	return Sign(parameters, pxy.apiKey, nil)
+}
+
 // 微信支付通用请求方法.
 //  注意: err == nil 表示协议状态都为 SUCCESS(return_code == SUCCESS).
 func (pxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]string, err error) {
+	return pxy.postXML(url, req, true)
+}
+
+// 和 PostXML 一样, 但是不校验返回结果的签名.
+//  企业付款, 查询企业付款等接口返回的结果里没有 sign 字段, 需要用这个方法.
+func (pxy *Proxy) PostXMLWithoutSign(url string, req map[string]string) (resp map[string]string, err error) {
+	return pxy.postXML(url, req, false)
+}
+
+func (pxy *Proxy) postXML(url string, req map[string]string, checkSign bool) (resp map[string]string, err error) {
 	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
 	bodyBuf.Reset()
 	defer textBufferPool.Put(bodyBuf)
@@ -97,6 +112,10 @@ func (pxy *Proxy) PostXML(url string, req map[string]string) (resp map[string]st
 		return
 	}
 
+	if !checkSign {
+		return
+	}
+
 	// 认证签名
 	signature1, ok := resp["sign"]
 	if !ok {
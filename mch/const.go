@@ -14,3 +14,8 @@ const (
 	ResultCodeSuccess = "SUCCESS"
 	ResultCodeFail    = "FAIL"
 )
+
+const (
+	SignTypeMD5        = "MD5"
+	SignTypeHMACSHA256 = "HMAC-SHA256"
+)
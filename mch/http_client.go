@@ -18,6 +18,21 @@ func NewTLSHttpClient(certFile, keyFile string) (httpClient *http.Client, err er
 	if err != nil {
 		return
 	}
+	return newTLSHttpClient(cert), nil
+}
+
+// NewTLSHttpClientFromPEM 创建支持双向证书认证的 http.Client.
+//  certPEMBlock, keyPEMBlock 为商户证书 apiclient_cert.pem, apiclient_key.pem 的内容,
+//  适用于证书保存在配置中心或者数据库而不是文件里的场景.
+func NewTLSHttpClientFromPEM(certPEMBlock, keyPEMBlock []byte) (httpClient *http.Client, err error) {
+	cert, err := tls.X509KeyPair(certPEMBlock, keyPEMBlock)
+	if err != nil {
+		return
+	}
+	return newTLSHttpClient(cert), nil
+}
+
+func newTLSHttpClient(cert tls.Certificate) (httpClient *http.Client) {
 	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 	}
@@ -6,11 +6,68 @@
 package mmpaymkttransfers
 
 import (
+	"strconv"
+
 	"github.com/chanxuehong/wechat/mch"
 )
 
 // 查询企业付款.
 //  NOTE: 请求需要双向证书
 func GetTransferInfo(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
-	return pxy.PostXML("https://api.mch.weixin.qq.com/mmpaymkttransfers/gettransferinfo", req)
+	return pxy.PostXMLWithoutSign("https://api.mch.weixin.qq.com/mmpaymkttransfers/gettransferinfo", req)
+}
+
+// 企业付款的详细信息
+type TransferInfo struct {
+	PartnerTradeNo string // 商户订单号
+	DetailId       string // 付款单号, 调用企业付款API时, 微信系统内部产生的单号
+	Status         string // 转账状态, SUCCESS: 转账成功, FAILED: 转账失败, PROCESSING: 处理中
+	Reason         string // 失败原因
+	OpenId         string // 收款用户openid
+	TransferName   string // 收款用户姓名
+	PaymentAmount  int    // 付款金额, 单位为分
+	TransferTime   string // 发起转账的时间
+	PaymentTime    string // 企业付款成功时间
+	Desc           string // 企业付款备注
+}
+
+// 查询企业付款, GetTransferInfo 的结构化版本.
+//  nonceStr:       随机字符串, 不长于32位
+//  partnerTradeNo: 商户调用企业付款API时使用的商户订单号
+//  NOTE: 请求需要双向证书; result_code != SUCCESS 时返回 *mch.ResultError.
+func QueryTransfer(pxy *mch.Proxy, nonceStr, partnerTradeNo string) (info *TransferInfo, err error) {
+	req := map[string]string{
+		"appid":            pxy.AppId(),
+		"mch_id":           pxy.MchId(),
+		"nonce_str":        nonceStr,
+		"partner_trade_no": partnerTradeNo,
+	}
+	req["sign"] = pxy.Sign(req)
+
+	resp, err := GetTransferInfo(pxy, req)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	info = &TransferInfo{
+		PartnerTradeNo: resp["partner_trade_no"],
+		DetailId:       resp["detail_id"],
+		Status:         resp["status"],
+		Reason:         resp["reason"],
+		OpenId:         resp["openid"],
+		TransferName:   resp["transfer_name"],
+		TransferTime:   resp["transfer_time"],
+		PaymentTime:    resp["payment_time"],
+		Desc:           resp["desc"],
+	}
+	if s := resp["payment_amount"]; s != "" {
+		if info.PaymentAmount, err = strconv.Atoi(s); err != nil {
+			info = nil
+			return
+		}
+	}
+	return
 }
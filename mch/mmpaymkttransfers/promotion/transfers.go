@@ -6,11 +6,87 @@
 package promotion
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/chanxuehong/wechat/mch"
 )
 
 // 企业付款.
 //  NOTE: 请求需要双向证书
 func Transfers(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
-	return pxy.PostXML("https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/transfers", req)
+	return pxy.PostXMLWithoutSign("https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/transfers", req)
+}
+
+// 企业付款校验用户姓名选项
+const (
+	CheckNameNoCheck    = "NO_CHECK"    // 不校验真实姓名
+	CheckNameForceCheck = "FORCE_CHECK" // 强校验真实姓名, 未实名认证的用户会校验失败, 无法转账
+)
+
+// 企业付款的请求参数, mch_appid, mchid, sign 由 Pay 自动填写.
+type TransfersRequest struct {
+	NonceStr       string // 必须, 随机字符串, 不长于32位
+	PartnerTradeNo string // 必须, 商户订单号, 需保持唯一性
+	OpenId         string // 必须, 商户appid下某用户的openid
+	CheckName      string // 必须, CheckNameNoCheck 或者 CheckNameForceCheck
+	ReUserName     string // 可选, 收款用户真实姓名, 如果 CheckName 为 CheckNameForceCheck 则必填
+	Amount         int    // 必须, 企业付款金额, 单位为分
+	Desc           string // 必须, 企业付款操作说明信息
+	SpbillCreateIP string // 必须, 调用接口的机器Ip地址
+	DeviceInfo     string // 可选, 微信支付分配的终端设备号
+}
+
+// 企业付款的返回结果
+type TransfersResponse struct {
+	PartnerTradeNo string // 商户订单号
+	PaymentNo      string // 企业付款成功, 返回的微信订单号
+	PaymentTime    string // 企业付款成功时间, 如 2015-05-19 15:26:59
+}
+
+// 企业付款, Transfers 的结构化版本.
+//  NOTE:
+//  1. 请求需要双向证书, pxy 的 http.Client 用 mch.NewTLSHttpClient 或者 mch.NewTLSHttpClientFromPEM 创建;
+//  2. 企业付款只支持 MD5 签名;
+//  3. result_code != SUCCESS 时返回 *mch.ResultError, 如果 err_code 为 SYSTEMERROR 需要用原来的商户订单号重试.
+func Pay(pxy *mch.Proxy, req *TransfersRequest) (resp *TransfersResponse, err error) {
+	if req == nil {
+		err = errors.New("nil request req")
+		return
+	}
+
+	m := map[string]string{
+		"mch_appid":        pxy.AppId(),
+		"mchid":            pxy.MchId(),
+		"device_info":      req.DeviceInfo,
+		"nonce_str":        req.NonceStr,
+		"partner_trade_no": req.PartnerTradeNo,
+		"openid":           req.OpenId,
+		"check_name":       req.CheckName,
+		"re_user_name":     req.ReUserName,
+		"amount":           strconv.Itoa(req.Amount),
+		"desc":             req.Desc,
+		"spbill_create_ip": req.SpbillCreateIP,
+	}
+	for k, v := range m {
+		if v == "" {
+			delete(m, k)
+		}
+	}
+	m["sign"] = pxy.Sign(m)
+
+	result, err := Transfers(pxy, m)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(result); err != nil {
+		return
+	}
+
+	resp = &TransfersResponse{
+		PartnerTradeNo: result["partner_trade_no"],
+		PaymentNo:      result["payment_no"],
+		PaymentTime:    result["payment_time"],
+	}
+	return
 }
@@ -0,0 +1,202 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package promotion
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mch"
+)
+
+// 向员工付款.
+//  NOTE: 请求需要双向证书
+func PayWWSPTrans2Pocket(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
+	return pxy.PostXMLWithoutSign("https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/paywwsptrans2pocket", req)
+}
+
+// 查询向员工付款记录.
+//  NOTE: 请求需要双向证书
+func QueryWWSPTrans2Pocket(pxy *mch.Proxy, req map[string]string) (resp map[string]string, err error) {
+	return pxy.PostXMLWithoutSign("https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/querywwsptrans2pocket", req)
+}
+
+// 向员工付款的消息类型
+const (
+	WWMsgTypeNormal   = "NORMAL_MSG"   // 普通付款消息
+	WWMsgTypeApproval = "APPROVAL_MSG" // 审批付款消息
+)
+
+// 向员工付款可能返回的 mch.ResultError.ErrCode
+const (
+	ErrCodeNoAuth       = "NO_AUTH"       // 没有该接口权限
+	ErrCodeAmountLimit  = "AMOUNT_LIMIT"  // 金额超限
+	ErrCodeSendNumLimit = "SENDNUM_LIMIT" // 该用户今日付款次数超过限制
+)
+
+// 向员工付款的请求参数, appid, mch_id, sign, workwx_sign 由 PayEmployee 自动填写.
+//  appid 为企业微信的 corpid, 员工的 openid 用 addresslist.Client.ConvertToOpenId 获取.
+type EmployeePayRequest struct {
+	NonceStr       string // 必须, 随机字符串, 不长于32位
+	PartnerTradeNo string // 必须, 商户订单号, 需保持唯一性
+	OpenId         string // 必须, 员工的 openid
+	CheckName      string // 必须, CheckNameNoCheck 或者 CheckNameForceCheck
+	ReUserName     string // 可选, 收款用户真实姓名, 如果 CheckName 为 CheckNameForceCheck 则必填
+	Amount         int    // 必须, 付款金额, 单位为分
+	Desc           string // 必须, 付款说明
+	SpbillCreateIP string // 必须, 调用接口的机器Ip地址
+	WWMsgType      string // 必须, WWMsgTypeNormal 或者 WWMsgTypeApproval
+	ApprovalNumber string // 可选, 审批单号, WWMsgType 为 WWMsgTypeApproval 时必填
+	ApprovalType   int    // 可选, 审批类型, 1: 审批单, WWMsgType 为 WWMsgTypeApproval 时必填
+	ActName        string // 必须, 项目名称, 最多50个字符
+	AgentId        int64  // 可选, 付款的应用id, 付款消息将由这个应用发出
+	DeviceInfo     string // 可选, 微信支付分配的终端设备号
+}
+
+// 向员工付款的返回结果
+type EmployeePayResponse struct {
+	PartnerTradeNo string // 商户订单号
+	PaymentNo      string // 付款成功, 返回的微信订单号
+	PaymentTime    string // 付款成功时间, 如 2015-05-19 15:26:59
+}
+
+// 企业微信向员工付款, PayWWSPTrans2Pocket 的结构化版本.
+//  paySecret: 企业微信管理端 "企业支付" 应用的 secret, 用来计算 workwx_sign
+//
+//  NOTE:
+//  1. 请求需要双向证书, pxy 的 http.Client 用 mch.NewTLSHttpClient 或者 mch.NewTLSHttpClientFromPEM 创建;
+//  2. 向员工付款只支持 MD5 签名;
+//  3. result_code != SUCCESS 时返回 *mch.ResultError, ErrCode 可以和 ErrCodeNoAuth 等比较.
+func PayEmployee(pxy *mch.Proxy, paySecret string, req *EmployeePayRequest) (resp *EmployeePayResponse, err error) {
+	if req == nil {
+		err = errors.New("nil request req")
+		return
+	}
+	if paySecret == "" {
+		err = errors.New("empty paySecret")
+		return
+	}
+
+	m := map[string]string{
+		"appid":            pxy.AppId(),
+		"mch_id":           pxy.MchId(),
+		"device_info":      req.DeviceInfo,
+		"nonce_str":        req.NonceStr,
+		"partner_trade_no": req.PartnerTradeNo,
+		"openid":           req.OpenId,
+		"check_name":       req.CheckName,
+		"re_user_name":     req.ReUserName,
+		"amount":           strconv.Itoa(req.Amount),
+		"desc":             req.Desc,
+		"spbill_create_ip": req.SpbillCreateIP,
+		"ww_msg_type":      req.WWMsgType,
+		"approval_number":  req.ApprovalNumber,
+		"act_name":         req.ActName,
+	}
+	if req.ApprovalType != 0 {
+		m["approval_type"] = strconv.Itoa(req.ApprovalType)
+	}
+	if req.AgentId != 0 {
+		m["agentid"] = strconv.FormatInt(req.AgentId, 10)
+	}
+	for k, v := range m {
+		if v == "" {
+			delete(m, k)
+		}
+	}
+	m["workwx_sign"] = WorkWXSign(m, paySecret)
+	m["sign"] = pxy.Sign(m)
+
+	result, err := PayWWSPTrans2Pocket(pxy, m)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(result); err != nil {
+		return
+	}
+
+	resp = &EmployeePayResponse{
+		PartnerTradeNo: result["partner_trade_no"],
+		PaymentNo:      result["payment_no"],
+		PaymentTime:    result["payment_time"],
+	}
+	return
+}
+
+// 向员工付款记录
+type EmployeePayInfo struct {
+	PartnerTradeNo string // 商户订单号
+	DetailId       string // 付款单号
+	Status         string // 转账状态, SUCCESS: 转账成功, FAILED: 转账失败, PROCESSING: 处理中
+	Reason         string // 失败原因
+	OpenId         string // 收款员工的 openid
+	TransferName   string // 收款员工姓名
+	PaymentAmount  int    // 付款金额, 单位为分
+	TransferTime   string // 发起转账的时间
+	Desc           string // 付款说明
+}
+
+// 查询向员工付款记录, QueryWWSPTrans2Pocket 的结构化版本.
+//  NOTE: 请求需要双向证书; result_code != SUCCESS 时返回 *mch.ResultError.
+func QueryEmployeePay(pxy *mch.Proxy, nonceStr, partnerTradeNo string) (info *EmployeePayInfo, err error) {
+	req := map[string]string{
+		"appid":            pxy.AppId(),
+		"mch_id":           pxy.MchId(),
+		"nonce_str":        nonceStr,
+		"partner_trade_no": partnerTradeNo,
+	}
+	req["sign"] = pxy.Sign(req)
+
+	resp, err := QueryWWSPTrans2Pocket(pxy, req)
+	if err != nil {
+		return
+	}
+	if err = mch.CheckResultCode(resp); err != nil {
+		return
+	}
+
+	info = &EmployeePayInfo{
+		PartnerTradeNo: resp["partner_trade_no"],
+		DetailId:       resp["detail_id"],
+		Status:         resp["status"],
+		Reason:         resp["reason"],
+		OpenId:         resp["openid"],
+		TransferName:   resp["transfer_name"],
+		TransferTime:   resp["transfer_time"],
+		Desc:           resp["desc"],
+	}
+	if s := resp["payment_amount"]; s != "" {
+		if info.PaymentAmount, err = strconv.Atoi(s); err != nil {
+			info = nil
+			return
+		}
+	}
+	return
+}
+
+// 向员工付款的企业微信签名 workwx_sign.
+//  参与签名的参数按照字典序为 amount, appid, desc, mch_id, nonce_str, openid, partner_trade_no, ww_msg_type,
+//  最后拼接 &secret=paySecret 做 MD5, 结果转换为大写的十六进制.
+func WorkWXSign(parameters map[string]string, paySecret string) string {
+	h := md5.New()
+	for i, k := range []string{"amount", "appid", "desc", "mch_id", "nonce_str", "openid", "partner_trade_no", "ww_msg_type"} {
+		if i > 0 {
+			h.Write([]byte{'&'})
+		}
+		h.Write([]byte(k))
+		h.Write([]byte{'='})
+		h.Write([]byte(parameters[k]))
+	}
+	h.Write([]byte("&secret="))
+	h.Write([]byte(paySecret))
+
+	signature := make([]byte, h.Size()*2)
+	hex.Encode(signature, h.Sum(nil))
+	return string(bytes.ToUpper(signature))
+}
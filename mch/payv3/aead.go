@@ -0,0 +1,79 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+)
+
+const AlgorithmAEADAES256GCM = "AEAD_AES_256_GCM"
+
+// 用 APIv3 密钥解密 AEAD_AES_256_GCM 加密的数据, 比如回调通知的 resource 和平台证书.
+//  ciphertext 为 base64 编码的密文.
+func DecryptAES256GCM(apiV3Key []byte, associatedData, nonce, ciphertext string) (plaintext []byte, err error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return
+	}
+	block, err := aes.NewCipher(apiV3Key)
+	if err != nil {
+		return
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return
+	}
+	return aead.Open(nil, []byte(nonce), data, []byte(associatedData))
+}
+
+// 用 APIv3 密钥做 AEAD_AES_256_GCM 加密, 返回 base64 编码的密文, 一般只用于测试.
+func EncryptAES256GCM(apiV3Key []byte, associatedData, nonce string, plaintext []byte) (ciphertext string, err error) {
+	block, err := aes.NewCipher(apiV3Key)
+	if err != nil {
+		return
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return
+	}
+	ciphertext = base64.StdEncoding.EncodeToString(aead.Seal(nil, []byte(nonce), plaintext, []byte(associatedData)))
+	return
+}
+
+// 用 Client 的 APIv3 密钥解密.
+func (clt *Client) DecryptAES256GCM(associatedData, nonce, ciphertext string) (plaintext []byte, err error) {
+	return DecryptAES256GCM(clt.apiV3Key, associatedData, nonce, ciphertext)
+}
+
+// 解析 PEM 格式的商户 API 证书私钥 apiclient_key.pem, 支持 PKCS#1 和 PKCS#8.
+func ParsePrivateKey(pemBlock []byte) (privateKey *rsa.PrivateKey, err error) {
+	block, _ := pem.Decode(pemBlock)
+	if block == nil {
+		err = errors.New("invalid PEM data")
+		return
+	}
+
+	if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		err = errors.New("not a RSA private key")
+		return
+	}
+	return
+}
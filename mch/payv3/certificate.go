@@ -0,0 +1,144 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// 加密的数据, 回调通知的 resource 和平台证书都是这个格式.
+type EncryptedResource struct {
+	Algorithm      string `json:"algorithm"` // AlgorithmAEADAES256GCM
+	Ciphertext     string `json:"ciphertext"`
+	AssociatedData string `json:"associated_data"`
+	Nonce          string `json:"nonce"`
+	OriginalType   string `json:"original_type,omitempty"` // 回调通知的原始类型, 比如 transaction, refund
+}
+
+// 用 Client 的 APIv3 密钥解密 resource.
+func (clt *Client) DecryptResource(resource *EncryptedResource) (plaintext []byte, err error) {
+	if resource == nil {
+		err = errors.New("nil resource")
+		return
+	}
+	if resource.Algorithm != AlgorithmAEADAES256GCM {
+		err = fmt.Errorf("unsupported algorithm: %s", resource.Algorithm)
+		return
+	}
+	return clt.DecryptAES256GCM(resource.AssociatedData, resource.Nonce, resource.Ciphertext)
+}
+
+// 微信支付平台证书
+type Certificate struct {
+	SerialNo      string             `json:"serial_no"`      // 证书序列号
+	EffectiveTime string             `json:"effective_time"` // 证书启用时间
+	ExpireTime    string             `json:"expire_time"`    // 证书弃用时间
+	Encrypt       *EncryptedResource `json:"encrypt_certificate"`
+
+	Certificate *x509.Certificate `json:"-"` // 解密后的证书
+}
+
+// 下载微信支付平台证书, 并且设置为 Client 验证签名使用的证书, 返回下载的证书.
+//  平台证书会定期更换, 建议定时(比如每 12 小时)调用一次.
+//
+//  NOTE: 下载证书的应答用下载到的证书验证签名, 所以第一次调用不需要先设置平台证书.
+func (clt *Client) DownloadCertificates() (certs []*Certificate, err error) {
+	body, header, err := clt.do("GET", "/v3/certificates", nil)
+	if err != nil {
+		return
+	}
+
+	var result struct {
+		Data []*Certificate `json:"data"`
+	}
+	if err = json.Unmarshal(body, &result); err != nil {
+		return
+	}
+
+	publicKeys := make(map[string]*rsa.PublicKey, len(result.Data))
+	certificates := make(map[string]*x509.Certificate, len(result.Data))
+	for _, cert := range result.Data {
+		var certPEM []byte
+		if certPEM, err = clt.DecryptResource(cert.Encrypt); err != nil {
+			return
+		}
+		if cert.Certificate, err = parseCertificate(certPEM); err != nil {
+			return
+		}
+		publicKey, ok := cert.Certificate.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			err = fmt.Errorf("the public key of platform certificate %s is not RSA", cert.SerialNo)
+			return
+		}
+		publicKeys[cert.SerialNo] = publicKey
+		certificates[cert.SerialNo] = cert.Certificate
+	}
+
+	// 用下载到的证书验证应答的签名
+	serialNo := header.Get("Wechatpay-Serial")
+	publicKey := publicKeys[serialNo]
+	if publicKey == nil {
+		err = fmt.Errorf("platform certificate not found for serial_no: %s", serialNo)
+		return
+	}
+	err = verifySignature(publicKey, header.Get("Wechatpay-Timestamp"), header.Get("Wechatpay-Nonce"), body, header.Get("Wechatpay-Signature"))
+	if err != nil {
+		return
+	}
+
+	clt.rwmutex.Lock()
+	for serialNo, publicKey := range publicKeys {
+		clt.platformCerts[serialNo] = publicKey
+		clt.certificates[serialNo] = certificates[serialNo]
+	}
+	clt.rwmutex.Unlock()
+	certs = result.Data
+	return
+}
+
+func parseCertificate(certPEM []byte) (cert *x509.Certificate, err error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		err = errors.New("invalid PEM data")
+		return
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// 微信支付平台证书的存储, 用于 PayNotifyVerifier 验证回调通知的签名.
+type CertificateStore interface {
+	// 获取序列号为 serialNo 的平台证书, 没有找到返回错误.
+	Get(serialNo string) (*x509.Certificate, error)
+	// 重新下载平台证书, 微信支付更换平台证书的时候, 回调通知可能使用新的证书签名.
+	Refresh() error
+}
+
+var _ CertificateStore = (*Client)(nil)
+
+// 获取 DownloadCertificates 下载的平台证书.
+//  NOTE: SetPlatformCertificate 只设置了公钥, 不能通过 Get 获取.
+func (clt *Client) Get(serialNo string) (cert *x509.Certificate, err error) {
+	clt.rwmutex.RLock()
+	cert = clt.certificates[serialNo]
+	clt.rwmutex.RUnlock()
+
+	if cert == nil {
+		err = fmt.Errorf("platform certificate not found for serial_no: %s", serialNo)
+		return
+	}
+	return
+}
+
+// 调用 DownloadCertificates 重新下载平台证书.
+func (clt *Client) Refresh() (err error) {
+	_, err = clt.DownloadCertificates()
+	return
+}
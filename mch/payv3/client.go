@@ -0,0 +1,253 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	BaseURL = "https://api.mch.weixin.qq.com"
+
+	authorizationSchema = "WECHATPAY2-SHA256-RSA2048"
+)
+
+// 微信支付 APIv3 返回的错误, http 状态码不是 2xx 时返回.
+type Error struct {
+	StatusCode int             `json:"-"`       // http 状态码
+	Code       string          `json:"code"`    // 详细错误码
+	Message    string          `json:"message"` // 错误描述
+	Detail     json.RawMessage `json:"detail,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("http status: %d, code: %s, message: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// 微信支付 APIv3 的客户端, 并发安全.
+type Client struct {
+	mchId      string
+	serialNo   string          // 商户 API 证书的序列号
+	privateKey *rsa.PrivateKey // 商户 API 证书的私钥
+	apiV3Key   []byte          // APIv3 密钥, 32 bytes
+	httpClient *http.Client
+
+	rwmutex       sync.RWMutex
+	platformCerts map[string]*rsa.PublicKey    // map[serial_no]*rsa.PublicKey, 微信支付平台证书的公钥
+	certificates  map[string]*x509.Certificate // map[serial_no]*x509.Certificate, DownloadCertificates 下载的证书
+}
+
+// 创建一个新的 Client.
+//  serialNo:   商户 API 证书的序列号
+//  privateKey: 商户 API 证书的私钥, 可以用 ParsePrivateKey 解析 apiclient_key.pem
+//  apiV3Key:   APIv3 密钥, 在商户平台设置, 32 个字符
+//  httpClient: 如果 httpClient == nil 则默认用 http.DefaultClient
+func NewClient(mchId, serialNo string, privateKey *rsa.PrivateKey, apiV3Key string, httpClient *http.Client) *Client {
+	if mchId == "" {
+		panic("empty mchId")
+	}
+	if serialNo == "" {
+		panic("empty serialNo")
+	}
+	if privateKey == nil {
+		panic("nil privateKey")
+	}
+	if len(apiV3Key) != 32 {
+		panic("the length of apiV3Key must equal to 32")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		mchId:         mchId,
+		serialNo:      serialNo,
+		privateKey:    privateKey,
+		apiV3Key:      []byte(apiV3Key),
+		httpClient:    httpClient,
+		platformCerts: make(map[string]*rsa.PublicKey),
+		certificates:  make(map[string]*x509.Certificate),
+	}
+}
+
+func (clt *Client) MchId() string {
+	return clt.mchId
+}
+
+// 设置用于验证应答和回调通知签名的微信支付平台证书公钥.
+func (clt *Client) SetPlatformCertificate(serialNo string, publicKey *rsa.PublicKey) {
+	if serialNo == "" {
+		panic("empty serialNo")
+	}
+	if publicKey == nil {
+		panic("nil publicKey")
+	}
+	clt.rwmutex.Lock()
+	clt.platformCerts[serialNo] = publicKey
+	clt.rwmutex.Unlock()
+}
+
+func (clt *Client) platformCertificate(serialNo string) (publicKey *rsa.PublicKey, err error) {
+	clt.rwmutex.RLock()
+	publicKey = clt.platformCerts[serialNo]
+	n := len(clt.platformCerts)
+	clt.rwmutex.RUnlock()
+
+	if publicKey != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.New("no platform certificate, call DownloadCertificates or SetPlatformCertificate first")
+		return
+	}
+	err = fmt.Errorf("platform certificate not found for serial_no: %s", serialNo)
+	return
+}
+
+// 生成 32 个字符的随机字符串.
+func NewNonceStr() string {
+	var b [16]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// 用商户私钥做 SHA256 with RSA 签名, 返回 base64 编码的签名.
+func (clt *Client) Sign(message string) (signature string, err error) {
+	hashsum := sha256.Sum256([]byte(message))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, clt.privateKey, crypto.SHA256, hashsum[:])
+	if err != nil {
+		return
+	}
+	signature = base64.StdEncoding.EncodeToString(sig)
+	return
+}
+
+// 计算请求的 Authorization 头.
+//  canonicalURL: 请求的绝对 URL 去掉域名部分, 包含查询参数, 比如 /v3/certificates?a=1
+func (clt *Client) Authorization(method, canonicalURL string, timestamp int64, nonceStr string, body []byte) (authorization string, err error) {
+	timestampStr := strconv.FormatInt(timestamp, 10)
+	message := method + "\n" + canonicalURL + "\n" + timestampStr + "\n" + nonceStr + "\n" + string(body) + "\n"
+	signature, err := clt.Sign(message)
+	if err != nil {
+		return
+	}
+	authorization = fmt.Sprintf(`%s mchid="%s",nonce_str="%s",timestamp="%s",serial_no="%s",signature="%s"`,
+		authorizationSchema, clt.mchId, nonceStr, timestampStr, clt.serialNo, signature)
+	return
+}
+
+// 验证微信支付的签名, 应答和回调通知的验签规则相同.
+func (clt *Client) VerifySignature(header http.Header, body []byte) (err error) {
+	timestamp := header.Get("Wechatpay-Timestamp")
+	nonce := header.Get("Wechatpay-Nonce")
+	signature := header.Get("Wechatpay-Signature")
+	serialNo := header.Get("Wechatpay-Serial")
+	if timestamp == "" || nonce == "" || signature == "" || serialNo == "" {
+		return errors.New("missing Wechatpay-Timestamp, Wechatpay-Nonce, Wechatpay-Signature or Wechatpay-Serial header")
+	}
+
+	publicKey, err := clt.platformCertificate(serialNo)
+	if err != nil {
+		return
+	}
+	return verifySignature(publicKey, timestamp, nonce, body, signature)
+}
+
+func verifySignature(publicKey *rsa.PublicKey, timestamp, nonce string, body []byte, signature string) (err error) {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return
+	}
+	message := timestamp + "\n" + nonce + "\n" + string(body) + "\n"
+	hashsum := sha256.Sum256([]byte(message))
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashsum[:], sig); err != nil {
+		return errors.New("check wechatpay signature failed")
+	}
+	return
+}
+
+// 调用微信支付 APIv3.
+//  path:     请求的路径, 包含查询参数, 比如 /v3/pay/transactions/jsapi
+//  request:  请求的数据, 会被 encoding/json marshal 后作为请求的 body, GET 请求为 nil
+//  response: 应答的数据, 用 encoding/json 解析应答的 body, 为 nil 时忽略应答的 body
+//
+//  NOTE: 应答都会用微信支付平台证书验证签名, http 状态码不是 2xx 时返回 *Error.
+func (clt *Client) Do(method, path string, request, response interface{}) (err error) {
+	body, header, err := clt.do(method, path, request)
+	if err != nil {
+		return
+	}
+	if err = clt.VerifySignature(header, body); err != nil {
+		return
+	}
+	if response == nil || len(body) == 0 {
+		return
+	}
+	return json.Unmarshal(body, response)
+}
+
+// 发送请求并且读取应答, 不验证应答的签名.
+func (clt *Client) do(method, path string, request interface{}) (body []byte, header http.Header, err error) {
+	var reqBody []byte
+	if request != nil {
+		if reqBody, err = json.Marshal(request); err != nil {
+			return
+		}
+	}
+
+	authorization, err := clt.Authorization(method, path, time.Now().Unix(), NewNonceStr(), reqBody)
+	if err != nil {
+		return
+	}
+
+	httpReq, err := http.NewRequest(method, BaseURL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return
+	}
+	httpReq.Header.Set("Authorization", authorization)
+	httpReq.Header.Set("Accept", "application/json")
+	if request != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := clt.httpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if body, err = ioutil.ReadAll(httpResp.Body); err != nil {
+		return
+	}
+	header = httpResp.Header
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		e := &Error{StatusCode: httpResp.StatusCode}
+		if json.Unmarshal(body, e) != nil || e.Code == "" {
+			e.Message = httpResp.Status
+		}
+		err = e
+		return
+	}
+	return
+}
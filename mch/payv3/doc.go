@@ -0,0 +1,14 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信支付 APIv3.
+//
+//  APIv3 使用 JSON 和 WECHATPAY2-SHA256-RSA2048 签名(商户 API 证书的私钥), 回调通知和敏感信息用 APIv3 密钥
+//  做 AEAD_AES_256_GCM 加密, 微信支付的应答和回调通知用微信支付平台证书签名.
+//
+//  clt := payv3.NewClient(mchId, serialNo, privateKey, apiV3Key, nil)
+//  if _, err := clt.DownloadCertificates(); err != nil { ... }
+//  prepayId, err := clt.JSAPI(&payv3.TransactionRequest{...})
+package payv3
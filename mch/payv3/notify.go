@@ -0,0 +1,58 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// 通知的类型
+const (
+	EventTypeTransactionSuccess = "TRANSACTION.SUCCESS" // 支付成功
+	EventTypeRefundSuccess      = "REFUND.SUCCESS"      // 退款成功
+	EventTypeRefundAbnormal     = "REFUND.ABNORMAL"     // 退款异常
+	EventTypeRefundClosed       = "REFUND.CLOSED"       // 退款关闭
+)
+
+// 微信支付的回调通知
+type Notification struct {
+	Id           string             `json:"id"`            // 通知的唯一id
+	CreateTime   string             `json:"create_time"`   // 通知创建的时间, rfc3339 格式
+	EventType    string             `json:"event_type"`    // 通知的类型, 参考 EventTypeXXX
+	ResourceType string             `json:"resource_type"` // 通知数据的类型, 一般为 encrypt-resource
+	Resource     *EncryptedResource `json:"resource"`      // 加密的通知数据
+	Summary      string             `json:"summary"`       // 回调摘要
+
+	Plaintext []byte `json:"-"` // 解密后的 Resource, 一般是 json 格式, 比如支付成功通知可以解析到 Transaction
+}
+
+// 读取并验证 r 的回调通知, 成功后解密通知数据到 notification.Plaintext.
+//  处理成功后回复 http 200 或 204, 失败回复 4xx/5xx 和 {"code": "FAIL", "message": "失败"}.
+//  和 PayNotifyVerifier.Verify 一样, Wechatpay-Timestamp 和服务器时间相差超过 NotifyTimestampTolerance 的通知返回错误.
+func (clt *Client) ParseNotification(r *http.Request) (notification *Notification, err error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	if err = clt.VerifySignature(r.Header, body); err != nil {
+		return
+	}
+	if err = checkNotifyTimestamp(r.Header.Get("Wechatpay-Timestamp")); err != nil {
+		return
+	}
+
+	var n Notification
+	if err = json.Unmarshal(body, &n); err != nil {
+		return
+	}
+	if n.Plaintext, err = clt.DecryptResource(n.Resource); err != nil {
+		return
+	}
+	notification = &n
+	return
+}
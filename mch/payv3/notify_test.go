@@ -0,0 +1,243 @@
+package payv3
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAPIV3Key = "0123456789abcdef0123456789abcdef"
+
+func newTestClient(t *testing.T) (clt *Client, platform *Client) {
+	merchantKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	platformKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clt = NewClient("1900000001", "MERCHANT_SERIAL", merchantKey, testAPIV3Key, nil)
+	clt.SetPlatformCertificate("PLATFORM_SERIAL", &platformKey.PublicKey)
+
+	// 用 Client 模拟微信支付平台签名
+	platform = NewClient("1900000001", "PLATFORM_SERIAL", platformKey, testAPIV3Key, nil)
+	return
+}
+
+func TestAuthorization(t *testing.T) {
+	clt, _ := newTestClient(t)
+
+	authorization, err := clt.Authorization("GET", "/v3/certificates", 1554208460, "593BEC0C930BF1AFEB40B4A08C8FB242", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := `WECHATPAY2-SHA256-RSA2048 mchid="1900000001",nonce_str="593BEC0C930BF1AFEB40B4A08C8FB242",timestamp="1554208460",serial_no="MERCHANT_SERIAL",signature="`
+	if !strings.HasPrefix(authorization, prefix) {
+		t.Errorf("Authorization() = %q, want prefix %q", authorization, prefix)
+	}
+	signature := strings.TrimSuffix(strings.TrimPrefix(authorization, prefix), `"`)
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashsum := sha256.Sum256([]byte("GET\n/v3/certificates\n1554208460\n593BEC0C930BF1AFEB40B4A08C8FB242\n\n"))
+	if err = rsa.VerifyPKCS1v15(&clt.privateKey.PublicKey, crypto.SHA256, hashsum[:], sig); err != nil {
+		t.Errorf("verify Authorization signature: %v", err)
+	}
+}
+
+func TestAES256GCM(t *testing.T) {
+	plaintext := []byte(`{"out_trade_no":"1217752501201407033233368018"}`)
+	ciphertext, err := EncryptAES256GCM([]byte(testAPIV3Key), "transaction", "fdasflkja484", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecryptAES256GCM([]byte(testAPIV3Key), "transaction", "fdasflkja484", ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("DecryptAES256GCM() = %s, want %s", got, plaintext)
+	}
+	if _, err = DecryptAES256GCM([]byte(testAPIV3Key), "refund", "fdasflkja484", ciphertext); err == nil {
+		t.Error("DecryptAES256GCM() with wrong associated data should fail")
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	clt, platform := newTestClient(t)
+
+	ciphertext, err := EncryptAES256GCM([]byte(testAPIV3Key), "transaction", "fdasflkja484",
+		[]byte(`{"out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(&Notification{
+		Id:           "EV-2018022511223320873",
+		EventType:    EventTypeTransactionSuccess,
+		ResourceType: "encrypt-resource",
+		Resource: &EncryptedResource{
+			Algorithm:      AlgorithmAEADAES256GCM,
+			Ciphertext:     ciphertext,
+			AssociatedData: "transaction",
+			Nonce:          "fdasflkja484",
+			OriginalType:   "transaction",
+		},
+	})
+
+	newRequestAt := func(body []byte, timestamp int64) *http.Request {
+		ts := strconv.FormatInt(timestamp, 10)
+		signature, err := platform.Sign(ts + "\nnonce\n" + string(body) + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, _ := http.NewRequest("POST", "/notify", bytes.NewReader(body))
+		r.Header.Set("Wechatpay-Timestamp", ts)
+		r.Header.Set("Wechatpay-Nonce", "nonce")
+		r.Header.Set("Wechatpay-Signature", signature)
+		r.Header.Set("Wechatpay-Serial", "PLATFORM_SERIAL")
+		return r
+	}
+	newRequest := func(body []byte) *http.Request {
+		return newRequestAt(body, time.Now().Unix())
+	}
+
+	notification, err := clt.ParseNotification(newRequest(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transaction Transaction
+	if err = json.Unmarshal(notification.Plaintext, &transaction); err != nil {
+		t.Fatal(err)
+	}
+	if transaction.OutTradeNo != "1217752501201407033233368018" || transaction.TradeState != TradeStateSuccess {
+		t.Errorf("unexpected transaction: %+v", transaction)
+	}
+
+	// 签名之后 body 被修改
+	r := newRequest(body)
+	r.Body = ioutil.NopCloser(strings.NewReader(`{}`))
+	if _, err = clt.ParseNotification(r); err == nil {
+		t.Error("ParseNotification() with tampered body should fail")
+	}
+
+	// 签名正确但是时间戳过期, 认为是重放的请求
+	if _, err = clt.ParseNotification(newRequestAt(body, time.Now().Add(-time.Hour).Unix())); err == nil {
+		t.Error("ParseNotification() with expired timestamp should fail")
+	}
+}
+
+type testCertStore struct {
+	certs     map[string]*x509.Certificate
+	refreshed map[string]*x509.Certificate // Refresh 之后才有的证书
+	refreshes int
+}
+
+func (store *testCertStore) Get(serialNo string) (*x509.Certificate, error) {
+	if cert := store.certs[serialNo]; cert != nil {
+		return cert, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (store *testCertStore) Refresh() error {
+	store.refreshes++
+	for serialNo, cert := range store.refreshed {
+		store.certs[serialNo] = cert
+	}
+	return nil
+}
+
+func TestPayNotifyVerifier(t *testing.T) {
+	platformKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &platformKey.PublicKey, platformKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &testCertStore{
+		certs:     map[string]*x509.Certificate{},
+		refreshed: map[string]*x509.Certificate{"NEW_SERIAL": cert},
+	}
+	verifier := NewPayNotifyVerifier("1900000001", testAPIV3Key, store)
+	platform := NewClient("1900000001", "NEW_SERIAL", platformKey, testAPIV3Key, nil)
+
+	body := []byte(`{"id":"EV-2018022511223320873"}`)
+	newRequest := func(timestamp int64) *http.Request {
+		ts := strconv.FormatInt(timestamp, 10)
+		signature, err := platform.Sign(ts + "\nnonce\n" + string(body) + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, _ := http.NewRequest("POST", "/notify", bytes.NewReader(body))
+		r.Header.Set("Wechatpay-Timestamp", ts)
+		r.Header.Set("Wechatpay-Nonce", "nonce")
+		r.Header.Set("Wechatpay-Signature", signature)
+		r.Header.Set("Wechatpay-Serial", "NEW_SERIAL")
+		return r
+	}
+	newRequestWithSerial := func(serialNo string) *http.Request {
+		r := newRequest(time.Now().Unix())
+		r.Header.Set("Wechatpay-Serial", serialNo)
+		return r
+	}
+
+	// 证书不在 store 里, 需要 Refresh
+	if err = verifier.Verify(newRequest(time.Now().Unix()), body); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+	if err = verifier.Verify(newRequest(time.Now().Unix()), []byte(`{}`)); err == nil {
+		t.Error("Verify() with tampered body should fail")
+	}
+	if err = verifier.Verify(newRequest(time.Now().Add(-time.Hour).Unix()), body); err == nil {
+		t.Error("Verify() with expired timestamp should fail")
+	}
+
+	// 未知的证书序列号在 CertificateRefreshInterval 之内不再 Refresh
+	for _, serialNo := range []string{"UNKNOWN_1", "UNKNOWN_2", "UNKNOWN_3"} {
+		if err = verifier.Verify(newRequestWithSerial(serialNo), body); err == nil {
+			t.Errorf("Verify() with serial %s should fail", serialNo)
+		}
+	}
+	if store.refreshes != 1 {
+		t.Errorf("Refresh called %d times, want 1", store.refreshes)
+	}
+
+	ciphertext, err := EncryptAES256GCM([]byte(testAPIV3Key), "transaction", "fdasflkja484", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := verifier.Decrypt(ciphertext, "fdasflkja484", "transaction")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, body) {
+		t.Errorf("Decrypt() = %s, want %s", plaintext, body)
+	}
+}
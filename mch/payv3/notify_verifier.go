@@ -0,0 +1,124 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 回调通知的时间戳和服务器时间最多相差多少, 超过的认为是重放的请求.
+const NotifyTimestampTolerance = 5 * time.Minute
+
+// 找不到回调通知里的证书序列号时, 两次 Refresh 之间最少间隔多少.
+//  Refresh 在验证签名之前调用, 不加限制的话伪造的回调通知每次都会触发一次下载.
+const CertificateRefreshInterval = time.Minute
+
+// PayNotifyVerifier 验证和解密微信支付的回调通知, 不需要商户私钥, 适合单独部署的回调服务:
+//  verifier := payv3.NewPayNotifyVerifier(mchId, apiV3Key, clt)
+//  body, _ := ioutil.ReadAll(r.Body)
+//  if err := verifier.Verify(r, body); err != nil { ... }
+//
+//  一般直接使用 Client, 它实现了 CertificateStore.
+type PayNotifyVerifier struct {
+	mchId     string
+	apiV3Key  []byte
+	certStore CertificateStore
+
+	refreshMutex sync.Mutex
+	lastRefresh  time.Time
+}
+
+// 创建一个新的 PayNotifyVerifier.
+//  apiV3Key:  APIv3 密钥, 32 个字符
+//  certStore: 平台证书的存储, 找不到回调通知里的证书序列号时会调用 Refresh, 每 CertificateRefreshInterval 最多一次
+func NewPayNotifyVerifier(mchId, apiV3Key string, certStore CertificateStore) *PayNotifyVerifier {
+	if mchId == "" {
+		panic("empty mchId")
+	}
+	if len(apiV3Key) != 32 {
+		panic("the length of apiV3Key must equal to 32")
+	}
+	if certStore == nil {
+		panic("nil CertificateStore")
+	}
+	return &PayNotifyVerifier{
+		mchId:     mchId,
+		apiV3Key:  []byte(apiV3Key),
+		certStore: certStore,
+	}
+}
+
+func (verifier *PayNotifyVerifier) MchId() string {
+	return verifier.mchId
+}
+
+// 验证回调通知的签名, body 为 r.Body 读取到的全部内容.
+//  签名串为 timestamp + "\n" + nonce + "\n" + body + "\n", 用 Wechatpay-Serial 对应的平台证书做 SHA256 with RSA 验证.
+func (verifier *PayNotifyVerifier) Verify(r *http.Request, body []byte) (err error) {
+	timestamp := r.Header.Get("Wechatpay-Timestamp")
+	nonce := r.Header.Get("Wechatpay-Nonce")
+	signature := r.Header.Get("Wechatpay-Signature")
+	serialNo := r.Header.Get("Wechatpay-Serial")
+	if timestamp == "" || nonce == "" || signature == "" || serialNo == "" {
+		return errors.New("missing Wechatpay-Timestamp, Wechatpay-Nonce, Wechatpay-Signature or Wechatpay-Serial header")
+	}
+
+	if err = checkNotifyTimestamp(timestamp); err != nil {
+		return
+	}
+
+	cert, err := verifier.certStore.Get(serialNo)
+	if err != nil {
+		// 可能是微信支付更换了平台证书
+		if err = verifier.refresh(); err != nil {
+			return
+		}
+		if cert, err = verifier.certStore.Get(serialNo); err != nil {
+			return
+		}
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("the public key of platform certificate %s is not RSA", serialNo)
+	}
+	return verifySignature(publicKey, timestamp, nonce, body, signature)
+}
+
+// 调用 certStore.Refresh, 同一时间只有一个 Refresh 在执行, 其他的调用等待它完成;
+// 距离上一次 Refresh 不到 CertificateRefreshInterval 的时候直接返回, 由调用者重新 Get.
+func (verifier *PayNotifyVerifier) refresh() error {
+	verifier.refreshMutex.Lock()
+	defer verifier.refreshMutex.Unlock()
+
+	if now := time.Now(); now.Sub(verifier.lastRefresh) >= CertificateRefreshInterval {
+		verifier.lastRefresh = now
+		return verifier.certStore.Refresh()
+	}
+	return nil
+}
+
+// 解密回调通知的 resource.
+func (verifier *PayNotifyVerifier) Decrypt(ciphertext, nonce, associatedData string) (plaintext []byte, err error) {
+	return DecryptAES256GCM(verifier.apiV3Key, associatedData, nonce, ciphertext)
+}
+
+// 检查回调通知的 Wechatpay-Timestamp 和服务器时间相差不超过 NotifyTimestampTolerance.
+func checkNotifyTimestamp(timestamp string) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid Wechatpay-Timestamp: %s", timestamp)
+	}
+	if d := time.Since(time.Unix(ts, 0)); d > NotifyTimestampTolerance || d < -NotifyTimestampTolerance {
+		return fmt.Errorf("Wechatpay-Timestamp %s is out of range", timestamp)
+	}
+	return nil
+}
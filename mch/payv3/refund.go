@@ -0,0 +1,93 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"errors"
+	"net/url"
+)
+
+// 退款金额
+type RefundAmount struct {
+	Refund   int64  `json:"refund"`   // 退款金额, 单位为分
+	Total    int64  `json:"total"`    // 原订单金额, 单位为分
+	Currency string `json:"currency"` // 退款币种, 目前只支持 CNY
+}
+
+// 申请退款的参数
+type RefundRequest struct {
+	TransactionId string       `json:"transaction_id,omitempty"` // TransactionId 和 OutTradeNo 二选一
+	OutTradeNo    string       `json:"out_trade_no,omitempty"`   // TransactionId 和 OutTradeNo 二选一
+	OutRefundNo   string       `json:"out_refund_no"`            // 必须; 商户退款单号
+	Reason        string       `json:"reason,omitempty"`         // 退款原因
+	NotifyURL     string       `json:"notify_url,omitempty"`     // 退款结果通知的地址
+	Amount        RefundAmount `json:"amount"`                   // 必须; 退款金额
+}
+
+// 退款状态
+const (
+	RefundStatusSuccess    = "SUCCESS"    // 退款成功
+	RefundStatusClosed     = "CLOSED"     // 退款关闭
+	RefundStatusProcessing = "PROCESSING" // 退款处理中
+	RefundStatusAbnormal   = "ABNORMAL"   // 退款异常
+)
+
+// 退款单信息
+type Refund struct {
+	RefundId            string `json:"refund_id"` // 微信支付退款单号
+	OutRefundNo         string `json:"out_refund_no"`
+	TransactionId       string `json:"transaction_id"`
+	OutTradeNo          string `json:"out_trade_no"`
+	Channel             string `json:"channel"`               // 退款渠道, ORIGINAL, BALANCE, OTHER_BALANCE, OTHER_BANKCARD
+	UserReceivedAccount string `json:"user_received_account"` // 退款入账账户
+	SuccessTime         string `json:"success_time"`          // 退款成功时间, rfc3339 格式
+	CreateTime          string `json:"create_time"`           // 退款创建时间, rfc3339 格式
+	Status              string `json:"status"`                // 退款状态, 参考 RefundStatusXXX
+	Amount              struct {
+		Total       int64  `json:"total"`        // 订单金额
+		Refund      int64  `json:"refund"`       // 退款金额
+		PayerTotal  int64  `json:"payer_total"`  // 用户支付金额
+		PayerRefund int64  `json:"payer_refund"` // 用户退款金额
+		Currency    string `json:"currency"`
+	} `json:"amount"`
+}
+
+// 申请退款.
+func (clt *Client) Refund(req *RefundRequest) (refund *Refund, err error) {
+	if req == nil {
+		err = errors.New("nil RefundRequest")
+		return
+	}
+	if req.TransactionId == "" && req.OutTradeNo == "" {
+		err = errors.New("both TransactionId and OutTradeNo are empty")
+		return
+	}
+	if req.OutRefundNo == "" {
+		err = errors.New("empty OutRefundNo")
+		return
+	}
+
+	var result Refund
+	if err = clt.Do("POST", "/v3/refund/domestic/refunds", req, &result); err != nil {
+		return
+	}
+	refund = &result
+	return
+}
+
+// 用商户退款单号查询退款.
+func (clt *Client) QueryRefund(outRefundNo string) (refund *Refund, err error) {
+	if outRefundNo == "" {
+		err = errors.New("empty outRefundNo")
+		return
+	}
+	var result Refund
+	if err = clt.Do("GET", "/v3/refund/domestic/refunds/"+url.PathEscape(outRefundNo), nil, &result); err != nil {
+		return
+	}
+	refund = &result
+	return
+}
@@ -0,0 +1,242 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 订单金额
+type Amount struct {
+	Total    int64  `json:"total"`              // 总金额, 单位为分
+	Currency string `json:"currency,omitempty"` // 货币类型, 默认 CNY
+}
+
+// 支付者
+type Payer struct {
+	OpenId string `json:"openid"` // 用户在 AppId 下的 openid
+}
+
+// H5 场景信息
+type H5Info struct {
+	Type string `json:"type"` // 场景类型, iOS, Android, Wap
+}
+
+// 支付场景描述
+type SceneInfo struct {
+	PayerClientIP string  `json:"payer_client_ip"`     // 用户终端 IP
+	DeviceId      string  `json:"device_id,omitempty"` // 商户端设备号
+	H5Info        *H5Info `json:"h5_info,omitempty"`   // H5 支付必须
+}
+
+// 下单的参数
+type TransactionRequest struct {
+	AppId       string     `json:"appid"`                 // 必须; 公众号, 小程序或者移动应用的 appid
+	MchId       string     `json:"mchid"`                 // 可以为空, 为空时使用 Client 的商户号
+	Description string     `json:"description"`           // 必须; 商品描述
+	OutTradeNo  string     `json:"out_trade_no"`          // 必须; 商户订单号
+	TimeExpire  string     `json:"time_expire,omitempty"` // 交易结束时间, rfc3339 格式, 可以用 FormatTime 生成
+	Attach      string     `json:"attach,omitempty"`      // 附加数据, 在查询和支付通知中原样返回
+	NotifyURL   string     `json:"notify_url"`            // 必须; 支付结果通知的地址, 必须为 https
+	GoodsTag    string     `json:"goods_tag,omitempty"`   // 订单优惠标记
+	Amount      Amount     `json:"amount"`                // 必须; 订单金额
+	Payer       *Payer     `json:"payer,omitempty"`       // JSAPI 支付必须
+	SceneInfo   *SceneInfo `json:"scene_info,omitempty"`  // H5 支付必须
+}
+
+// 把 t 格式化成 APIv3 使用的 rfc3339 格式, 比如 2018-06-08T10:34:56+08:00
+func FormatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func (clt *Client) prepay(tradeType string, req *TransactionRequest, response interface{}) (err error) {
+	if req == nil {
+		err = errors.New("nil TransactionRequest")
+		return
+	}
+	if req.OutTradeNo == "" {
+		err = errors.New("empty OutTradeNo")
+		return
+	}
+	if req.MchId == "" {
+		r := *req
+		r.MchId = clt.mchId
+		req = &r
+	}
+	return clt.Do("POST", "/v3/pay/transactions/"+tradeType, req, response)
+}
+
+// JSAPI 下单(公众号和小程序支付), 返回预支付交易会话标识 prepay_id, 可以用 JSAPIPayParams 生成调起支付的参数.
+//  req.Payer 必须.
+func (clt *Client) JSAPI(req *TransactionRequest) (prepayId string, err error) {
+	if req != nil && (req.Payer == nil || req.Payer.OpenId == "") {
+		err = errors.New("empty Payer.OpenId")
+		return
+	}
+	var result struct {
+		PrepayId string `json:"prepay_id"`
+	}
+	if err = clt.prepay("jsapi", req, &result); err != nil {
+		return
+	}
+	prepayId = result.PrepayId
+	return
+}
+
+// APP 下单, 返回预支付交易会话标识 prepay_id.
+func (clt *Client) App(req *TransactionRequest) (prepayId string, err error) {
+	var result struct {
+		PrepayId string `json:"prepay_id"`
+	}
+	if err = clt.prepay("app", req, &result); err != nil {
+		return
+	}
+	prepayId = result.PrepayId
+	return
+}
+
+// Native 下单(扫码支付), 返回二维码链接 code_url.
+func (clt *Client) Native(req *TransactionRequest) (codeURL string, err error) {
+	var result struct {
+		CodeURL string `json:"code_url"`
+	}
+	if err = clt.prepay("native", req, &result); err != nil {
+		return
+	}
+	codeURL = result.CodeURL
+	return
+}
+
+// H5 下单, 返回支付跳转链接 h5_url.
+//  req.SceneInfo 和 req.SceneInfo.H5Info 必须.
+func (clt *Client) H5(req *TransactionRequest) (h5URL string, err error) {
+	if req != nil && (req.SceneInfo == nil || req.SceneInfo.H5Info == nil) {
+		err = errors.New("nil SceneInfo.H5Info")
+		return
+	}
+	var result struct {
+		H5URL string `json:"h5_url"`
+	}
+	if err = clt.prepay("h5", req, &result); err != nil {
+		return
+	}
+	h5URL = result.H5URL
+	return
+}
+
+// 交易状态
+const (
+	TradeStateSuccess    = "SUCCESS"    // 支付成功
+	TradeStateRefund     = "REFUND"     // 转入退款
+	TradeStateNotPay     = "NOTPAY"     // 未支付
+	TradeStateClosed     = "CLOSED"     // 已关闭
+	TradeStateRevoked    = "REVOKED"    // 已撤销(付款码支付)
+	TradeStateUserPaying = "USERPAYING" // 用户支付中(付款码支付)
+	TradeStatePayError   = "PAYERROR"   // 支付失败
+)
+
+// 订单信息, 查询订单和支付成功通知返回的数据.
+type Transaction struct {
+	AppId          string `json:"appid"`
+	MchId          string `json:"mchid"`
+	OutTradeNo     string `json:"out_trade_no"`
+	TransactionId  string `json:"transaction_id"`   // 微信支付订单号
+	TradeType      string `json:"trade_type"`       // JSAPI, NATIVE, APP, MWEB, MICROPAY, FACEPAY
+	TradeState     string `json:"trade_state"`      // 交易状态, 参考 TradeStateXXX
+	TradeStateDesc string `json:"trade_state_desc"` // 交易状态描述
+	BankType       string `json:"bank_type"`        // 付款银行
+	Attach         string `json:"attach"`
+	SuccessTime    string `json:"success_time"` // 支付完成时间, rfc3339 格式
+	Payer          struct {
+		OpenId string `json:"openid"`
+	} `json:"payer"`
+	Amount struct {
+		Total         int64  `json:"total"`          // 订单总金额, 单位为分
+		PayerTotal    int64  `json:"payer_total"`    // 用户支付金额, 单位为分
+		Currency      string `json:"currency"`       // 货币类型
+		PayerCurrency string `json:"payer_currency"` // 用户支付币种
+	} `json:"amount"`
+}
+
+// 用商户订单号查询订单.
+func (clt *Client) QueryOrder(outTradeNo string) (transaction *Transaction, err error) {
+	if outTradeNo == "" {
+		err = errors.New("empty outTradeNo")
+		return
+	}
+	var result Transaction
+	path := "/v3/pay/transactions/out-trade-no/" + url.PathEscape(outTradeNo) + "?mchid=" + url.QueryEscape(clt.mchId)
+	if err = clt.Do("GET", path, nil, &result); err != nil {
+		return
+	}
+	transaction = &result
+	return
+}
+
+// 用微信支付订单号查询订单.
+func (clt *Client) QueryOrderByTransactionId(transactionId string) (transaction *Transaction, err error) {
+	if transactionId == "" {
+		err = errors.New("empty transactionId")
+		return
+	}
+	var result Transaction
+	path := "/v3/pay/transactions/id/" + url.PathEscape(transactionId) + "?mchid=" + url.QueryEscape(clt.mchId)
+	if err = clt.Do("GET", path, nil, &result); err != nil {
+		return
+	}
+	transaction = &result
+	return
+}
+
+// 关闭订单.
+//  NOTE: 订单生成后不能马上调用关单接口, 最短调用时间间隔为 5 分钟.
+func (clt *Client) CloseOrder(outTradeNo string) (err error) {
+	if outTradeNo == "" {
+		err = errors.New("empty outTradeNo")
+		return
+	}
+	var request = struct {
+		MchId string `json:"mchid"`
+	}{
+		MchId: clt.mchId,
+	}
+	return clt.Do("POST", "/v3/pay/transactions/out-trade-no/"+url.PathEscape(outTradeNo)+"/close", &request, nil)
+}
+
+// JSAPI 调起支付的参数, 可以直接 json 编码后给 WeixinJSBridge.invoke('getBrandWCPayRequest', ...) 或者 wx.requestPayment 使用.
+type JSAPIPayParams struct {
+	AppId     string `json:"appId"`
+	TimeStamp string `json:"timeStamp"`
+	NonceStr  string `json:"nonceStr"`
+	Package   string `json:"package"`
+	SignType  string `json:"signType"`
+	PaySign   string `json:"paySign"`
+}
+
+// 生成 JSAPI 调起支付的参数.
+//  appId:    下单时的 appid
+//  prepayId: JSAPI 下单返回的 prepay_id
+func (clt *Client) JSAPIPayParams(appId, prepayId string) (params *JSAPIPayParams, err error) {
+	if prepayId == "" {
+		err = errors.New("empty prepayId")
+		return
+	}
+	p := JSAPIPayParams{
+		AppId:     appId,
+		TimeStamp: strconv.FormatInt(time.Now().Unix(), 10),
+		NonceStr:  NewNonceStr(),
+		Package:   "prepay_id=" + prepayId,
+		SignType:  "RSA",
+	}
+	if p.PaySign, err = clt.Sign(p.AppId + "\n" + p.TimeStamp + "\n" + p.NonceStr + "\n" + p.Package + "\n"); err != nil {
+		return
+	}
+	params = &p
+	return
+}
@@ -0,0 +1,68 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mch
+
+import (
+	"github.com/chanxuehong/util/security"
+)
+
+// RequestSigner 用 API密钥 对微信支付的请求参数签名, 或者校验微信支付回调的签名.
+//  签名的规则: 参数按照 key 的字典序排列, 去掉 sign 和值为空的参数, 拼接成
+//  key1=val1&key2=val2&...&key=apiKey 之后做 MD5 或者 HMAC-SHA256, 结果转换为大写的十六进制.
+type RequestSigner struct {
+	apiKey   string
+	signType string
+}
+
+// 创建一个新的 RequestSigner.
+//  signType: SignTypeMD5 或者 SignTypeHMACSHA256, 为空时默认为 SignTypeMD5
+func NewRequestSigner(apiKey, signType string) *RequestSigner {
+	if apiKey == "" {
+		panic("empty apiKey")
+	}
+	switch signType {
+	case "":
+		signType = SignTypeMD5
+	case SignTypeMD5, SignTypeHMACSHA256:
+	default:
+		panic("unsupported signType: " + signType)
+	}
+	return &RequestSigner{
+		apiKey:   apiKey,
+		signType: signType,
+	}
+}
+
+func (signer *RequestSigner) SignType() string {
+	return signer.signType
+}
+
+// 计算 params 的签名.
+func (signer *RequestSigner) Sign(params map[string]string) string {
+	if signer.signType == SignTypeHMACSHA256 {
+		return HMACSHA256Sign(params, signer.apiKey)
+	}
+	return Sign(params, signer.apiKey, nil)
+}
+
+// 返回 params 的一个拷贝, 并且加上 sign 参数, 不修改 params.
+//  HMAC-SHA256 签名时如果 params 里没有 sign_type, 会先加上 sign_type 再签名.
+func (signer *RequestSigner) SignedParams(params map[string]string) map[string]string {
+	ret := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		ret[k] = v
+	}
+	if signer.signType == SignTypeHMACSHA256 && ret["sign_type"] == "" {
+		ret["sign_type"] = SignTypeHMACSHA256
+	}
+	ret["sign"] = signer.Sign(ret)
+	return ret
+}
+
+// 校验 params 的签名是否为 signature, 一般用于校验微信支付回调的签名.
+func (signer *RequestSigner) Verify(params map[string]string, signature string) bool {
+	return security.SecureCompareString(signer.Sign(params), signature)
+}
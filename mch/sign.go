@@ -7,8 +7,10 @@ package mch
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"hash"
 	"sort"
@@ -57,6 +59,13 @@ func Sign(parameters map[string]string, apiKey string, fn func() hash.Hash) stri
 	return string(bytes.ToUpper(signature))
 }
 
+// 微信支付 HMAC-SHA256 签名, 请求参数里的 sign_type 要设置为 SignTypeHMACSHA256.
+func HMACSHA256Sign(parameters map[string]string, apiKey string) string {
+	return Sign(parameters, apiKey, func() hash.Hash {
+		return hmac.New(sha256.New, []byte(apiKey))
+	})
+}
+
 // 收货地址共享接口签名
 func EditAddressSign(appId, url, timestamp, nonceStr, accessToken string) string {
 	h := sha1.New()
@@ -75,3 +75,41 @@ func Sign2(parameters map[string]string, apiKey string, fn func() hash.Hash) str
 	hex.Encode(signature, h.Sum(nil))
 	return string(bytes.ToUpper(signature))
 }
+
+func TestRequestSigner(t *testing.T) {
+	params := map[string]string{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      "10000100",
+		"device_info": "1000",
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+		"empty":       "",
+	}
+	apiKey := "192006250b4c09247ec02edce69f6a2d"
+
+	signer := NewRequestSigner(apiKey, SignTypeMD5)
+	want := "9A0A8659F005D6984697E2CA0A9CF3B7"
+	if have := signer.Sign(params); have != want {
+		t.Errorf("Sign:\nhave: %s\nwant: %s", have, want)
+	}
+
+	signed := signer.SignedParams(params)
+	if signed["sign"] != want {
+		t.Errorf("SignedParams:\nhave: %s\nwant: %s", signed["sign"], want)
+	}
+	if _, ok := params["sign"]; ok {
+		t.Error("SignedParams modified the input params")
+	}
+	if !signer.Verify(signed, want) {
+		t.Error("Verify failed for a valid signature")
+	}
+	if signer.Verify(signed, "9A0A8659F005D6984697E2CA0A9CF3B8") {
+		t.Error("Verify succeeded for an invalid signature")
+	}
+
+	signer = NewRequestSigner(apiKey, SignTypeHMACSHA256)
+	want = "6A9AE1657590FD6257D693A078E1C3E4BB6BA4DC30B23E0EE2496E54170DACD6"
+	if have := signer.Sign(params); have != want {
+		t.Errorf("HMAC-SHA256 Sign:\nhave: %s\nwant: %s", have, want)
+	}
+}
@@ -0,0 +1,101 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	waitForTokenMinInterval = 100 * time.Millisecond
+	waitForTokenMaxInterval = 5 * time.Second
+)
+
+// 阻塞直到从 srv 获取到有效的 access_token, 或者 ctx 被取消.
+//  一般在进程启动的时候调用, 保证后续的 api 调用都有 access_token 可用.
+//
+//  NOTE:
+//  1. 获取失败后会重试, 重试间隔从 100ms 开始翻倍, 最大 5s;
+//  2. 正常情况下第一次获取 access_token 需要一个 http 周期, 一般在 100ms~1s 之间,
+//     微信服务器繁忙或者网络不好时可能需要数秒, 所以 ctx 的超时时间建议不要小于 5s.
+func WaitForToken(ctx context.Context, srv AccessTokenServer) (token string, err error) {
+	if srv == nil {
+		err = errors.New("nil AccessTokenServer")
+		return
+	}
+
+	interval := waitForTokenMinInterval
+	for {
+		if token, err = srv.Token(); err == nil && token != "" {
+			return
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = ctx.Err()
+			}
+			return "", err
+		case <-timer.C:
+		}
+
+		if interval *= 2; interval > waitForTokenMaxInterval {
+			interval = waitForTokenMaxInterval
+		}
+	}
+}
+
+var _ Interceptor = (*AccessTokenInterceptor)(nil)
+
+// AccessTokenInterceptor 在 access_token 可用之前拒绝处理回调请求, 返回 503 并带上 Retry-After 头部.
+//  用于 handler 里面需要调用 api 的场景, 避免进程刚启动时因为还没有 access_token 而处理失败;
+//  微信服务器收不到正确的回复会重试推送这个消息.
+//
+//  frontend := NewServerFrontend(server, nil, NewAccessTokenInterceptor(tokenServer, time.Second))
+type AccessTokenInterceptor struct {
+	srv     AccessTokenServer
+	timeout time.Duration
+}
+
+// 创建一个新的 AccessTokenInterceptor.
+//  timeout: 每个请求最多等待 access_token 的时间.
+func NewAccessTokenInterceptor(srv AccessTokenServer, timeout time.Duration) *AccessTokenInterceptor {
+	if srv == nil {
+		panic("nil AccessTokenServer")
+	}
+	return &AccessTokenInterceptor{
+		srv:     srv,
+		timeout: timeout,
+	}
+}
+
+// 只等待推送消息(事件)的 POST 请求; 验证 URL 有效性的 GET 请求(带有 echostr)不需要 access_token, 直接放行,
+// 否则 appsecret 配置错误的时候 URL 验证永远无法通过.
+//  客户端断开连接或者服务器关闭(r.Context() 被取消)时停止等待.
+func (interceptor *AccessTokenInterceptor) Intercept(w http.ResponseWriter, r *http.Request, queryValues url.Values) (shouldContinue bool) {
+	if r.Method != "POST" {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), interceptor.timeout)
+	defer cancel()
+
+	if _, err := WaitForToken(ctx, interceptor.srv); err != nil {
+		LogInfoln("[WECHAT_WAIT_TOKEN]", err)
+		retryAfter := int(waitForTokenMaxInterval / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
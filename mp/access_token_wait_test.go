@@ -0,0 +1,46 @@
+package mp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testFailingAccessTokenServer struct{}
+
+func (testFailingAccessTokenServer) Token() (string, error) {
+	return "", errors.New("invalid appsecret")
+}
+func (testFailingAccessTokenServer) TokenRefresh() (string, error) {
+	return "", errors.New("invalid appsecret")
+}
+func (testFailingAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+func TestAccessTokenInterceptor(t *testing.T) {
+	interceptor := NewAccessTokenInterceptor(testFailingAccessTokenServer{}, time.Minute)
+
+	// 验证 URL 的请求不需要 access_token
+	r := httptest.NewRequest("GET", "/?signature=x&timestamp=1&nonce=n&echostr=e", nil)
+	if !interceptor.Intercept(httptest.NewRecorder(), r, r.URL.Query()) {
+		t.Error("GET echostr request should not be intercepted")
+	}
+
+	// 请求被取消的时候不再等待 timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r = httptest.NewRequest("POST", "/?signature=x&timestamp=1&nonce=n", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	if interceptor.Intercept(w, r, r.URL.Query()) {
+		t.Error("POST request without access_token should be intercepted")
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Intercept waited %v after the request was canceled", d)
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status code, have: %d, want: %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
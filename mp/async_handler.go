@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// 异步处理消息(事件)的任务队列.
+type JobQueue interface {
+	// 把 r 放到队列里, r 已经是 Request.Clone 的拷贝, 可以在 MessageHandler 返回后继续使用.
+	Enqueue(r *Request) error
+}
+
+// 返回一个异步处理消息(事件)的 MessageHandler.
+//  微信服务器要求 5 秒内回复, 对于处理比较慢的消息(比如图片识别), 先把消息的拷贝放到 queue 里,
+//  然后立即回复 immediateReply(r) 返回的消息(比如 "正在处理, 请稍候"), 处理完成后再通过客服消息接口发送结果.
+//
+//  immediateReply: 返回立即回复的消息, 可以为 nil, 为 nil 或者返回 nil 时回复 "success"
+//
+//  NOTE: 放入 queue 失败时回复 503, 不回复 immediateReply, 微信服务器会重试推送这个消息.
+func NewAsyncHandler(queue JobQueue, immediateReply func(r *Request) interface{}) MessageHandler {
+	if queue == nil {
+		panic("nil JobQueue")
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		if err := queue.Enqueue(r.Clone()); err != nil {
+			LogInfoln("[WECHAT_ASYNC_HANDLER]", err)
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+
+		var msg interface{}
+		if immediateReply != nil {
+			msg = immediateReply(r)
+		}
+		if msg == nil {
+			io.WriteString(w, "success")
+			return
+		}
+
+		var err error
+		if r.EncryptType == "aes" {
+			err = WriteAESResponse(w, r, msg)
+		} else {
+			err = WriteRawResponse(w, r, msg)
+		}
+		if err != nil {
+			LogInfoln("[WECHAT_ASYNC_HANDLER]", err)
+		}
+	})
+}
+
+var ErrJobQueueFull = errors.New("job queue is full")
+
+var _ JobQueue = (*ChannelJobQueue)(nil)
+
+// 基于 channel 的 JobQueue, 只在当前进程内有效.
+type ChannelJobQueue struct {
+	ch chan *Request
+}
+
+// 创建一个新的 ChannelJobQueue, bufSize 为队列的容量.
+func NewChannelJobQueue(bufSize int) *ChannelJobQueue {
+	if bufSize <= 0 {
+		panic("bufSize must be positive")
+	}
+	return &ChannelJobQueue{
+		ch: make(chan *Request, bufSize),
+	}
+}
+
+// 放入队列, 队列满了不会阻塞, 直接返回 ErrJobQueueFull.
+func (queue *ChannelJobQueue) Enqueue(r *Request) error {
+	select {
+	case queue.ch <- r:
+		return nil
+	default:
+		return ErrJobQueueFull
+	}
+}
+
+// 从队列里取出消息交给 handler 处理, 直到 ctx 被取消.
+//  可以在多个 goroutine 里调用 Consume 来并发处理.
+func (queue *ChannelJobQueue) Consume(ctx context.Context, handler func(r *Request)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case r := <-queue.ch:
+			handler(r)
+		}
+	}
+}
@@ -23,3 +23,28 @@ func Sign(strs []string) (signature string) {
 	}
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// 添加卡券(wx.addCard)时每张卡券的 cardExt 参数, 序列化成 JSON 字符串后使用.
+type CardExt struct {
+	Code      string `json:"code,omitempty"`   // 指定的卡券 code 码, 只能被领一次, 自定义 code 模式的卡券必须填写
+	OpenId    string `json:"openid,omitempty"` // 指定领取者的 openid, 只有该用户能领取
+	Timestamp string `json:"timestamp"`        // 时间戳, 商户生成从1970年1月1日00:00:00至今的秒数
+	NonceStr  string `json:"nonce_str"`        // 随机字符串, 由开发者设置传入
+	Signature string `json:"signature"`        // 签名, 用 CardExtSign 计算
+}
+
+// 添加卡券(wx.addCard)时 cardExt 的签名.
+//  apiTicket 是卡券的 api_ticket(参考 jssdk.WxCardTicketServer), 不是 jsapi_ticket;
+//  code 和 openId 没有指定时传空字符串.
+//  签名算法: 将 api_ticket, timestamp, card_id, code, openid, nonce_str 的 value 值字典排序后拼接, 然后做 sha1.
+func CardExtSign(apiTicket, timestamp, nonceStr, cardId, code, openId string) (signature string) {
+	return Sign([]string{apiTicket, timestamp, nonceStr, cardId, code, openId})
+}
+
+// 拉取适用卡券列表(wx.chooseCard)时的 cardSign 参数.
+//  apiTicket 是卡券的 api_ticket(参考 jssdk.WxCardTicketServer), 不是 jsapi_ticket;
+//  locationId(shopId), cardId, cardType 没有指定时传空字符串.
+//  签名算法: 将 api_ticket, app_id, location_id, timestamp, nonce_str, card_id, card_type 的 value 值字典排序后拼接, 然后做 sha1.
+func ChooseCardSign(apiTicket, appId, locationId, timestamp, nonceStr, cardId, cardType string) (signature string) {
+	return Sign([]string{apiTicket, appId, locationId, timestamp, nonceStr, cardId, cardType})
+}
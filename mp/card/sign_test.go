@@ -0,0 +1,70 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"testing"
+)
+
+// 期望的签名是把参数值排序拼接后用 sha1sum 独立计算出来的
+func TestCardExtSign(t *testing.T) {
+	const (
+		apiTicket = "ojZ8YtyVyr30HheH3CM73y7h4jJE"
+		timestamp = "1404896688"
+		nonceStr  = "Wm3WZYTPz0wzccnW"
+		cardId    = "pjZ8Yt1XGILfi-FUsewpnnolGgZk"
+	)
+
+	tests := []struct {
+		code      string
+		openId    string
+		signature string
+	}{
+		{"", "", "7f975f23cc5be2793e4426eb2900df9545eb1fbb"},
+		{"1434008071", "oLVPpjqs9BhvzwPj5A-vTYAX3GLc", "a7e2450d39ff56bd1f9e1e08e99dcfe55de0de18"},
+	}
+
+	for _, test := range tests {
+		signature := CardExtSign(apiTicket, timestamp, nonceStr, cardId, test.code, test.openId)
+		if signature != test.signature {
+			t.Errorf("CardExtSign(code=%q, openid=%q) 签名错误, have: %s, want: %s",
+				test.code, test.openId, signature, test.signature)
+		}
+
+		// 参数的先后顺序不影响签名结果
+		signature = CardExtSign(test.openId, cardId, test.code, apiTicket, nonceStr, timestamp)
+		if signature != test.signature {
+			t.Errorf("CardExtSign 签名和参数顺序有关, have: %s, want: %s", signature, test.signature)
+		}
+	}
+}
+
+func TestChooseCardSign(t *testing.T) {
+	const (
+		apiTicket = "ojZ8YtyVyr30HheH3CM73y7h4jJE"
+		appId     = "wx7b8ba3a3a8c1fbb6"
+		timestamp = "1404896688"
+		nonceStr  = "Wm3WZYTPz0wzccnW"
+	)
+
+	tests := []struct {
+		locationId string
+		cardId     string
+		cardType   string
+		signature  string
+	}{
+		{"", "", "", "c6adebbe67d3010bef968dacc345e1acc5897fda"},
+		{"123", "pjZ8Yt1XGILfi-FUsewpnnolGgZk", "GROUPON", "5e29ba54cef4c6741864482a19caf010f8143e6d"},
+	}
+
+	for _, test := range tests {
+		signature := ChooseCardSign(apiTicket, appId, test.locationId, timestamp, nonceStr, test.cardId, test.cardType)
+		if signature != test.signature {
+			t.Errorf("ChooseCardSign(location_id=%q, card_id=%q, card_type=%q) 签名错误, have: %s, want: %s",
+				test.locationId, test.cardId, test.cardType, signature, test.signature)
+		}
+	}
+}
@@ -5,10 +5,17 @@
 
 package component
 
+import (
+	"errors"
+	"fmt"
+)
+
 const (
 	// 微信服务器推送过来的消息类型
-	MsgTypeVerifyTicket = "component_verify_ticket" // 推送 component_verify_ticket 协议
-	MsgTypeUnauthorized = "unauthorized"            // 取消授权的通知
+	MsgTypeVerifyTicket     = "component_verify_ticket" // 推送 component_verify_ticket 协议
+	MsgTypeUnauthorized     = "unauthorized"            // 取消授权的通知
+	MsgTypeAuthorized       = "authorized"              // 授权成功的通知
+	MsgTypeUpdateAuthorized = "updateauthorized"        // 授权更新的通知
 )
 
 type VerifyTicketMessage struct {
@@ -48,3 +55,64 @@ func GetUnauthorizedMessage(msg *MixedMessage) *UnauthorizedMessage {
 		AuthorizerAppId: msg.AuthorizerAppId,
 	}
 }
+
+// 授权成功和授权更新的通知
+type AuthorizedMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	AppId      string `xml:"AppId"      json:"AppId"`
+	CreateTime int64  `xml:"CreateTime" json:"CreateTime"`
+	InfoType   string `xml:"InfoType"   json:"InfoType"`
+
+	AuthorizerAppId              string `xml:"AuthorizerAppid"              json:"AuthorizerAppid"`
+	AuthorizationCode            string `xml:"AuthorizationCode"            json:"AuthorizationCode"`            // 授权码, 可用于 QueryAuth 获取授权信息
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime" json:"AuthorizationCodeExpiredTime"` // 授权码过期时间
+	PreAuthCode                  string `xml:"PreAuthCode"                  json:"PreAuthCode"`                  // 预授权码
+}
+
+func GetAuthorizedMessage(msg *MixedMessage) *AuthorizedMessage {
+	return &AuthorizedMessage{
+		AppId:                        msg.AppId,
+		CreateTime:                   msg.CreateTime,
+		InfoType:                     msg.InfoType,
+		AuthorizerAppId:              msg.AuthorizerAppId,
+		AuthorizationCode:            msg.AuthorizationCode,
+		AuthorizationCodeExpiredTime: msg.AuthorizationCodeExpiredTime,
+		PreAuthCode:                  msg.PreAuthCode,
+	}
+}
+
+// 第三方平台的通知, 根据 InfoType 只有一个字段不为 nil:
+//  MsgTypeVerifyTicket:                        VerifyTicket
+//  MsgTypeUnauthorized:                        Unauthorized
+//  MsgTypeAuthorized, MsgTypeUpdateAuthorized: Authorized
+type ComponentEvent struct {
+	InfoType string
+
+	VerifyTicket *VerifyTicketMessage
+	Unauthorized *UnauthorizedMessage
+	Authorized   *AuthorizedMessage
+}
+
+// 根据 InfoType 把 r.MixedMsg 转换成对应类型的通知, 不支持的 InfoType 返回错误.
+func ParseComponentEvent(r *Request) (event *ComponentEvent, err error) {
+	if r == nil || r.MixedMsg == nil {
+		err = errors.New("nil MixedMsg")
+		return
+	}
+	msg := r.MixedMsg
+
+	event = &ComponentEvent{InfoType: msg.InfoType}
+	switch msg.InfoType {
+	case MsgTypeVerifyTicket:
+		event.VerifyTicket = GetVerifyTicketMessage(msg)
+	case MsgTypeUnauthorized:
+		event.Unauthorized = GetUnauthorizedMessage(msg)
+	case MsgTypeAuthorized, MsgTypeUpdateAuthorized:
+		event.Authorized = GetAuthorizedMessage(msg)
+	default:
+		event = nil
+		err = fmt.Errorf("unknown InfoType: %s", msg.InfoType)
+	}
+	return
+}
@@ -0,0 +1,180 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// component_verify_ticket 的持久化接口
+type VerifyTicketPersister interface {
+	// 保存 appId 的 ticket, 返回之前必须已经写到持久化存储里.
+	SaveComponentVerifyTicket(appId, ticket string) error
+
+	// 加载 appId 的 ticket, 如果没有找到返回 ErrNotFound.
+	LoadComponentVerifyTicket(appId string) (ticket string, err error)
+}
+
+var _ VerifyTicketGetter = (*VerifyTicketStore)(nil)
+
+// VerifyTicketStore 在内存里缓存 component_verify_ticket, 同时保存到 VerifyTicketPersister.
+//
+//  微信服务器每 10 分钟推送一次 component_verify_ticket, 如果只保存在内存里, 进程重启以后要等待下一次推送
+//  才能获取 component_access_token. VerifyTicketStore 每次收到推送都同步写入 persister, 重启以后从 persister 加载.
+//
+//  收到 MsgTypeVerifyTicket 推送的时候调用 SetComponentVerifyTicket:
+//  mux.MessageHandleFunc(component.MsgTypeVerifyTicket, func(w http.ResponseWriter, r *component.Request) {
+//      if err := store.SetComponentVerifyTicket(r.MixedMsg.AppId, r.MixedMsg.VerifyTicket); err != nil {
+//          ... // 返回错误, 让微信服务器重新推送
+//      }
+//      io.WriteString(w, "success")
+//  })
+type VerifyTicketStore struct {
+	persister VerifyTicketPersister
+
+	rwmutex sync.RWMutex
+	m       map[string]string // map[appId]ticket
+}
+
+// 创建一个新的 VerifyTicketStore, persister 为 nil 时只保存在内存里.
+func NewVerifyTicketStore(persister VerifyTicketPersister) *VerifyTicketStore {
+	return &VerifyTicketStore{
+		persister: persister,
+		m:         make(map[string]string),
+	}
+}
+
+func (store *VerifyTicketStore) Tag9AEACC95FE9911E4B5A4A4DB30FED8E1() {}
+
+// 保存推送的 ticket, 先写入 persister, 成功以后再更新内存里的缓存.
+func (store *VerifyTicketStore) SetComponentVerifyTicket(appId string, ticket string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+	if ticket == "" {
+		return errors.New("empty ticket")
+	}
+
+	store.rwmutex.Lock()
+	defer store.rwmutex.Unlock()
+
+	if store.persister != nil {
+		if err = store.persister.SaveComponentVerifyTicket(appId, ticket); err != nil {
+			return
+		}
+	}
+	store.m[appId] = ticket
+	return
+}
+
+// 获取 appId 当前的 ticket, 内存里没有的时候从 persister 加载.
+func (store *VerifyTicketStore) GetComponentVerifyTicket(appId string) (ticket string, err error) {
+	store.rwmutex.RLock()
+	ticket = store.m[appId]
+	store.rwmutex.RUnlock()
+	if ticket != "" {
+		return
+	}
+	if store.persister == nil {
+		err = ErrNotFound
+		return
+	}
+
+	store.rwmutex.Lock()
+	defer store.rwmutex.Unlock()
+
+	if ticket = store.m[appId]; ticket != "" {
+		return
+	}
+	if ticket, err = store.persister.LoadComponentVerifyTicket(appId); err != nil {
+		ticket = ""
+		return
+	}
+	if ticket == "" {
+		err = ErrNotFound
+		return
+	}
+	store.m[appId] = ticket
+	return
+}
+
+var _ VerifyTicketPersister = (*FileVerifyTicketPersister)(nil)
+
+// 把 ticket 保存到目录 dir 下的文件里, 每个 appId 一个文件.
+type FileVerifyTicketPersister struct {
+	dir string
+}
+
+// 创建一个新的 FileVerifyTicketPersister, dir 必须已经存在.
+func NewFileVerifyTicketPersister(dir string) *FileVerifyTicketPersister {
+	if dir == "" {
+		panic("empty dir")
+	}
+	return &FileVerifyTicketPersister{
+		dir: dir,
+	}
+}
+
+func (persister *FileVerifyTicketPersister) filename(appId string) string {
+	return filepath.Join(persister.dir, "component_verify_ticket_"+appId)
+}
+
+// 先写到临时文件再重命名, 保证写入失败的时候不会破坏之前保存的 ticket.
+func (persister *FileVerifyTicketPersister) SaveComponentVerifyTicket(appId, ticket string) (err error) {
+	if appId == "" || strings.ContainsAny(appId, `/\`) {
+		return errors.New("invalid appId")
+	}
+
+	file, err := ioutil.TempFile(persister.dir, "component_verify_ticket_")
+	if err != nil {
+		return
+	}
+	tmpname := file.Name()
+	defer func() {
+		if err != nil {
+			os.Remove(tmpname)
+		}
+	}()
+
+	if _, err = file.WriteString(ticket); err != nil {
+		file.Close()
+		return
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	return os.Rename(tmpname, persister.filename(appId))
+}
+
+func (persister *FileVerifyTicketPersister) LoadComponentVerifyTicket(appId string) (ticket string, err error) {
+	if appId == "" || strings.ContainsAny(appId, `/\`) {
+		err = errors.New("invalid appId")
+		return
+	}
+
+	data, err := ioutil.ReadFile(persister.filename(appId))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrNotFound
+		}
+		return
+	}
+	if len(data) == 0 {
+		err = ErrNotFound
+		return
+	}
+	ticket = string(data)
+	return
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestVerifyTicketStoreRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify_ticket_store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const appId = "wx1234567890abcdef"
+
+	store := NewVerifyTicketStore(NewFileVerifyTicketPersister(dir))
+	if _, err := store.GetComponentVerifyTicket(appId); err != ErrNotFound {
+		t.Fatalf("GetComponentVerifyTicket before push, have err: %v, want: %v", err, ErrNotFound)
+	}
+	if err := store.SetComponentVerifyTicket(appId, "ticket@@@1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetComponentVerifyTicket(appId, "ticket@@@2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟进程重启, 新的 VerifyTicketStore 只能从文件里加载
+	store = NewVerifyTicketStore(NewFileVerifyTicketPersister(dir))
+	ticket, err := store.GetComponentVerifyTicket(appId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket != "ticket@@@2" {
+		t.Errorf("GetComponentVerifyTicket after restart, have: %q, want: %q", ticket, "ticket@@@2")
+	}
+
+	if _, err := store.GetComponentVerifyTicket("wx_other"); err != ErrNotFound {
+		t.Errorf("GetComponentVerifyTicket for unknown appId, have err: %v, want: %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var _ MessageHandler = (*ConcurrencyLimiter)(nil)
+
+// ConcurrencyLimiter 限制后端 MessageHandler 同时处理的消息(事件)个数,
+// 用于保护比较慢的 handler(比如需要查询数据库, 调用其他服务), 防止资源耗尽.
+//  超过限制时直接返回 503, 微信服务器会重试推送这个消息.
+//
+//  MessageServeMux.MessageHandle(request.MsgTypeText, NewConcurrencyLimiter(slowHandler, 10, 0))
+type ConcurrencyLimiter struct {
+	handler MessageHandler
+	timeout time.Duration
+	sem     chan struct{}
+
+	inflight int64 // 正在处理的消息个数, 原子操作
+}
+
+// 创建一个新的 ConcurrencyLimiter.
+//  handler: 后端真正处理消息的 MessageHandler;
+//  n:       同时处理的消息的最大个数;
+//  timeout: 超过限制时最多等待多长时间, 0 表示不等待, 直接返回 503.
+func NewConcurrencyLimiter(handler MessageHandler, n int, timeout time.Duration) *ConcurrencyLimiter {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if n <= 0 {
+		panic("n must be positive")
+	}
+	return &ConcurrencyLimiter{
+		handler: handler,
+		timeout: timeout,
+		sem:     make(chan struct{}, n),
+	}
+}
+
+// ConcurrencyLimiter 实现了 MessageHandler 接口.
+func (limiter *ConcurrencyLimiter) ServeMessage(w http.ResponseWriter, r *Request) {
+	if !limiter.acquire() {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	defer limiter.release()
+
+	limiter.handler.ServeMessage(w, r)
+}
+
+// 返回当前正在处理的消息个数.
+func (limiter *ConcurrencyLimiter) Gauge() int {
+	return int(atomic.LoadInt64(&limiter.inflight))
+}
+
+func (limiter *ConcurrencyLimiter) acquire() bool {
+	select {
+	case limiter.sem <- struct{}{}:
+		atomic.AddInt64(&limiter.inflight, 1)
+		return true
+	default:
+	}
+
+	if limiter.timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(limiter.timeout)
+	defer timer.Stop()
+
+	select {
+	case limiter.sem <- struct{}{}:
+		atomic.AddInt64(&limiter.inflight, 1)
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (limiter *ConcurrencyLimiter) release() {
+	atomic.AddInt64(&limiter.inflight, -1)
+	<-limiter.sem
+}
@@ -0,0 +1,186 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// 会话上下文的存储接口, 按照用户的 openid 和 key 存取数据.
+//  时间都是 unixtime, 单位为秒.
+type ContextStore interface {
+	// 保存数据, expiresAt 之后数据失效.
+	Set(openId, key string, value interface{}, expiresAt int64) error
+
+	// 获取在 now 时刻还有效的数据, 没有找到或者已经失效时 ok 为 false.
+	Get(openId, key string, now int64) (value interface{}, ok bool, err error)
+}
+
+// ConversationContext 保存聊天机器人等场景下同一个用户多条消息之间的上下文,
+// 比如上一次问了用户什么问题.
+//
+//  一般在程序初始化的时候创建, 然后在 MessageHandler 里通过 ForRequest 获取消息对应的上下文:
+//   conversation := mp.NewConversationContext(mp.NewMemoryContextStore(time.Minute))
+//   ...
+//   cc := conversation.ForRequest(r)
+//   last, ok, err := cc.Get(r.MixedMsg.FromUserName, "last_question")
+type ConversationContext struct {
+	store ContextStore
+	now   int64 // 不为 0 时作为当前时间, 一般为消息的 CreateTime
+}
+
+func NewConversationContext(store ContextStore) *ConversationContext {
+	if store == nil {
+		panic("nil ContextStore")
+	}
+	return &ConversationContext{
+		store: store,
+	}
+}
+
+// 返回以消息的 CreateTime 作为当前时间的 ConversationContext.
+//  这样微信重试推送同一条消息的时候, 计算得到的过期时间和第一次是一样的,
+//  也不受服务器本地时钟的影响.
+func (cc *ConversationContext) ForRequest(r *Request) *ConversationContext {
+	if r == nil || r.MixedMsg == nil || r.MixedMsg.CreateTime <= 0 {
+		return cc
+	}
+	return &ConversationContext{
+		store: cc.store,
+		now:   r.MixedMsg.CreateTime,
+	}
+}
+
+func (cc *ConversationContext) currentTime() int64 {
+	if cc.now != 0 {
+		return cc.now
+	}
+	return time.Now().Unix()
+}
+
+// 保存用户 openId 的上下文数据 key, ttl 之后失效.
+func (cc *ConversationContext) Set(openId, key string, value interface{}, ttl time.Duration) (err error) {
+	if openId == "" {
+		return errors.New("empty openId")
+	}
+	if key == "" {
+		return errors.New("empty key")
+	}
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+	return cc.store.Set(openId, key, value, cc.currentTime()+int64(ttl/time.Second))
+}
+
+// 获取用户 openId 的上下文数据 key, 没有找到或者已经失效时 ok 为 false.
+func (cc *ConversationContext) Get(openId, key string) (value interface{}, ok bool, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+	if key == "" {
+		err = errors.New("empty key")
+		return
+	}
+	return cc.store.Get(openId, key, cc.currentTime())
+}
+
+var _ ContextStore = (*MemoryContextStore)(nil)
+
+// 基于内存的 ContextStore 实现.
+//  失效的数据在 Get 的时候检查, 同时后台 goroutine 定期调用 Prune 清除.
+type MemoryContextStore struct {
+	rwmutex sync.RWMutex
+	users   map[string]map[string]contextEntry // map[openId]map[key]contextEntry
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+type contextEntry struct {
+	value     interface{}
+	expiresAt int64
+}
+
+// 创建一个新的 MemoryContextStore.
+//  pruneInterval: 后台清除失效数据的时间间隔, <= 0 时不启动后台 goroutine
+func NewMemoryContextStore(pruneInterval time.Duration) *MemoryContextStore {
+	store := &MemoryContextStore{
+		users:  make(map[string]map[string]contextEntry),
+		closed: make(chan struct{}),
+	}
+	if pruneInterval > 0 {
+		go store.pruneLoop(pruneInterval)
+	}
+	return store
+}
+
+func (store *MemoryContextStore) pruneLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			store.Prune()
+		case <-store.closed:
+			return
+		}
+	}
+}
+
+// 停止后台清除失效数据的 goroutine.
+func (store *MemoryContextStore) Close() {
+	store.closeOnce.Do(func() { close(store.closed) })
+}
+
+func (store *MemoryContextStore) Set(openId, key string, value interface{}, expiresAt int64) (err error) {
+	store.rwmutex.Lock()
+	entries := store.users[openId]
+	if entries == nil {
+		entries = make(map[string]contextEntry)
+		store.users[openId] = entries
+	}
+	entries[key] = contextEntry{
+		value:     value,
+		expiresAt: expiresAt,
+	}
+	store.rwmutex.Unlock()
+	return
+}
+
+func (store *MemoryContextStore) Get(openId, key string, now int64) (value interface{}, ok bool, err error) {
+	store.rwmutex.RLock()
+	entry, ok := store.users[openId][key]
+	store.rwmutex.RUnlock()
+
+	if !ok || entry.expiresAt <= now {
+		ok = false
+		return
+	}
+	value = entry.value
+	return
+}
+
+// 清除所有已经失效的数据.
+func (store *MemoryContextStore) Prune() {
+	now := time.Now().Unix()
+
+	store.rwmutex.Lock()
+	for openId, entries := range store.users {
+		for key, entry := range entries {
+			if entry.expiresAt <= now {
+				delete(entries, key)
+			}
+		}
+		if len(entries) == 0 {
+			delete(store.users, openId)
+		}
+	}
+	store.rwmutex.Unlock()
+}
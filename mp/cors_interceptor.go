@@ -0,0 +1,70 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"net/url"
+)
+
+const (
+	corsAllowMethods = "GET, POST, OPTIONS"
+	corsAllowHeaders = "Content-Type"
+)
+
+var _ Interceptor = (*CORSInterceptor)(nil)
+
+// CORSInterceptor 给回调 URL 的响应加上 CORS 头部, 用于微信内置浏览器里不同域名的网页通过 AJAX 访问回调 URL.
+//  NOTE:
+//  1. 对于 OPTIONS 预检请求直接返回 204, 不做签名验证(浏览器的预检请求不会带上微信的签名参数);
+//  2. 只对使用这个拦截器的 ServerFrontend(MultiServerFrontend) 生效, 同一个 http.ServeMux 上的其他路由不受影响.
+//
+//  frontend := NewServerFrontend(server, nil, NewCORSInterceptor([]string{"http://www.example.com"}))
+//  http.Handle("/wechat_callback", frontend)
+type CORSInterceptor struct {
+	allowAll bool
+	origins  map[string]struct{}
+}
+
+// 创建一个新的 CORSInterceptor, origins 是允许的 Origin 列表, 比如 http://www.example.com, "*" 表示允许所有的 Origin.
+func NewCORSInterceptor(origins []string) *CORSInterceptor {
+	interceptor := &CORSInterceptor{
+		origins: make(map[string]struct{}, len(origins)),
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			interceptor.allowAll = true
+			continue
+		}
+		interceptor.origins[origin] = struct{}{}
+	}
+	return interceptor
+}
+
+func (interceptor *CORSInterceptor) Intercept(w http.ResponseWriter, r *http.Request, queryValues url.Values) (shouldContinue bool) {
+	origin := r.Header.Get("Origin")
+	if origin != "" && interceptor.isAllowed(origin) {
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Set("Access-Control-Allow-Methods", corsAllowMethods)
+		header.Set("Access-Control-Allow-Headers", corsAllowHeaders)
+		header.Add("Vary", "Origin")
+	}
+
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusNoContent)
+		return false
+	}
+	return true
+}
+
+func (interceptor *CORSInterceptor) isAllowed(origin string) bool {
+	if interceptor.allowAll {
+		return true
+	}
+	_, ok := interceptor.origins[origin]
+	return ok
+}
@@ -0,0 +1,159 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package datacube
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// 把统计数据以 CSV 格式写入 w, 方便用 Excel 等工具打开.
+//  stats 必须是结构体的 slice(或者结构体指针的 slice), 比如 GetUserSummary 返回的 []UserSummaryData.
+//  NOTE:
+//  1. 第一行是表头, 每一列的名称是字段的 json tag 名称, 比如 ref_date, new_user;
+//  2. 嵌入的结构体(比如 ArticleBaseData)的字段会展开成单独的列;
+//  3. 非基本类型的字段(比如 ArticleTotalData.Details)会被忽略;
+//  4. ref_date 等日期字段按照微信返回的 YYYY-MM-DD 格式原样输出.
+func ExportCSV(w io.Writer, stats interface{}) (err error) {
+	if w == nil {
+		return errors.New("nil io.Writer")
+	}
+
+	statsValue := reflect.ValueOf(stats)
+	if statsValue.Kind() != reflect.Slice {
+		return fmt.Errorf("stats must be a slice, got %T", stats)
+	}
+
+	elemType := statsValue.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("stats must be a slice of struct, got %T", stats)
+	}
+
+	columns := csvColumns(elemType, nil)
+	if len(columns) == 0 {
+		return fmt.Errorf("%s has no exportable field", elemType)
+	}
+
+	csvWriter := csv.NewWriter(w)
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	if err = csvWriter.Write(record); err != nil {
+		return
+	}
+
+	for i, n := 0, statsValue.Len(); i < n; i++ {
+		elemValue := statsValue.Index(i)
+		if isPtr {
+			if elemValue.IsNil() {
+				continue
+			}
+			elemValue = elemValue.Elem()
+		}
+
+		for j, column := range columns {
+			record[j] = csvFormatValue(elemValue.FieldByIndex(column.index))
+		}
+		if err = csvWriter.Write(record); err != nil {
+			return
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// 把统计数据以 CSV 格式写入文件 path, 文件已经存在则覆盖, 参考 ExportCSV.
+func ExportCSVFile(path string, stats interface{}) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+
+	if err = ExportCSV(file, stats); err != nil {
+		file.Close()
+		return
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return
+	}
+	return file.Close()
+}
+
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+func csvColumns(structType reflect.Type, parentIndex []int) (columns []csvColumn) {
+	for i, n := 0, structType.NumField(); i < n; i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" { // 非导出字段
+			continue
+		}
+
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, csvColumns(field.Type, index)...)
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag = strings.Split(tag, ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+		}
+		columns = append(columns, csvColumn{name: name, index: index})
+	}
+	return
+}
+
+func csvFormatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return ""
+	}
+}
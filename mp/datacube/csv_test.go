@@ -0,0 +1,123 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package datacube
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	stats := []UserSummaryData{
+		{RefDate: "2014-12-07", UserSource: 0, NewUser: 10, CancelUser: 2},
+		{RefDate: "2014-12-08", UserSource: 35, NewUser: 3, CancelUser: 0},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"ref_date", "user_source", "new_user", "cancel_user"},
+		{"2014-12-07", "0", "10", "2"},
+		{"2014-12-08", "35", "3", "0"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("ExportCSV 输出错误,\nhave: %v,\nwant: %v", records, want)
+	}
+}
+
+func TestExportCSVEmbedded(t *testing.T) {
+	stats := []*UserReadHourData{
+		{
+			RefHour: 1500,
+			UserReadData: UserReadData{
+				RefDate: "2014-12-07",
+				ArticleBaseData: ArticleBaseData{
+					IntPageReadUser: 1,
+					ShareCount:      2,
+				},
+			},
+		},
+		nil,
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("ExportCSV 输出的行数错误, have: %d, want: 2", len(records))
+	}
+
+	header, row := records[0], records[1]
+	values := make(map[string]string, len(header))
+	for i, name := range header {
+		values[name] = row[i]
+	}
+	for name, value := range map[string]string{
+		"ref_hour":           "1500",
+		"total_online_time":  "0",
+		"ref_date":           "2014-12-07",
+		"int_page_read_user": "1",
+		"share_count":        "2",
+	} {
+		if values[name] != value {
+			t.Errorf("ExportCSV 列 %s 错误, have: %q, want: %q", name, values[name], value)
+		}
+	}
+}
+
+func TestExportCSVFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datacube")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stats := []UserSummaryData{{RefDate: "2014-12-07", NewUser: 1}}
+	path := filepath.Join(dir, "stats.csv")
+	if err = ExportCSVFile(path, stats); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = ExportCSV(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, buf.Bytes()) {
+		t.Errorf("ExportCSVFile 输出错误, have: %s, want: %s", content, buf.Bytes())
+	}
+}
+
+func TestExportCSVInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, UserSummaryData{}); err == nil {
+		t.Error("ExportCSV 应该拒绝非 slice 类型")
+	}
+	if err := ExportCSV(&buf, []int{1}); err == nil {
+		t.Error("ExportCSV 应该拒绝非结构体的 slice")
+	}
+}
@@ -0,0 +1,34 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package dkf
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 返回一个把消息转发到多客服的 mp.MessageHandler, 回复 response.TransferToCustomerService:
+//  mux.MessageHandle(request.MsgTypeText, command.NewCommandRouter().Register("人工", dkf.NewTransferHandler("")))
+//
+//  kfAccount: 指定接入的客服账号(账号前缀@公众号微信号), 为空时由多客服系统分配;
+//             指定的客服不在线或者接待人数已满时消息不会转发
+//
+//  NOTE: 客服只能在用户最近一次给公众号发消息(或者触发指定的事件)之后的 48 小时内通过客服消息接口回复用户,
+//  超过 48 小时客服发送的消息会失败, 需要等用户再次发消息.
+func NewTransferHandler(kfAccount string) mp.MessageHandler {
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		if r.MixedMsg == nil {
+			return
+		}
+		msg := response.NewTransferToCustomerService(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, kfAccount)
+
+		if err := response.Write(w, r, msg); err != nil {
+			mp.LogInfoln("[WECHAT_DKF_TRANSFER_HANDLER]", err)
+		}
+	})
+}
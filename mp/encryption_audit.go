@@ -0,0 +1,133 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// 安全模式下加密解密的审计接口, 用于合规要求记录所有加密消息的场景.
+//
+//  LogDecrypt 在 ServeHTTP 解密消息之后, 解析 XML 之前调用, plaintext 为解密后的 XML 原文, 解密失败时 plaintext 为 nil, err 不为 nil;
+//  LogEncrypt 在 WriteAESResponse 加密回复之后, 写入 http.ResponseWriter 之前调用.
+//
+//  NOTE: 审计在处理消息的 goroutine 里同步调用, 会增加回复的耗时; 实现 panic 的时候只记录日志.
+type AuditLogger interface {
+	LogDecrypt(appId string, ciphertext, plaintext []byte, err error)
+	LogEncrypt(appId string, plaintext, ciphertext []byte)
+}
+
+var auditLogger AuditLogger
+
+// 设置安全模式下加密解密的审计接口, nil 表示不审计.
+//  沒有加锁, 请确保在初始化阶段调用!
+func SetAuditLogger(logger AuditLogger) {
+	auditLogger = logger
+}
+
+func auditDecrypt(appId string, ciphertext, plaintext []byte, err error) {
+	if auditLogger == nil {
+		return
+	}
+	defer recoverAudit()
+	auditLogger.LogDecrypt(appId, ciphertext, plaintext, err)
+}
+
+func auditEncrypt(appId string, plaintext, ciphertext []byte) {
+	if auditLogger == nil {
+		return
+	}
+	defer recoverAudit()
+	auditLogger.LogEncrypt(appId, plaintext, ciphertext)
+}
+
+func recoverAudit() {
+	if v := recover(); v != nil {
+		LogInfoln("[WECHAT_AUDIT_LOGGER]", v, string(debug.Stack()))
+	}
+}
+
+var _ AuditLogger = (*FileAuditLogger)(nil)
+
+// 把审计记录以 JSON Lines 的格式追加到文件里, 每行一条记录:
+//  {"time":"2006-01-02T15:04:05Z","op":"decrypt","appid":"wx...","ciphertext":"base64...","plaintext":"<xml>...</xml>"}
+//
+//  ciphertext 会被 encoding/json 编码成 base64; 解密失败的记录有 error 字段, 没有 plaintext 字段.
+type FileAuditLogger struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+type auditRecord struct {
+	Time       time.Time `json:"time"`
+	Op         string    `json:"op"` // decrypt, encrypt
+	AppId      string    `json:"appid"`
+	Ciphertext []byte    `json:"ciphertext"`
+	Plaintext  string    `json:"plaintext,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// 创建一个新的 FileAuditLogger, 文件不存在时创建, 存在时追加.
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAuditLogger{
+		file: file,
+	}, nil
+}
+
+func (logger *FileAuditLogger) LogDecrypt(appId string, ciphertext, plaintext []byte, err error) {
+	record := auditRecord{
+		Time:       time.Now().UTC(),
+		Op:         "decrypt",
+		AppId:      appId,
+		Ciphertext: ciphertext,
+		Plaintext:  string(plaintext),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	logger.write(&record)
+}
+
+func (logger *FileAuditLogger) LogEncrypt(appId string, plaintext, ciphertext []byte) {
+	logger.write(&auditRecord{
+		Time:       time.Now().UTC(),
+		Op:         "encrypt",
+		AppId:      appId,
+		Ciphertext: ciphertext,
+		Plaintext:  string(plaintext),
+	})
+}
+
+func (logger *FileAuditLogger) write(record *auditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		LogInfoln("[WECHAT_AUDIT_LOGGER]", err)
+		return
+	}
+	line = append(line, '\n')
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	if _, err = logger.file.Write(line); err != nil {
+		LogInfoln("[WECHAT_AUDIT_LOGGER]", err)
+	}
+}
+
+// 关闭文件, 之后的记录都会失败.
+func (logger *FileAuditLogger) Close() error {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	return logger.file.Close()
+}
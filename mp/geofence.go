@@ -0,0 +1,60 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"io"
+	"math"
+	"net/http"
+)
+
+// 地球的平均半径, 单位为米
+const earthRadiusMeters = 6371008.8
+
+// 返回两个经纬度之间的球面距离(haversine 公式), 单位为米.
+//  使用 atan2 计算, 相距很近的点和对跖点都有比较好的精度.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	const rad = math.Pi / 180
+
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	if a > 1 { // 浮点误差
+		a = 1
+	}
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// 返回一个按照地理位置分发的 MessageHandler, 位置在以 (lat, lng) 为圆心, radiusMeters 为半径的范围内(包括边界)时
+// 调用 inside, 否则调用 outside:
+//  mux.EventHandle(request.EventTypeLocation, mp.NewGeoFenceHandler(39.9087, 116.3975, 500, insideHandler, nil))
+//
+//  支持上报地理位置事件(Latitude, Longitude)和地理位置消息(Location_X, Location_Y);
+//  inside, outside 可以为 nil, 为 nil 时回复 "success".
+//  NOTE: 微信的坐标是火星坐标(GCJ-02), 圆心也要使用同样的坐标系.
+func NewGeoFenceHandler(lat, lng, radiusMeters float64, inside, outside MessageHandler) MessageHandler {
+	if radiusMeters < 0 {
+		panic("radiusMeters must not be negative")
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		handler := outside
+		if msg := r.MixedMsg; msg != nil {
+			msgLat, msgLng := msg.Latitude, msg.Longitude
+			if msg.MsgType == "location" {
+				msgLat, msgLng = msg.LocationX, msg.LocationY
+			}
+			if Distance(lat, lng, msgLat, msgLng) <= radiusMeters {
+				handler = inside
+			}
+		}
+		if handler == nil {
+			io.WriteString(w, "success")
+			return
+		}
+		handler.ServeMessage(w, r)
+	})
+}
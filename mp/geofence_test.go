@@ -0,0 +1,73 @@
+package mp
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	const lat, lng = 39.9087, 116.3975
+
+	if d := Distance(lat, lng, lat, lng); d != 0 {
+		t.Errorf("Distance to center, have: %v, want: 0", d)
+	}
+
+	// 对跖点的距离是半个周长
+	want := math.Pi * earthRadiusMeters
+	if d := Distance(lat, lng, -lat, lng-180); math.Abs(d-want) > 1 {
+		t.Errorf("Distance to antipode, have: %v, want: %v", d, want)
+	}
+	if d := Distance(0, 0, 0, 180); math.Abs(d-want) > 1 {
+		t.Errorf("Distance to antipode on equator, have: %v, want: %v", d, want)
+	}
+}
+
+func TestGeoFenceHandler(t *testing.T) {
+	const lat, lng = 39.9087, 116.3975
+
+	// 正北方向纬度每增加 1 度的距离是 R*π/180
+	metersPerDegree := earthRadiusMeters * math.Pi / 180
+	atRadius := lat + 500/metersPerDegree
+	radius := Distance(lat, lng, atRadius, lng)
+
+	var result string
+	inside := MessageHandlerFunc(func(http.ResponseWriter, *Request) { result = "inside" })
+	outside := MessageHandlerFunc(func(http.ResponseWriter, *Request) { result = "outside" })
+	handler := NewGeoFenceHandler(lat, lng, radius, inside, outside)
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+		want     string
+	}{
+		{"center", lat, lng, "inside"},
+		{"boundary", atRadius, lng, "inside"},
+		{"radius+1m", lat + (radius+1)/metersPerDegree, lng, "outside"},
+		{"antipode", -lat, lng - 180, "outside"},
+	}
+	for _, tt := range tests {
+		result = ""
+		msg := &MixedMessage{Latitude: tt.lat, Longitude: tt.lng}
+		msg.MsgType = "event"
+		handler.ServeMessage(httptest.NewRecorder(), &Request{MixedMsg: msg})
+		if result != tt.want {
+			t.Errorf("%s: have: %q, want: %q", tt.name, result, tt.want)
+		}
+	}
+
+	msg := &MixedMessage{LocationX: lat, LocationY: lng}
+	msg.MsgType = "location"
+	result = ""
+	handler.ServeMessage(httptest.NewRecorder(), &Request{MixedMsg: msg})
+	if result != "inside" {
+		t.Errorf("location message: have: %q, want: %q", result, "inside")
+	}
+
+	w := httptest.NewRecorder()
+	NewGeoFenceHandler(lat, lng, radius, nil, nil).ServeMessage(w, &Request{MixedMsg: msg})
+	if w.Body.String() != "success" {
+		t.Errorf("nil handler: have body: %q, want: %q", w.Body.String(), "success")
+	}
+}
@@ -17,7 +17,8 @@ import (
 
 // 明文模式下回复消息给微信服务器.
 //  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式);
-//  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random.
+//  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random;
+//  w 是 SafeResponseWriter 并且已经回复过时返回 ErrAlreadyReplied.
 func WriteRawResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
 	if w == nil {
 		return errors.New("nil http.ResponseWriter")
@@ -25,7 +26,16 @@ func WriteRawResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 	if msg == nil {
 		return errors.New("nil message")
 	}
-	return xml.NewEncoder(w).Encode(msg)
+
+	guardResponse(r, msg)
+
+	// 一次 Write 写完, 见 SafeResponseWriter
+	rawMsgXML, err := xml.Marshal(msg)
+	if err != nil {
+		return
+	}
+	_, err = writeReply(w, rawMsgXML)
+	return
 }
 
 // 安全模式下回复消息的 http body
@@ -40,7 +50,8 @@ type ResponseHttpBody struct {
 
 // 安全模式下回复消息给微信服务器.
 //  要求 msg 是有效的消息数据结构(经过 encoding/xml marshal 后符合微信消息格式);
-//  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random.
+//  如果有必要可以修改 Request 里面的某些值, 比如 Timestamp, Nonce, Random;
+//  w 是 SafeResponseWriter 并且已经回复过时返回 ErrAlreadyReplied.
 func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err error) {
 	if w == nil {
 		return errors.New("nil http.ResponseWriter")
@@ -52,12 +63,19 @@ func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 		return errors.New("nil message")
 	}
 
+	guardResponse(r, msg)
+
 	rawMsgXML, err := xml.Marshal(msg)
 	if err != nil {
 		return
 	}
+	return writeAESResponse(w, r, rawMsgXML)
+}
 
+// 用 r 的 AESKey, Random, Timestamp, Nonce 加密 rawMsgXML 并写入 w.
+func writeAESResponse(w http.ResponseWriter, r *Request, rawMsgXML []byte) (err error) {
 	encryptedMsg := util.AESEncryptMsg(r.Random, rawMsgXML, r.AppId, r.AESKey)
+	auditEncrypt(r.AppId, rawMsgXML, encryptedMsg)
 	base64EncryptedMsg := base64.StdEncoding.EncodeToString(encryptedMsg)
 
 	responseHttpBody := ResponseHttpBody{
@@ -69,5 +87,10 @@ func WriteAESResponse(w http.ResponseWriter, r *Request, msg interface{}) (err e
 	TimestampStr := strconv.FormatInt(responseHttpBody.Timestamp, 10)
 	responseHttpBody.MsgSignature = util.MsgSign(r.Token, TimestampStr, responseHttpBody.Nonce, responseHttpBody.EncryptedMsg)
 
-	return xml.NewEncoder(w).Encode(&responseHttpBody)
+	body, err := xml.Marshal(&responseHttpBody)
+	if err != nil {
+		return
+	}
+	_, err = writeReply(w, body)
+	return
 }
@@ -0,0 +1,60 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wx.config 需要的参数, 可以直接 JSON 编码以后传给前端:
+//  wx.config({debug: false, appId: cfg.appId, timestamp: cfg.timestamp, nonceStr: cfg.nonceStr, signature: cfg.signature, jsApiList: [...]})
+type WXConfig struct {
+	AppId     string `json:"appId"`
+	Timestamp int64  `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// 用 srv 缓存的 jsapi_ticket 为页面 url 生成 wx.config 的参数.
+//  url 是调用 wx.config 的页面的完整 URL, # 及其后面的部分会被去掉.
+func NewWXConfig(srv TicketServer, appId, url string) (cfg *WXConfig, err error) {
+	if srv == nil {
+		err = errors.New("nil TicketServer")
+		return
+	}
+	if url == "" {
+		err = errors.New("empty url")
+		return
+	}
+	if i := strings.IndexByte(url, '#'); i >= 0 {
+		url = url[:i]
+	}
+
+	ticket, err := srv.Ticket()
+	if err != nil {
+		return
+	}
+
+	var b [16]byte
+	if _, err = rand.Read(b[:]); err != nil {
+		return
+	}
+	nonceStr := hex.EncodeToString(b[:])
+	timestamp := time.Now().Unix()
+
+	cfg = &WXConfig{
+		AppId:     appId,
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: WXConfigSign(ticket, nonceStr, strconv.FormatInt(timestamp, 10), url),
+	}
+	return
+}
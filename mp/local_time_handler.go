@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type localTimeContextKey struct{}
+
+// 获取 LocalTimeHandler 保存的消息创建时间, 没有保存时返回零值.
+func LocalTimeFromRequest(r *Request) time.Time {
+	if r == nil || r.HttpRequest == nil {
+		return time.Time{}
+	}
+	t, _ := r.HttpRequest.Context().Value(localTimeContextKey{}).(time.Time)
+	return t
+}
+
+// 返回一个先把消息的 CreateTime 转换成 loc 时区的时间, 再调用 handler 的 MessageHandler,
+// handler 里可以用 LocalTimeFromRequest 获取转换后的时间:
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, NewLocalTimeHandler(messageServeMux, loc))
+//
+//  CreateTime 是 Unix 时间戳, 和时区无关; 部署在国外的服务需要按照用户所在的时区显示时间.
+//  loc 为 nil 时使用 time.Local.
+//
+//  NOTE: 不会修改 r.MixedMsg.CreateTime, 转换后的时间保存在 r.HttpRequest 的 Context 里,
+//  r.HttpRequest 为 nil 的时候直接调用 handler.
+func NewLocalTimeHandler(handler MessageHandler, loc *time.Location) MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		if r.HttpRequest == nil || r.MixedMsg == nil {
+			handler.ServeMessage(w, r)
+			return
+		}
+
+		t := time.Unix(r.MixedMsg.CreateTime, 0).In(loc)
+		req := *r
+		req.HttpRequest = r.HttpRequest.WithContext(context.WithValue(r.HttpRequest.Context(), localTimeContextKey{}, t))
+		handler.ServeMessage(w, &req)
+	})
+}
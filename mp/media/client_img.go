@@ -68,3 +68,14 @@ func (clt *Client) uploadImagePermanentFromReader(filename string, reader io.Rea
 	info = result.ImageInfo
 	return
 }
+
+// 上传卡券图片(比如卡券的 logo), 返回永久有效的图片 URL, 创建卡券时引用该 URL.
+//  NOTE: 和 UploadImage 等上传临时素材的接口不同, 这个接口返回的是 URL 而不是 media_id, 并且永久有效.
+func (clt *Client) UploadCardImage(filename string, reader io.Reader) (url string, err error) {
+	info, err := clt.UploadImagePermanentFromReader(filename, reader)
+	if err != nil {
+		return
+	}
+	url = info.URL
+	return
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type testAccessTokenServer string
+
+func (srv testAccessTokenServer) Token() (string, error)               { return string(srv), nil }
+func (srv testAccessTokenServer) TokenRefresh() (string, error)        { return string(srv), nil }
+func (srv testAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+// 把所有请求都转发到 target, 用来模拟微信服务器.
+type testRedirectTransport struct {
+	target *url.URL
+}
+
+func (t testRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestUploadCardImage(t *testing.T) {
+	const (
+		testToken    = "ACCESS_TOKEN"
+		testFilename = "logo.jpg"
+		testContent  = "fake image content"
+		testURL      = "http://mmbiz.qpic.cn/mmbiz/iaL1LJM1mF9aRKPZJkmG8xXhiaHqkKSVMMWeN3hLut7X7hicFNjakmxibMLGWpXrEXB33367o7zHN0CwngnQY7zb7g/0"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cgi-bin/media/uploadimg" {
+			t.Errorf("请求路径错误, have: %s", r.URL.Path)
+		}
+		if token := r.URL.Query().Get("access_token"); token != testToken {
+			t.Errorf("access_token 错误, have: %s, want: %s", token, testToken)
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Errorf("Content-Type 错误, have: %s", r.Header.Get("Content-Type"))
+		}
+
+		file, header, err := r.FormFile("buffer")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer file.Close()
+
+		if header.Filename != testFilename {
+			t.Errorf("文件名错误, have: %s, want: %s", header.Filename, testFilename)
+		}
+		content, err := ioutil.ReadAll(file)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if string(content) != testContent {
+			t.Errorf("文件内容错误, have: %s, want: %s", content, testContent)
+		}
+
+		w.Write([]byte(`{"url":"` + testURL + `"}`))
+	}))
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := &http.Client{Transport: testRedirectTransport{target: target}}
+	clt := NewClient(testAccessTokenServer(testToken), httpClient)
+
+	imgURL, err := clt.UploadCardImage(testFilename, strings.NewReader(testContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imgURL != testURL {
+		t.Errorf("URL 错误, have: %s, want: %s", imgURL, testURL)
+	}
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"sync"
+)
+
+// 按照 key 加锁, 同一个 key 同一时间只有一个 goroutine 持有锁, 不同的 key 互不影响.
+//  每个 key 的锁带有引用计数, 最后一个等待者释放之后才删除, 保证等待同一个 key 的 goroutine 用的是同一把锁.
+//  零值可以直接使用.
+type keyedMutex struct {
+	mutex sync.Mutex
+	locks map[string]*refMutex
+}
+
+type refMutex struct {
+	sync.Mutex
+	refs int // 持有和等待这把锁的 goroutine 个数, 由 keyedMutex.mutex 保护
+}
+
+func (km *keyedMutex) lock(key string) (unlock func()) {
+	km.mutex.Lock()
+	if km.locks == nil {
+		km.locks = make(map[string]*refMutex)
+	}
+	mu := km.locks[key]
+	if mu == nil {
+		mu = new(refMutex)
+		km.locks[key] = mu
+	}
+	mu.refs++
+	km.mutex.Unlock()
+
+	mu.Lock()
+	return func() {
+		mu.Unlock()
+		km.mutex.Lock()
+		if mu.refs--; mu.refs == 0 {
+			delete(km.locks, key)
+		}
+		km.mutex.Unlock()
+	}
+}
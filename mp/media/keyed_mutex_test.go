@@ -0,0 +1,41 @@
+package media
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	var km keyedMutex
+	var running, maxRunning int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := km.lock("media_id")
+			defer unlock()
+
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 1 {
+		t.Errorf("max concurrent holders, have: %d, want: 1", maxRunning)
+	}
+	if len(km.locks) != 0 {
+		t.Errorf("locks left after all unlocked: %d", len(km.locks))
+	}
+}
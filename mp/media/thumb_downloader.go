@@ -0,0 +1,114 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var _ http.Handler = (*ThumbDownloader)(nil)
+
+// ThumbDownloader 下载视频消息(小视频消息)的缩略图 ThumbMediaId, 缓存到本地目录, 同时可以作为 http.Handler 提供缓存的缩略图:
+//  downloader := media.NewThumbDownloader(clt, "/var/cache/wechat/thumb")
+//  http.Handle("/internal/thumb", downloader) // GET /internal/thumb?media_id=THUMB_MEDIA_ID
+//
+//  NOTE: 临时素材只保存 3 天, 需要在收到消息之后尽快下载.
+type ThumbDownloader struct {
+	clt      *Client
+	cacheDir string
+
+	pending keyedMutex // 正在下载的 mediaId, 避免同一个缩略图被并发下载多次
+}
+
+// 创建一个新的 ThumbDownloader.
+//  cacheDir: 缓存 jpg 文件的目录, 不存在时会自动创建
+func NewThumbDownloader(clt *Client, cacheDir string) *ThumbDownloader {
+	if clt == nil {
+		panic("nil Client")
+	}
+	if cacheDir == "" {
+		panic("empty cacheDir")
+	}
+	return &ThumbDownloader{
+		clt:      clt,
+		cacheDir: cacheDir,
+	}
+}
+
+// 缓存文件的路径, 用 mediaId 的 sha1 做文件名, 防止 mediaId 里有路径分隔符等特殊字符.
+func (downloader *ThumbDownloader) cachePath(mediaId string) string {
+	hashsum := sha1.Sum([]byte(mediaId))
+	return filepath.Join(downloader.cacheDir, hex.EncodeToString(hashsum[:])+".jpg")
+}
+
+// 下载缩略图 thumbMediaId, 返回 jpg 文件的路径; 已经缓存的直接返回缓存的路径.
+func (downloader *ThumbDownloader) Download(thumbMediaId string) (path string, err error) {
+	if thumbMediaId == "" {
+		err = errors.New("empty thumbMediaId")
+		return
+	}
+
+	path = downloader.cachePath(thumbMediaId)
+	if _, err = os.Stat(path); err == nil {
+		return
+	}
+
+	unlock := downloader.pending.lock(thumbMediaId)
+	defer unlock()
+
+	// 等待锁的时候可能已经被其他 goroutine 下载好了
+	if _, err = os.Stat(path); err == nil {
+		return
+	}
+
+	if err = os.MkdirAll(downloader.cacheDir, 0755); err != nil {
+		return
+	}
+
+	// 先下载到临时文件再重命名, 保证缓存里的文件都是完整的
+	file, err := ioutil.TempFile(downloader.cacheDir, "thumb-")
+	if err != nil {
+		return
+	}
+	tmpPath := file.Name()
+	file.Close()
+
+	if _, err = downloader.clt.DownloadMedia(thumbMediaId, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	return
+}
+
+// ThumbDownloader 实现了 http.Handler 接口, 返回 media_id 参数对应的缩略图, 没有缓存时先下载.
+func (downloader *ThumbDownloader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mediaId := r.URL.Query().Get("media_id")
+	if mediaId == "" {
+		http.Error(w, "media_id is required", http.StatusBadRequest)
+		return
+	}
+
+	path, err := downloader.Download(mediaId)
+	if err != nil {
+		mp.LogInfoln("[WECHAT_THUMB_DOWNLOADER]", err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	http.ServeFile(w, r, path)
+}
@@ -0,0 +1,138 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// VoiceDownloader 下载用户发送的语音消息(amr 格式), 用 ffmpeg 转换成 mp3 后缓存到本地目录.
+//  一般用于把语音交给语音识别等只支持 mp3 的服务处理.
+//
+//  NOTE: 需要系统安装了 ffmpeg, 默认从 PATH 里查找, 可以通过 FFmpegPath 指定.
+type VoiceDownloader struct {
+	FFmpegPath string // ffmpeg 可执行文件的路径, 为空时使用 "ffmpeg"
+
+	clt      *Client
+	cacheDir string
+
+	pending keyedMutex // 正在下载的 mediaId, 避免同一个语音被并发下载多次
+}
+
+// 创建一个新的 VoiceDownloader.
+//  cacheDir: 缓存 mp3 文件的目录, 不存在时会自动创建
+func NewVoiceDownloader(clt *Client, cacheDir string) *VoiceDownloader {
+	if clt == nil {
+		panic("nil Client")
+	}
+	if cacheDir == "" {
+		panic("empty cacheDir")
+	}
+	return &VoiceDownloader{
+		clt:      clt,
+		cacheDir: cacheDir,
+	}
+}
+
+// 缓存文件的路径, 用 mediaId 的 sha1 做文件名, 防止 mediaId 里有路径分隔符等特殊字符.
+func (downloader *VoiceDownloader) cachePath(mediaId string) string {
+	hashsum := sha1.Sum([]byte(mediaId))
+	return filepath.Join(downloader.cacheDir, hex.EncodeToString(hashsum[:])+".mp3")
+}
+
+// 下载语音 mediaId 并转换成 mp3, 返回 mp3 文件的路径; 已经缓存的直接返回缓存的路径.
+func (downloader *VoiceDownloader) Download(mediaId string) (path string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	path = downloader.cachePath(mediaId)
+	if _, err = os.Stat(path); err == nil {
+		return
+	}
+
+	unlock := downloader.pending.lock(mediaId)
+	defer unlock()
+
+	// 等待锁的时候可能已经被其他 goroutine 下载好了
+	if _, err = os.Stat(path); err == nil {
+		return
+	}
+
+	if err = os.MkdirAll(downloader.cacheDir, 0755); err != nil {
+		return
+	}
+
+	amrFile, err := ioutil.TempFile(downloader.cacheDir, "voice-")
+	if err != nil {
+		return
+	}
+	amrPath := amrFile.Name()
+	amrFile.Close()
+	defer os.Remove(amrPath)
+
+	if _, err = downloader.clt.DownloadMedia(mediaId, amrPath); err != nil {
+		return
+	}
+
+	// 先转换到临时文件再重命名, 保证缓存里的文件都是完整的
+	mp3File, err := ioutil.TempFile(downloader.cacheDir, "voice-")
+	if err != nil {
+		return
+	}
+	tmpPath := mp3File.Name()
+	mp3File.Close()
+
+	ffmpegPath := downloader.FFmpegPath
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	cmd := exec.Command(ffmpegPath, "-y", "-loglevel", "error", "-i", amrPath, "-f", "mp3", tmpPath)
+	if output, e := cmd.CombinedOutput(); e != nil {
+		os.Remove(tmpPath)
+		err = fmt.Errorf("ffmpeg failed: %s, output: %s", e.Error(), strings.TrimSpace(string(output)))
+		return
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	return
+}
+
+// 删除修改时间在 olderThan 之前的缓存文件.
+func (downloader *VoiceDownloader) Cleanup(olderThan time.Duration) (err error) {
+	files, err := ioutil.ReadDir(downloader.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	deadline := time.Now().Add(-olderThan)
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), ".mp3") {
+			continue
+		}
+		if fi.ModTime().Before(deadline) {
+			if e := os.Remove(filepath.Join(downloader.cacheDir, fi.Name())); e != nil && !os.IsNotExist(e) {
+				err = e
+			}
+		}
+	}
+	return
+}
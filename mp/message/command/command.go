@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package command
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 命令的前缀
+const Prefix = "/"
+
+// 解析文本消息的内容, 第一个空白字符(包括全角空格)之前的是命令, 之后用空白字符分隔的是参数.
+//
+//  1. 以 Prefix 开头的是命令, 比如 "/weather Beijing" 解析为 cmd == "weather", args == ["Beijing"];
+//  2. 不以 Prefix 开头时, 只有第一个字符是汉字才认为是命令, 比如 "查天气 北京" 解析为
+//     cmd == "查天气", args == ["北京"], 而 "hello world" 这样的普通文本返回 ok == false;
+//  3. 英文命令统一转换为小写, "/Weather" 和 "/weather" 是同一个命令.
+func ParseCommand(content string) (cmd string, args []string, ok bool) {
+	fields := strings.FieldsFunc(content, unicode.IsSpace)
+	if len(fields) == 0 {
+		return
+	}
+
+	cmd = fields[0]
+	if strings.HasPrefix(cmd, Prefix) {
+		cmd = cmd[len(Prefix):]
+	} else {
+		r, _ := utf8.DecodeRuneInString(cmd)
+		if !unicode.Is(unicode.Han, r) {
+			cmd = ""
+			return
+		}
+	}
+	if cmd == "" {
+		return
+	}
+
+	cmd = strings.ToLower(cmd)
+	args = fields[1:]
+	ok = true
+	return
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func noopHandler(http.ResponseWriter, *mp.Request) {}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		content string
+		cmd     string
+		args    []string
+		ok      bool
+	}{
+		{"/weather Beijing", "weather", []string{"Beijing"}, true},
+		{"/Weather  Beijing  today", "weather", []string{"Beijing", "today"}, true},
+		{"查天气 北京", "查天气", []string{"北京"}, true},
+		{"/查天气　北京", "查天气", []string{"北京"}, true},
+		{"  /help", "help", []string{}, true},
+		{"hello world", "", nil, false},
+		{"/ weather", "", nil, false},
+		{"", "", nil, false},
+		{"   ", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		cmd, args, ok := ParseCommand(tt.content)
+		if cmd != tt.cmd || ok != tt.ok || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("ParseCommand(%q) = %q, %q, %v; want %q, %q, %v", tt.content, cmd, args, ok, tt.cmd, tt.args, tt.ok)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"weather", "weather", 0},
+		{"waether", "weather", 2},
+		{"wether", "weather", 1},
+		{"weathers", "weather", 1},
+		{"查天汽", "查天气", 1},
+		{"", "help", 4},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyCommandRouterMatch(t *testing.T) {
+	router := NewFuzzyCommandRouter(1).
+		RegisterFunc("weather", noopHandler).
+		RegisterFunc("/Help", noopHandler).
+		RegisterFunc("查天气", noopHandler)
+
+	tests := []struct {
+		cmd      string
+		matched  string
+		distance int
+		ok       bool
+	}{
+		{"weather", "weather", 0, true},
+		{"WEATHER", "weather", 0, true},
+		{"wether", "weather", 1, true},
+		{"hlep", "", 2, false},
+		{"halp", "help", 1, true},
+		{"查天汽", "查天气", 1, true},
+		{"stock", "", 5, false},
+	}
+	for _, tt := range tests {
+		matched, distance, ok := router.Match(tt.cmd)
+		if matched != tt.matched || distance != tt.distance || ok != tt.ok {
+			t.Errorf("Match(%q) = %q, %d, %v; want %q, %d, %v", tt.cmd, matched, distance, ok, tt.matched, tt.distance, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyCommandRouterServeMessage(t *testing.T) {
+	var routed, content string
+	handler := func(cmd string) func(http.ResponseWriter, *mp.Request) {
+		return func(w http.ResponseWriter, r *mp.Request) {
+			routed, content = cmd, r.MixedMsg.Content
+		}
+	}
+	router := NewFuzzyCommandRouter(1).
+		RegisterFunc("weather", handler("weather")).
+		RegisterFunc("查天气", handler("查天气")).
+		DefaultHandle(mp.MessageHandlerFunc(handler("default")))
+
+	tests := []struct {
+		content string
+		routed  string
+		want    string
+	}{
+		{"/wether 北京", "weather", "/weather 北京"},
+		{"/waether 北京", "default", "/waether 北京"}, // 编辑距离是 2
+		{"查天汽 北京", "查天气", "/查天气 北京"},
+		{"wether 北京", "default", "wether 北京"}, // 不带 Prefix 的聊天内容不纠错
+		{"   ", "default", "   "},
+	}
+	for _, tt := range tests {
+		routed, content = "", ""
+		router.ServeMessage(nil, &mp.Request{MixedMsg: &mp.MixedMessage{Content: tt.content}})
+		if routed != tt.routed || content != tt.want {
+			t.Errorf("ServeMessage(%q) routed to %q with %q; want %q with %q", tt.content, routed, content, tt.routed, tt.want)
+		}
+	}
+}
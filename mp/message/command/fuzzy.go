@@ -0,0 +1,268 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package command
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+var _ mp.MessageHandler = (*FuzzyCommandRouter)(nil)
+
+// FuzzyCommandRouter 和 CommandRouter 类似, 但是命令没有完全匹配的时候, 会按照编辑距离(Levenshtein distance)
+// 找最接近的已注册命令, 距离不超过 maxDistance 就当作这个命令处理, 用于容忍手机上输入的错别字:
+//  router := command.NewFuzzyCommandRouter(1).Register("weather", weatherHandler)
+//  // "/wether 北京" 会路由到 weatherHandler, "/waether 北京" 的编辑距离是 2, 不会路由
+//
+//  和 CommandRouter 一样, 只有 ParseCommand 能解析出命令的消息(带 Prefix 或者以汉字开头)才会纠错,
+//  普通的聊天内容交给 DefaultHandler 处理.
+//
+//  路由到后端 mp.MessageHandler 时 r.MixedMsg.Content 里的命令已经被纠正, 后端可以直接调用 ParseCommand.
+//  调用 DidYouMean 之后不会自动纠正, 而是先回复用户确认.
+type FuzzyCommandRouter struct {
+	maxDistance int
+
+	rwmutex        sync.RWMutex
+	handlerMap     map[string]mp.MessageHandler // map[cmd]mp.MessageHandler
+	cmds           []string                     // 按照注册的顺序, 距离相同时取先注册的命令
+	defaultHandler mp.MessageHandler
+	conversation   *mp.ConversationContext // 不为 nil 时需要用户确认
+}
+
+// 创建一个新的 FuzzyCommandRouter.
+//  maxDistance: 允许的最大编辑距离, 0 表示只能完全匹配
+func NewFuzzyCommandRouter(maxDistance int) *FuzzyCommandRouter {
+	if maxDistance < 0 {
+		panic("maxDistance must be non-negative")
+	}
+	return &FuzzyCommandRouter{
+		maxDistance: maxDistance,
+		handlerMap:  make(map[string]mp.MessageHandler),
+	}
+}
+
+// 注册命令 cmd 的 mp.MessageHandler, cmd 不区分大小写, 可以带 Prefix.
+func (router *FuzzyCommandRouter) Register(cmd string, handler mp.MessageHandler) *FuzzyCommandRouter {
+	cmd = strings.ToLower(strings.TrimPrefix(cmd, Prefix))
+	if cmd == "" {
+		panic("empty cmd")
+	}
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	router.rwmutex.Lock()
+	if _, ok := router.handlerMap[cmd]; !ok {
+		router.cmds = append(router.cmds, cmd)
+	}
+	router.handlerMap[cmd] = handler
+	router.rwmutex.Unlock()
+	return router
+}
+
+// 注册命令 cmd 的 mp.MessageHandler, cmd 不区分大小写, 可以带 Prefix.
+func (router *FuzzyCommandRouter) RegisterFunc(cmd string, handler func(http.ResponseWriter, *mp.Request)) *FuzzyCommandRouter {
+	return router.Register(cmd, mp.MessageHandlerFunc(handler))
+}
+
+// 注册没有匹配到命令时的 mp.MessageHandler, 默认回复 "未知命令".
+func (router *FuzzyCommandRouter) DefaultHandle(handler mp.MessageHandler) *FuzzyCommandRouter {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	router.rwmutex.Lock()
+	router.defaultHandler = handler
+	router.rwmutex.Unlock()
+	return router
+}
+
+// 模糊匹配的时候不自动纠正, 而是回复 "你是不是要输入 /xxx ? 回复 "是" 确认", 用户在 DidYouMeanTTL 内回复确认之后
+// 再按照纠正后的命令处理. 待确认的命令保存在 conversation 里.
+func (router *FuzzyCommandRouter) DidYouMean(conversation *mp.ConversationContext) *FuzzyCommandRouter {
+	if conversation == nil {
+		panic("nil ConversationContext")
+	}
+
+	router.rwmutex.Lock()
+	router.conversation = conversation
+	router.rwmutex.Unlock()
+	return router
+}
+
+const (
+	DidYouMeanTTL = 5 * time.Minute // 等待用户确认的时间
+
+	didYouMeanContextKey = "command.did_you_mean"
+)
+
+// 用户确认的回复
+var confirmReplies = map[string]bool{
+	"是": true, "是的": true, "对": true, "确认": true, "y": true, "yes": true, "ok": true,
+}
+
+// 找到和 cmd 编辑距离最小的已注册命令, 距离超过 maxDistance 时 ok 为 false.
+func (router *FuzzyCommandRouter) Match(cmd string) (matched string, distance int, ok bool) {
+	cmd = strings.ToLower(cmd)
+
+	router.rwmutex.RLock()
+	defer router.rwmutex.RUnlock()
+
+	if _, ok = router.handlerMap[cmd]; ok {
+		return cmd, 0, true
+	}
+	distance = -1
+	for _, name := range router.cmds {
+		if d := levenshtein(cmd, name); distance < 0 || d < distance {
+			matched, distance = name, d
+		}
+	}
+	if distance < 0 || distance > router.maxDistance {
+		return "", distance, false
+	}
+	return matched, distance, true
+}
+
+// FuzzyCommandRouter 实现了 mp.MessageHandler 接口.
+func (router *FuzzyCommandRouter) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	if r.MixedMsg == nil {
+		router.serveDefault(w, r)
+		return
+	}
+
+	router.rwmutex.RLock()
+	conversation := router.conversation
+	router.rwmutex.RUnlock()
+
+	var cc *mp.ConversationContext
+	if conversation != nil {
+		cc = conversation.ForRequest(r)
+		if router.serveConfirm(w, r, cc) {
+			return
+		}
+	}
+
+	cmd, args, ok := ParseCommand(r.MixedMsg.Content)
+	if !ok {
+		router.serveDefault(w, r)
+		return
+	}
+
+	matched, distance, ok := router.Match(cmd)
+	if !ok {
+		router.serveDefault(w, r)
+		return
+	}
+	content := Prefix + matched
+	if len(args) > 0 {
+		content += " " + strings.Join(args, " ")
+	}
+	if distance == 0 || cc == nil {
+		router.dispatch(w, r, matched, content)
+		return
+	}
+
+	if err := cc.Set(r.MixedMsg.FromUserName, didYouMeanContextKey, content, DidYouMeanTTL); err != nil {
+		mp.LogInfoln("[WECHAT_FUZZY_COMMAND_ROUTER]", err)
+	}
+	writeText(w, r, "你是不是要输入 "+Prefix+matched+" ? 回复 \"是\" 确认")
+}
+
+// 处理用户对 DidYouMean 的确认, 已经处理返回 true.
+func (router *FuzzyCommandRouter) serveConfirm(w http.ResponseWriter, r *mp.Request, cc *mp.ConversationContext) bool {
+	openId := r.MixedMsg.FromUserName
+	value, ok, err := cc.Get(openId, didYouMeanContextKey)
+	if err != nil {
+		mp.LogInfoln("[WECHAT_FUZZY_COMMAND_ROUTER]", err)
+		return false
+	}
+	content, _ := value.(string)
+	if !ok || content == "" {
+		return false
+	}
+
+	// 不管是不是确认, 待确认的命令都只使用一次
+	if err = cc.Set(openId, didYouMeanContextKey, "", time.Second); err != nil {
+		mp.LogInfoln("[WECHAT_FUZZY_COMMAND_ROUTER]", err)
+	}
+	if !confirmReplies[strings.ToLower(strings.TrimSpace(r.MixedMsg.Content))] {
+		return false
+	}
+	cmd, _, _ := ParseCommand(content)
+	router.dispatch(w, r, cmd, content)
+	return true
+}
+
+// 用纠正后的 content 调用 cmd 的 mp.MessageHandler, 不修改原来的 r.
+func (router *FuzzyCommandRouter) dispatch(w http.ResponseWriter, r *mp.Request, cmd, content string) {
+	router.rwmutex.RLock()
+	handler := router.handlerMap[cmd]
+	router.rwmutex.RUnlock()
+	if handler == nil {
+		router.serveDefault(w, r)
+		return
+	}
+
+	msg := *r.MixedMsg
+	msg.Content = content
+	req := *r
+	req.MixedMsg = &msg
+	handler.ServeMessage(w, &req)
+}
+
+func (router *FuzzyCommandRouter) serveDefault(w http.ResponseWriter, r *mp.Request) {
+	router.rwmutex.RLock()
+	handler := router.defaultHandler
+	router.rwmutex.RUnlock()
+	if handler == nil {
+		handler = unknownCommandHandler
+	}
+	handler.ServeMessage(w, r)
+}
+
+func writeText(w http.ResponseWriter, r *mp.Request, content string) {
+	msg := response.NewText(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, content)
+	if err := response.Write(w, r, msg); err != nil {
+		mp.LogInfoln("[WECHAT_FUZZY_COMMAND_ROUTER]", err)
+	}
+}
+
+// 按照 unicode 字符计算 a 和 b 的编辑距离.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
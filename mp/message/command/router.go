@@ -0,0 +1,101 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package command
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+var _ mp.MessageHandler = (*CommandRouter)(nil)
+
+// CommandRouter 按照文本消息里的命令路由到不同的 mp.MessageHandler, 同时也是一个 mp.MessageHandler 的实现,
+// 一般注册到 MessageServeMux 处理文本消息:
+//  mux.MessageHandle(request.MsgTypeText, command.NewCommandRouter().Register("weather", weatherHandler))
+//
+//  后端的 mp.MessageHandler 可以调用 ParseCommand(r.MixedMsg.Content) 获取参数.
+type CommandRouter struct {
+	rwmutex        sync.RWMutex
+	handlerMap     map[string]mp.MessageHandler // map[cmd]mp.MessageHandler
+	defaultHandler mp.MessageHandler
+}
+
+func NewCommandRouter() *CommandRouter {
+	return &CommandRouter{
+		handlerMap: make(map[string]mp.MessageHandler),
+	}
+}
+
+// 注册命令 cmd 的 mp.MessageHandler, cmd 不区分大小写, 可以带 Prefix.
+func (router *CommandRouter) Register(cmd string, handler mp.MessageHandler) *CommandRouter {
+	cmd = strings.ToLower(strings.TrimPrefix(cmd, Prefix))
+	if cmd == "" {
+		panic("empty cmd")
+	}
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	router.rwmutex.Lock()
+	router.handlerMap[cmd] = handler
+	router.rwmutex.Unlock()
+	return router
+}
+
+// 注册命令 cmd 的 mp.MessageHandler, cmd 不区分大小写, 可以带 Prefix.
+func (router *CommandRouter) RegisterFunc(cmd string, handler func(http.ResponseWriter, *mp.Request)) *CommandRouter {
+	return router.Register(cmd, mp.MessageHandlerFunc(handler))
+}
+
+// 注册没有找到命令时的 mp.MessageHandler, 默认回复 "未知命令".
+func (router *CommandRouter) DefaultHandle(handler mp.MessageHandler) *CommandRouter {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	router.rwmutex.Lock()
+	router.defaultHandler = handler
+	router.rwmutex.Unlock()
+	return router
+}
+
+// 获取 r 对应的 mp.MessageHandler, 不是命令或者命令没有注册时返回默认的 mp.MessageHandler.
+func (router *CommandRouter) Route(r *mp.Request) (handler mp.MessageHandler) {
+	router.rwmutex.RLock()
+	defer router.rwmutex.RUnlock()
+
+	if r.MixedMsg != nil {
+		if cmd, _, ok := ParseCommand(r.MixedMsg.Content); ok {
+			if handler = router.handlerMap[cmd]; handler != nil {
+				return
+			}
+		}
+	}
+	if handler = router.defaultHandler; handler != nil {
+		return
+	}
+	return unknownCommandHandler
+}
+
+// CommandRouter 实现了 mp.MessageHandler 接口.
+func (router *CommandRouter) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	router.Route(r).ServeMessage(w, r)
+}
+
+var unknownCommandHandler = mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+	if r.MixedMsg == nil {
+		return
+	}
+	msg := response.NewText(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, "未知命令")
+
+	if err := response.Write(w, r, msg); err != nil {
+		mp.LogInfoln("[WECHAT_COMMAND_ROUTER]", err)
+	}
+})
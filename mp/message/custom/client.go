@@ -77,6 +77,14 @@ func (clt *Client) SendWxCard(msg *WxCard) (err error) {
 	return clt.send(msg)
 }
 
+// 发送客服消息, 菜单, 一般用 MsgMenuBuilder 构造 msg.
+func (clt *Client) SendMsgMenu(msg *MsgMenu) (err error) {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.send(msg)
+}
+
 func (clt *Client) send(msg interface{}) (err error) {
 	var result mp.Error
 
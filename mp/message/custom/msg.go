@@ -18,6 +18,8 @@ const (
 	MsgTypeMusic  = "music"  // 音乐消息
 	MsgTypeNews   = "news"   // 图文消息
 	MsgTypeWxCard = "wxcard" // 卡卷消息
+
+	MsgTypeMsgMenu = "msgmenu" // 菜单消息
 )
 
 type MessageHeader struct {
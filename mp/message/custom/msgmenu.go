@@ -0,0 +1,211 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+const (
+	MsgMenuItemCountLimit = 3 // 点击菜单项的个数限制, 网页菜单和小程序菜单不占用 list, 不计入
+)
+
+// 菜单消息里的菜单项, 用户点击后会收到一条 id 和 content 对应的文本消息(bizmsgmenuid)
+type MsgMenuItem struct {
+	Id      string `json:"id"`
+	Content string `json:"content"`
+}
+
+// 菜单消息
+type MsgMenu struct {
+	MessageHeader
+
+	MsgMenu struct {
+		HeadContent string        `json:"head_content,omitempty"`
+		List        []MsgMenuItem `json:"list"`
+		TailContent string        `json:"tail_content,omitempty"`
+	} `json:"msgmenu"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+const (
+	msgMenuItemTypeClick = iota
+	msgMenuItemTypeView
+	msgMenuItemTypeMiniProgram
+)
+
+type msgMenuBuilderItem struct {
+	typ      int
+	id       string
+	content  string
+	url      string
+	appId    string
+	pagePath string
+}
+
+// MsgMenuBuilder 用来构造菜单消息(MsgMenu), 所有的检查都在 Build 时进行.
+//  NOTE: 微信菜单消息的 list 只支持点击菜单, 点击菜单项必须有 1 到 MsgMenuItemCountLimit 个; 网页菜单和小程序菜单以超链接的形式追加在 tail_content 的前面.
+//
+//  msg, err := custom.NewMsgMenuBuilder(toUser, "").
+//      AddHeader("您对本次服务是否满意呢?").
+//      AddClickItem("101", "满意").
+//      AddClickItem("102", "不满意").
+//      SetTail("欢迎再次光临").
+//      Build()
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  err = Client.SendMsgMenu(msg)
+type MsgMenuBuilder struct {
+	toUser    string
+	kfAccount string
+
+	headContent string
+	tailContent string
+	items       []msgMenuBuilderItem
+}
+
+// 新建菜单消息的 MsgMenuBuilder.
+//  如果不指定客服则 kfAccount 留空.
+func NewMsgMenuBuilder(toUser, kfAccount string) *MsgMenuBuilder {
+	return &MsgMenuBuilder{
+		toUser:    toUser,
+		kfAccount: kfAccount,
+	}
+}
+
+// 设置菜单消息的头部内容, 多次调用会用换行符连接.
+func (builder *MsgMenuBuilder) AddHeader(content string) *MsgMenuBuilder {
+	if builder.headContent == "" {
+		builder.headContent = content
+	} else {
+		builder.headContent += "\n" + content
+	}
+	return builder
+}
+
+// 添加点击菜单项, id 在所有点击菜单项中必须唯一.
+func (builder *MsgMenuBuilder) AddClickItem(id, content string) *MsgMenuBuilder {
+	builder.items = append(builder.items, msgMenuBuilderItem{
+		typ:     msgMenuItemTypeClick,
+		id:      id,
+		content: content,
+	})
+	return builder
+}
+
+// 添加网页菜单项, 用户点击后打开 url.
+func (builder *MsgMenuBuilder) AddViewItem(content, url string) *MsgMenuBuilder {
+	builder.items = append(builder.items, msgMenuBuilderItem{
+		typ:     msgMenuItemTypeView,
+		content: content,
+		url:     url,
+	})
+	return builder
+}
+
+// 添加小程序菜单项, 用户点击后打开小程序 appId 的 pagePath 页面.
+func (builder *MsgMenuBuilder) AddMiniProgramItem(content, appId, pagePath string) *MsgMenuBuilder {
+	builder.items = append(builder.items, msgMenuBuilderItem{
+		typ:      msgMenuItemTypeMiniProgram,
+		content:  content,
+		appId:    appId,
+		pagePath: pagePath,
+	})
+	return builder
+}
+
+// 设置菜单消息的尾部内容.
+func (builder *MsgMenuBuilder) SetTail(content string) *MsgMenuBuilder {
+	builder.tailContent = content
+	return builder
+}
+
+// 检查并构造菜单消息.
+func (builder *MsgMenuBuilder) Build() (msg *MsgMenu, err error) {
+	n := len(builder.items)
+	msg = &MsgMenu{
+		MessageHeader: MessageHeader{
+			ToUser:  builder.toUser,
+			MsgType: MsgTypeMsgMenu,
+		},
+	}
+	msg.MsgMenu.HeadContent = builder.headContent
+	msg.MsgMenu.List = make([]MsgMenuItem, 0, n)
+
+	var links []string
+	ids := make(map[string]bool, n)
+	for _, item := range builder.items {
+		if item.content == "" {
+			err = errors.New("菜单项的 content 不能为空")
+			return nil, err
+		}
+
+		switch item.typ {
+		case msgMenuItemTypeClick:
+			if item.id == "" {
+				err = errors.New("点击菜单项的 id 不能为空")
+				return nil, err
+			}
+			if ids[item.id] {
+				err = fmt.Errorf("点击菜单项的 id 重复: %s", item.id)
+				return nil, err
+			}
+			ids[item.id] = true
+			msg.MsgMenu.List = append(msg.MsgMenu.List, MsgMenuItem{
+				Id:      item.id,
+				Content: item.content,
+			})
+
+		case msgMenuItemTypeView:
+			u, e := url.Parse(item.url)
+			if e != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				err = fmt.Errorf("网页菜单项的 url 不合法: %s", item.url)
+				return nil, err
+			}
+			links = append(links, fmt.Sprintf(`<a href="%s">%s</a>`,
+				html.EscapeString(item.url), html.EscapeString(item.content)))
+
+		case msgMenuItemTypeMiniProgram:
+			if item.appId == "" {
+				err = errors.New("小程序菜单项的 appId 不能为空")
+				return nil, err
+			}
+			links = append(links, fmt.Sprintf(`<a href="http://www.qq.com" data-miniprogram-appid="%s" data-miniprogram-path="%s">%s</a>`,
+				html.EscapeString(item.appId), html.EscapeString(item.pagePath), html.EscapeString(item.content)))
+		}
+	}
+
+	switch clickCount := len(msg.MsgMenu.List); {
+	case clickCount <= 0:
+		err = errors.New("没有有效的点击菜单项")
+		return nil, err
+	case clickCount > MsgMenuItemCountLimit:
+		err = fmt.Errorf("点击菜单项的个数不能超过 %d, 现在为 %d", MsgMenuItemCountLimit, clickCount)
+		return nil, err
+	}
+
+	if len(links) > 0 {
+		if builder.tailContent != "" {
+			links = append(links, builder.tailContent)
+		}
+		msg.MsgMenu.TailContent = strings.Join(links, "\n")
+	} else {
+		msg.MsgMenu.TailContent = builder.tailContent
+	}
+
+	if builder.kfAccount != "" {
+		msg.CustomService = &CustomService{
+			KfAccount: builder.kfAccount,
+		}
+	}
+	return
+}
@@ -0,0 +1,72 @@
+package custom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMsgMenuBuilder(t *testing.T) {
+	msg, err := NewMsgMenuBuilder("o_user", "").
+		AddHeader("您对本次服务是否满意呢?").
+		AddClickItem("101", "满意").
+		AddViewItem("详情", "https://example.com/a?b=1&c=2").
+		AddMiniProgramItem("小程序", "wx123", "pages/index").
+		AddClickItem("102", "一般").
+		AddViewItem("帮助", "http://example.com/help").
+		AddClickItem("103", "不满意").
+		SetTail("欢迎再次光临").
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if n := len(msg.MsgMenu.List); n != 3 {
+		t.Errorf("len(List), have: %d, want: 3", n)
+	}
+	tail := msg.MsgMenu.TailContent
+	if !strings.Contains(tail, `href="https://example.com/a?b=1&amp;c=2"`) ||
+		!strings.Contains(tail, `data-miniprogram-appid="wx123"`) ||
+		!strings.HasSuffix(tail, "\n欢迎再次光临") {
+		t.Errorf("TailContent, have: %q", tail)
+	}
+}
+
+func TestMsgMenuBuilderError(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *MsgMenuBuilder
+	}{
+		{"no items", NewMsgMenuBuilder("o_user", "")},
+		{"no click items", NewMsgMenuBuilder("o_user", "").
+			AddViewItem("详情", "https://example.com").
+			AddMiniProgramItem("小程序", "wx123", "pages/index")},
+		{"too many click items", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "a").
+			AddClickItem("102", "b").
+			AddClickItem("103", "c").
+			AddClickItem("104", "d")},
+		{"duplicate id", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "满意").
+			AddClickItem("101", "不满意")},
+		{"empty id", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("", "满意")},
+		{"empty content", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "")},
+		{"relative url", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "满意").
+			AddViewItem("详情", "/detail")},
+		{"javascript url", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "满意").
+			AddViewItem("详情", "javascript:alert(1)")},
+		{"invalid url", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "满意").
+			AddViewItem("详情", "http://%zz")},
+		{"empty appId", NewMsgMenuBuilder("o_user", "").
+			AddClickItem("101", "满意").
+			AddMiniProgramItem("小程序", "", "pages/index")},
+	}
+	for _, tt := range tests {
+		if msg, err := tt.builder.Build(); err == nil {
+			t.Errorf("%s: Build succeeded with %+v, want error", tt.name, msg)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package custom
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 计划在 SendAt 发送的客服消息
+type ScheduledReply struct {
+	Id     string          `json:"id"`
+	SendAt time.Time       `json:"send_at"`
+	Msg    json.RawMessage `json:"msg"` // 序列化后的客服消息, 比如 NewText 的结果
+}
+
+// 保存计划发送的客服消息, 用于进程重启以后恢复.
+type ScheduleStore interface {
+	Save(reply *ScheduledReply) error
+	Delete(id string) error
+	Load() ([]*ScheduledReply, error)
+}
+
+// ReplyQueue 在指定的时间之后通过客服消息接口发送消息.
+//
+//  微信服务器要求 5 秒内回复, 处理比较慢的时候可以先回复 "正在处理", 然后用 ReplyQueue 在结果准备好之后发送:
+//  queue := custom.NewReplyQueue(clt, store)
+//  if err := queue.Restore(); err != nil { ... } // 恢复重启之前没有发送的消息
+//  id, err := queue.Schedule(custom.NewText(openId, "处理完成", ""), 10*time.Second)
+//
+//  计划发送的消息先保存到 ScheduleStore, 发送之后(不管成功还是失败)删除.
+//  NOTE: 客服消息只能在用户最近一次给公众号发消息(或者触发指定的事件)之后的 48 小时内发送, 超过 48 小时会失败.
+type ReplyQueue struct {
+	clt   *Client
+	store ScheduleStore
+
+	mutex  sync.Mutex
+	timers map[string]*time.Timer // map[id]*time.Timer
+}
+
+// 创建一个新的 ReplyQueue.
+func NewReplyQueue(clt *Client, store ScheduleStore) *ReplyQueue {
+	if clt == nil {
+		panic("nil Client")
+	}
+	if store == nil {
+		panic("nil ScheduleStore")
+	}
+	return &ReplyQueue{
+		clt:    clt,
+		store:  store,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// 计划在 delay 之后发送客服消息 msg, 返回的 id 可以用于 Cancel.
+//  msg 是 NewText, NewNews 这样构造的客服消息, 会先序列化保存到 ScheduleStore.
+func (queue *ReplyQueue) Schedule(msg interface{}, delay time.Duration) (id string, err error) {
+	if msg == nil {
+		err = errors.New("nil msg")
+		return
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	if id, err = newReplyId(); err != nil {
+		return
+	}
+
+	reply := &ScheduledReply{
+		Id:     id,
+		SendAt: time.Now().Add(delay),
+		Msg:    data,
+	}
+	if err = queue.store.Save(reply); err != nil {
+		return
+	}
+	queue.start(reply)
+	return
+}
+
+// 取消计划发送的消息, 已经发送或者不存在时不做任何事.
+func (queue *ReplyQueue) Cancel(id string) error {
+	queue.mutex.Lock()
+	if timer := queue.timers[id]; timer != nil {
+		timer.Stop()
+		delete(queue.timers, id)
+	}
+	queue.mutex.Unlock()
+	return queue.store.Delete(id)
+}
+
+// 从 ScheduleStore 恢复计划发送的消息, 一般在进程启动的时候调用一次; 已经过了发送时间的立即发送.
+func (queue *ReplyQueue) Restore() error {
+	replies, err := queue.store.Load()
+	if err != nil {
+		return err
+	}
+	for _, reply := range replies {
+		queue.mutex.Lock()
+		_, ok := queue.timers[reply.Id]
+		queue.mutex.Unlock()
+		if !ok {
+			queue.start(reply)
+		}
+	}
+	return nil
+}
+
+// 停止所有的定时器, 不删除 ScheduleStore 里的消息, 下次 Restore 的时候会继续发送.
+func (queue *ReplyQueue) Stop() {
+	queue.mutex.Lock()
+	for id, timer := range queue.timers {
+		timer.Stop()
+		delete(queue.timers, id)
+	}
+	queue.mutex.Unlock()
+}
+
+func (queue *ReplyQueue) start(reply *ScheduledReply) {
+	delay := reply.SendAt.Sub(time.Now())
+	if delay < 0 {
+		delay = 0
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.timers[reply.Id] = time.AfterFunc(delay, func() {
+		queue.mutex.Lock()
+		_, ok := queue.timers[reply.Id]
+		delete(queue.timers, reply.Id)
+		queue.mutex.Unlock()
+		if !ok { // 已经被 Cancel 或者 Stop
+			return
+		}
+
+		if err := queue.clt.send(reply.Msg); err != nil {
+			mp.LogInfoln("[WECHAT_REPLY_QUEUE]", reply.Id, err)
+		}
+		if err := queue.store.Delete(reply.Id); err != nil {
+			mp.LogInfoln("[WECHAT_REPLY_QUEUE]", reply.Id, err)
+		}
+	})
+}
+
+func newReplyId() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+var _ ScheduleStore = (*MemoryScheduleStore)(nil)
+
+// 基于内存的 ScheduleStore, 只用于开发和测试, 进程重启以后数据会丢失.
+type MemoryScheduleStore struct {
+	mutex   sync.Mutex
+	replies map[string]*ScheduledReply
+}
+
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{
+		replies: make(map[string]*ScheduledReply),
+	}
+}
+
+func (store *MemoryScheduleStore) Save(reply *ScheduledReply) error {
+	if reply == nil {
+		return errors.New("nil ScheduledReply")
+	}
+	store.mutex.Lock()
+	store.replies[reply.Id] = reply
+	store.mutex.Unlock()
+	return nil
+}
+
+func (store *MemoryScheduleStore) Delete(id string) error {
+	store.mutex.Lock()
+	delete(store.replies, id)
+	store.mutex.Unlock()
+	return nil
+}
+
+func (store *MemoryScheduleStore) Load() ([]*ScheduledReply, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	replies := make([]*ScheduledReply, 0, len(store.replies))
+	for _, reply := range store.replies {
+		replies = append(replies, reply)
+	}
+	return replies, nil
+}
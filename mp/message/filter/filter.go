@@ -0,0 +1,100 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package filter
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 过滤条件, 返回 true 表示 r 满足条件.
+//  NOTE: r.MixedMsg 为 nil 的时候下面提供的过滤条件都返回 false.
+type Predicate func(r *mp.Request) bool
+
+// 返回 requests 中满足所有 predicates 的 *mp.Request, 保持原来的顺序.
+//  predicates 为空时返回 requests 中所有非 nil 的元素.
+func Filter(requests []*mp.Request, predicates ...Predicate) []*mp.Request {
+	var ret []*mp.Request
+NextRequest:
+	for _, r := range requests {
+		if r == nil {
+			continue
+		}
+		for _, predicate := range predicates {
+			if !predicate(r) {
+				continue NextRequest
+			}
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+// 按照 key(r) 对 requests 分组, 每一组保持原来的顺序.
+//  比如按照用户分组:
+//  GroupBy(requests, func(r *mp.Request) string { return r.MixedMsg.FromUserName })
+func GroupBy(requests []*mp.Request, key func(r *mp.Request) string) map[string][]*mp.Request {
+	ret := make(map[string][]*mp.Request)
+	for _, r := range requests {
+		if r == nil {
+			continue
+		}
+		k := key(r)
+		ret[k] = append(ret[k], r)
+	}
+	return ret
+}
+
+// 消息类型为 msgType, 比如 request.MsgTypeText, request.MsgTypeEvent.
+func ByMsgType(msgType string) Predicate {
+	return func(r *mp.Request) bool {
+		return r.MixedMsg != nil && r.MixedMsg.MsgType == msgType
+	}
+}
+
+// 事件类型为 event, 比如 request.EventTypeSubscribe.
+func ByEvent(event string) Predicate {
+	return func(r *mp.Request) bool {
+		return r.MixedMsg != nil && r.MixedMsg.Event == event
+	}
+}
+
+// 消息的发送者(FromUserName)为 openId.
+func ByFromUser(openId string) Predicate {
+	return func(r *mp.Request) bool {
+		return r.MixedMsg != nil && r.MixedMsg.FromUserName == openId
+	}
+}
+
+// 消息的创建时间(CreateTime)在 [start, end) 之间.
+//  start 或者 end 为零值时表示不限制.
+func ByTimeRange(start, end time.Time) Predicate {
+	return func(r *mp.Request) bool {
+		if r.MixedMsg == nil {
+			return false
+		}
+		t := time.Unix(r.MixedMsg.CreateTime, 0)
+		if !start.IsZero() && t.Before(start) {
+			return false
+		}
+		if !end.IsZero() && !t.Before(end) {
+			return false
+		}
+		return true
+	}
+}
+
+// 消息内容(Content)匹配 pattern.
+func ByContent(pattern *regexp.Regexp) Predicate {
+	if pattern == nil {
+		panic("nil pattern")
+	}
+	return func(r *mp.Request) bool {
+		return r.MixedMsg != nil && pattern.MatchString(r.MixedMsg.Content)
+	}
+}
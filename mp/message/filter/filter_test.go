@@ -0,0 +1,82 @@
+package filter
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func newRequest(from, msgType, content string, createTime int64) *mp.Request {
+	return &mp.Request{
+		MixedMsg: &mp.MixedMessage{
+			MessageHeader: mp.MessageHeader{FromUserName: from, MsgType: msgType, CreateTime: createTime},
+			Content:       content,
+		},
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	r := newRequest("o_user", "text", "hello world", 1000)
+	event := &mp.Request{MixedMsg: &mp.MixedMessage{Event: "subscribe"}}
+	empty := &mp.Request{} // MixedMsg 为 nil
+
+	at := func(sec int64) time.Time { return time.Unix(sec, 0) }
+	tests := []struct {
+		name      string
+		predicate Predicate
+		r         *mp.Request
+		want      bool
+	}{
+		{"ByMsgType", ByMsgType("text"), r, true},
+		{"ByMsgType mismatch", ByMsgType("image"), r, false},
+		{"ByMsgType nil MixedMsg", ByMsgType(""), empty, false},
+		{"ByEvent", ByEvent("subscribe"), event, true},
+		{"ByEvent nil MixedMsg", ByEvent(""), empty, false},
+		{"ByFromUser", ByFromUser("o_user"), r, true},
+		{"ByFromUser mismatch", ByFromUser("o_other"), r, false},
+		{"ByFromUser nil MixedMsg", ByFromUser(""), empty, false},
+		{"ByContent", ByContent(regexp.MustCompile(`^hello`)), r, true},
+		{"ByContent mismatch", ByContent(regexp.MustCompile(`^world`)), r, false},
+		{"ByContent nil MixedMsg", ByContent(regexp.MustCompile(``)), empty, false},
+
+		{"ByTimeRange inside", ByTimeRange(at(999), at(1001)), r, true},
+		{"ByTimeRange start inclusive", ByTimeRange(at(1000), at(1001)), r, true},
+		{"ByTimeRange before start", ByTimeRange(at(1001), at(2000)), r, false},
+		{"ByTimeRange end exclusive", ByTimeRange(at(999), at(1000)), r, false},
+		{"ByTimeRange zero start", ByTimeRange(time.Time{}, at(1001)), r, true},
+		{"ByTimeRange zero end", ByTimeRange(at(1000), time.Time{}), r, true},
+		{"ByTimeRange zero start and end", ByTimeRange(time.Time{}, time.Time{}), r, true},
+		{"ByTimeRange nil MixedMsg", ByTimeRange(time.Time{}, time.Time{}), empty, false},
+	}
+	for _, tt := range tests {
+		if have := tt.predicate(tt.r); have != tt.want {
+			t.Errorf("%s: have: %v, want: %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestFilterAndGroupBy(t *testing.T) {
+	a1 := newRequest("a", "text", "1", 1)
+	b1 := newRequest("b", "image", "", 2)
+	a2 := newRequest("a", "text", "2", 3)
+	requests := []*mp.Request{a1, nil, b1, a2}
+
+	if have, want := Filter(requests), []*mp.Request{a1, b1, a2}; !reflect.DeepEqual(have, want) {
+		t.Errorf("Filter without predicates, have: %v, want: %v", have, want)
+	}
+	if have, want := Filter(requests, ByFromUser("a"), ByMsgType("text")), []*mp.Request{a1, a2}; !reflect.DeepEqual(have, want) {
+		t.Errorf("Filter, have: %v, want: %v", have, want)
+	}
+	if have := Filter(requests, ByFromUser("c")); len(have) != 0 {
+		t.Errorf("Filter without matches, have: %v", have)
+	}
+
+	groups := GroupBy(requests, func(r *mp.Request) string { return r.MixedMsg.FromUserName })
+	want := map[string][]*mp.Request{"a": {a1, a2}, "b": {b1}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupBy, have: %v, want: %v", groups, want)
+	}
+}
@@ -0,0 +1,123 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 把被动回复的消息渲染成字符串.
+//  msg 一般是 response 包里面的消息, 比如 *response.Text, *response.News.
+type Formatter interface {
+	Format(msg interface{}) string
+}
+
+type FormatterFunc func(msg interface{}) string
+
+func (fn FormatterFunc) Format(msg interface{}) string {
+	return fn(msg)
+}
+
+var (
+	MarkdownFormatter  Formatter = FormatterFunc(FormatAsMarkdown)
+	PlainTextFormatter Formatter = FormatterFunc(FormatAsPlainText)
+)
+
+// 把被动回复的消息渲染成 Markdown.
+//  文本消息渲染成引用, 图文消息渲染成标题和链接的列表, 不支持的消息类型渲染成 [未知消息].
+//  消息里的文字(文本消息的内容, 标题, 描述)都会转义 Markdown 的特殊字符, 渲染出来和微信里看到的一样.
+func FormatAsMarkdown(msg interface{}) string {
+	return format(msg, true)
+}
+
+// 把被动回复的消息渲染成纯文本, 和 FormatAsMarkdown 的内容一样但是没有 Markdown 标记.
+func FormatAsPlainText(msg interface{}) string {
+	return format(msg, false)
+}
+
+func format(msg interface{}, markdown bool) string {
+	escape := func(s string) string {
+		if markdown {
+			return escapeMarkdown(s)
+		}
+		return s
+	}
+
+	switch msg := msg.(type) {
+	case *response.Text:
+		if !markdown {
+			return msg.Content
+		}
+		return "> " + strings.Replace(escapeMarkdown(msg.Content), "\n", "\n> ", -1)
+
+	case *response.Image:
+		return "[图片]"
+
+	case *response.Voice:
+		return "[语音]"
+
+	case *response.Video:
+		return joinNonEmpty("[视频]", escape(msg.Video.Title), escape(msg.Video.Description))
+
+	case *response.Music:
+		title := msg.Music.Title
+		if title == "" {
+			title = "音乐"
+		}
+		if msg.Music.MusicURL == "" {
+			return "[音乐] " + escape(title)
+		}
+		if markdown {
+			return fmt.Sprintf("[音乐] [%s](%s)", escapeMarkdown(title), msg.Music.MusicURL)
+		}
+		return fmt.Sprintf("[音乐] %s %s", title, msg.Music.MusicURL)
+
+	case *response.News:
+		var buf bytes.Buffer
+		for i, article := range msg.Articles {
+			if i > 0 {
+				buf.WriteByte('\n')
+			}
+			switch {
+			case markdown && article.URL != "":
+				fmt.Fprintf(&buf, "- [%s](%s)", escapeMarkdown(article.Title), article.URL)
+			case markdown:
+				fmt.Fprintf(&buf, "- %s", escapeMarkdown(article.Title))
+			default:
+				buf.WriteString(joinNonEmpty(article.Title, article.URL))
+			}
+		}
+		return buf.String()
+
+	case *response.TransferToCustomerService:
+		return "[转发到多客服]"
+
+	default:
+		return "[未知消息]"
+	}
+}
+
+func joinNonEmpty(ss ...string) string {
+	ret := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if s != "" {
+			ret = append(ret, s)
+		}
+	}
+	return strings.Join(ret, " ")
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`",
+)
+
+func escapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
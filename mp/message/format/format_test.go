@@ -0,0 +1,100 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       interface{}
+		markdown  string
+		plainText string
+	}{
+		{
+			"text",
+			response.NewText("to", "from", 0, "第一行 *重点*\n[链接]"),
+			"> 第一行 \\*重点\\*\n> \\[链接\\]",
+			"第一行 *重点*\n[链接]",
+		},
+		{
+			"image",
+			response.NewImage("to", "from", 0, "media_id"),
+			"[图片]",
+			"[图片]",
+		},
+		{
+			"video",
+			response.NewVideo("to", "from", 0, "media_id", "标题_1", ""),
+			"[视频] 标题\\_1",
+			"[视频] 标题_1",
+		},
+		{
+			"music without url",
+			response.NewMusic("to", "from", 0, "thumb", "", "", "", ""),
+			"[音乐] 音乐",
+			"[音乐] 音乐",
+		},
+		{
+			"music",
+			response.NewMusic("to", "from", 0, "thumb", "http://example.com/a.mp3", "", "[歌]", ""),
+			"[音乐] [\\[歌\\]](http://example.com/a.mp3)",
+			"[音乐] [歌] http://example.com/a.mp3",
+		},
+		{
+			"news",
+			response.NewNews("to", "from", 0, []response.Article{
+				{Title: "a_b", URL: "http://example.com/1"},
+				{Title: "`c`"},
+			}),
+			"- [a\\_b](http://example.com/1)\n- \\`c\\`",
+			"a_b http://example.com/1\n`c`",
+		},
+		{
+			"transfer",
+			response.NewTransferToCustomerService("to", "from", 0, ""),
+			"[转发到多客服]",
+			"[转发到多客服]",
+		},
+		{
+			"unknown",
+			"text",
+			"[未知消息]",
+			"[未知消息]",
+		},
+		{
+			"nil",
+			nil,
+			"[未知消息]",
+			"[未知消息]",
+		},
+	}
+	for _, tt := range tests {
+		if have := MarkdownFormatter.Format(tt.msg); have != tt.markdown {
+			t.Errorf("%s: FormatAsMarkdown, have: %q, want: %q", tt.name, have, tt.markdown)
+		}
+		if have := PlainTextFormatter.Format(tt.msg); have != tt.plainText {
+			t.Errorf("%s: FormatAsPlainText, have: %q, want: %q", tt.name, have, tt.plainText)
+		}
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"普通标题", "普通标题"},
+		{`a\b`, `a\\b`},
+		{"[a](b)", `\[a\](b)`},
+		{"*a* _b_ `c`", "\\*a\\* \\_b\\_ \\`c\\`"},
+	}
+	for _, tt := range tests {
+		if have := escapeMarkdown(tt.s); have != tt.want {
+			t.Errorf("escapeMarkdown(%q), have: %q, want: %q", tt.s, have, tt.want)
+		}
+	}
+}
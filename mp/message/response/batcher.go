@@ -0,0 +1,92 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// MessageBatcher 的最大文章数, 微信目前最多显示 8 条图文.
+const MessageBatcherMaxItems = 8
+
+// 收集多个处理逻辑各自产生的 Article, 最后合并成一条图文消息回复, 比如:
+//  batcher := response.NewMessageBatcher(3, 4*time.Second)
+//  batcher.Add(weatherArticle)
+//  batcher.Add(stockArticle)
+//  err := batcher.Flush(w, r)
+//
+//  NOTE: MessageBatcher 不是并发安全的, 一般在一个 mp.MessageHandler 里创建和使用, 如果多个 goroutine
+//  并发查询, 需要调用者自己收集结果之后再在同一个 goroutine 里 Add.
+type MessageBatcher struct {
+	maxItems int
+	deadline time.Time // 零值表示没有限制
+	articles []Article
+	flushed  bool
+}
+
+// 创建一个新的 MessageBatcher.
+//  maxItems: 最多收集的文章数, 超过 MessageBatcherMaxItems 时使用 MessageBatcherMaxItems
+//  timeout:  从创建开始超过 timeout 之后 Add 不再接收文章, 保证能在微信要求的 5 秒内回复; 0 表示不限制
+func NewMessageBatcher(maxItems int, timeout time.Duration) *MessageBatcher {
+	if maxItems <= 0 {
+		panic("maxItems must be positive")
+	}
+	if maxItems > MessageBatcherMaxItems {
+		maxItems = MessageBatcherMaxItems
+	}
+	batcher := &MessageBatcher{
+		maxItems: maxItems,
+		articles: make([]Article, 0, maxItems),
+	}
+	if timeout > 0 {
+		batcher.deadline = time.Now().Add(timeout)
+	}
+	return batcher
+}
+
+// 添加一篇文章, 已经满了或者超时返回 false.
+//  Flush 之后添加的文章直接丢弃, 返回 false.
+func (batcher *MessageBatcher) Add(item *Article) bool {
+	if item == nil || batcher.flushed {
+		return false
+	}
+	if len(batcher.articles) >= batcher.maxItems {
+		return false
+	}
+	if !batcher.deadline.IsZero() && time.Now().After(batcher.deadline) {
+		return false
+	}
+	batcher.articles = append(batcher.articles, *item)
+	return true
+}
+
+// 已经添加的文章数.
+func (batcher *MessageBatcher) Len() int {
+	return len(batcher.articles)
+}
+
+// 把添加的文章合并成一条图文消息回复给 r 的发送者, 只能调用一次.
+//  没有添加文章时什么都不回复, 返回 nil.
+func (batcher *MessageBatcher) Flush(w http.ResponseWriter, r *mp.Request) (err error) {
+	if batcher.flushed {
+		return errors.New("MessageBatcher already flushed")
+	}
+	batcher.flushed = true
+
+	if len(batcher.articles) == 0 {
+		return
+	}
+	if r.MixedMsg == nil {
+		return errors.New("nil MixedMsg")
+	}
+
+	news := NewNews(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, r.MixedMsg.CreateTime, batcher.articles)
+	return Write(w, r, news)
+}
@@ -0,0 +1,80 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 下面的 NewXxxReply 根据收到的消息 r 构造被动回复消息:
+//  ToUserName 为 r.MixedMsg.FromUserName, FromUserName 为 r.MixedMsg.ToUserName, CreateTime 为当前时间.
+//
+//  mux.MessageHandleFunc(request.MsgTypeText, func(w http.ResponseWriter, r *mp.Request) {
+//      response.Write(w, r, response.NewTextReply(r, "你好"))
+//  })
+//
+//  NOTE: 调用者保证 r.MixedMsg 不为 nil, 在 MessageHandler 里总是满足的.
+
+func NewTextReply(r *mp.Request, content string) *Text {
+	return NewText(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), content)
+}
+
+func NewImageReply(r *mp.Request, mediaId string) *Image {
+	return NewImage(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), mediaId)
+}
+
+func NewVoiceReply(r *mp.Request, mediaId string) *Voice {
+	return NewVoice(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), mediaId)
+}
+
+func NewVideoReply(r *mp.Request, mediaId, title, description string) *Video {
+	return NewVideo(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), mediaId, title, description)
+}
+
+func NewMusicReply(r *mp.Request, thumbMediaId, musicURL, HQMusicURL, title, description string) *Music {
+	return NewMusic(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(),
+		thumbMediaId, musicURL, HQMusicURL, title, description)
+}
+
+// 文章个数必须在 1 和 NewsArticleCountLimit 之间, 否则返回错误.
+func NewNewsReply(r *mp.Request, articles []Article) (news *News, err error) {
+	if len(articles) == 0 {
+		err = errors.New("empty articles")
+		return
+	}
+	if len(articles) > NewsArticleCountLimit {
+		err = fmt.Errorf("图文消息的文章个数不能超过 %d, 现在为 %d", NewsArticleCountLimit, len(articles))
+		return
+	}
+	news = NewNews(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), articles)
+	return
+}
+
+// kfAccount 为空时不指定客服.
+func NewTransferToCustomerServiceReply(r *mp.Request, kfAccount string) *TransferToCustomerService {
+	return NewTransferToCustomerService(r.MixedMsg.FromUserName, r.MixedMsg.ToUserName, time.Now().Unix(), kfAccount)
+}
+
+// 回复消息 msg 给 r 的发送者, 根据 r.EncryptType 选择 mp.WriteAESResponse 或者 mp.WriteRawResponse,
+// 写入之前设置 Content-Type 为 application/xml.
+func Write(w http.ResponseWriter, r *mp.Request, msg interface{}) error {
+	if w == nil {
+		return errors.New("nil http.ResponseWriter")
+	}
+	if r == nil {
+		return errors.New("nil Request")
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	if r.EncryptType == "aes" {
+		return mp.WriteAESResponse(w, r, msg)
+	}
+	return mp.WriteRawResponse(w, r, msg)
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestNewTextReply(t *testing.T) {
+	r := &mp.Request{
+		MixedMsg: &mp.MixedMessage{
+			MessageHeader: mp.MessageHeader{
+				ToUserName:   "gh_123456789abc",
+				FromUserName: "o_user",
+			},
+		},
+	}
+
+	text := NewTextReply(r, "hello")
+	if text.ToUserName != "o_user" || text.FromUserName != "gh_123456789abc" || text.CreateTime == 0 {
+		t.Errorf("unexpected header: %+v", text.MessageHeader)
+	}
+
+	w := httptest.NewRecorder()
+	if err := Write(w, r, text); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Errorf("Content-Type, have: %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), "<Content>hello</Content>") {
+		t.Errorf("body, have: %s", w.Body.String())
+	}
+
+	if _, err := NewNewsReply(r, make([]Article, NewsArticleCountLimit+1)); err == nil {
+		t.Error("NewNewsReply with too many articles, want error")
+	}
+}
@@ -0,0 +1,99 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 被动回复消息字段的最大字节数(UTF-8 编码)
+const (
+	TextContentMaxBytes        = 2048 // 文本消息的内容
+	ArticleTitleMaxBytes       = 64   // 图文消息的标题
+	ArticleDescriptionMaxBytes = 512  // 图文消息的描述
+)
+
+// 截断字段时追加的后缀, 3 个字节
+const truncateSuffix = "…"
+
+// 超过长度限制的字段
+type SizeViolation struct {
+	Field       string // 字段名, 比如 Content, Articles[0].Title
+	MaxBytes    int    // 最大字节数
+	ActualBytes int    // 实际字节数
+}
+
+var _ mp.ResponseGuard = (*MessageSizeGuard)(nil)
+
+// MessageSizeGuard 检查被动回复消息的字段长度, 超过微信限制的时候微信客户端收到的消息会被截断或者直接不显示.
+//  mp.SetResponseGuard(response.NewMessageSizeGuard())
+//
+//  设置以后 mp.WriteRawResponse, mp.WriteAESResponse 在回复之前把超过限制的字段截断到最大字节数(以 … 结尾),
+//  同时记录日志, 而不是拒绝回复; 截断只在 UTF-8 字符的边界, 不会截断半个汉字.
+//  目前检查 *Text 的 Content, *News 里每个 Article 的 Title, Description, 其他类型的消息不做检查.
+type MessageSizeGuard struct{}
+
+func NewMessageSizeGuard() *MessageSizeGuard {
+	return &MessageSizeGuard{}
+}
+
+// 检查 msg 超过长度限制的字段, 没有的时候返回 nil; msg 不会被修改.
+func (guard *MessageSizeGuard) Validate(msg interface{}) []SizeViolation {
+	return guard.check(msg, false)
+}
+
+// 实现 mp.ResponseGuard, 截断 msg 里超过长度限制的字段.
+func (guard *MessageSizeGuard) GuardResponse(r *mp.Request, msg interface{}) {
+	for _, v := range guard.check(msg, true) {
+		mp.LogInfoln("[WECHAT_MESSAGE_SIZE_GUARD] truncate", v.Field, "from", v.ActualBytes, "to", v.MaxBytes, "bytes")
+	}
+}
+
+func (guard *MessageSizeGuard) check(msg interface{}, truncate bool) (violations []SizeViolation) {
+	field := func(name string, s *string, maxBytes int) {
+		if len(*s) <= maxBytes {
+			return
+		}
+		violations = append(violations, SizeViolation{
+			Field:       name,
+			MaxBytes:    maxBytes,
+			ActualBytes: len(*s),
+		})
+		if truncate {
+			*s = truncateString(*s, maxBytes)
+		}
+	}
+
+	switch msg := msg.(type) {
+	case *Text:
+		field("Content", &msg.Content, TextContentMaxBytes)
+	case *News:
+		for i := range msg.Articles {
+			prefix := "Articles[" + strconv.Itoa(i) + "]."
+			field(prefix+"Title", &msg.Articles[i].Title, ArticleTitleMaxBytes)
+			field(prefix+"Description", &msg.Articles[i].Description, ArticleDescriptionMaxBytes)
+		}
+	}
+	return
+}
+
+// 截断 s, 使结果(包括后缀 …)不超过 maxBytes 个字节, 只在 UTF-8 字符的边界截断.
+func truncateString(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	n := maxBytes - len(truncateSuffix)
+	if n < 0 {
+		n = 0
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + truncateSuffix
+}
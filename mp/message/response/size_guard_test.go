@@ -0,0 +1,40 @@
+package response
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMessageSizeGuardTruncate(t *testing.T) {
+	content := strings.Repeat("微信", 1000) // 6000 字节
+	text := NewText("to", "from", 0, content)
+	news := NewNews("to", "from", 0, []Article{
+		{Title: "short"},
+		{Title: strings.Repeat("标题", 20)}, // 120 字节
+	})
+
+	guard := NewMessageSizeGuard()
+	if v := guard.Validate(text); len(v) != 1 || v[0].Field != "Content" || v[0].ActualBytes != 6000 {
+		t.Errorf("Validate(text), have: %+v", v)
+	}
+	if text.Content != content {
+		t.Error("Validate must not modify the message")
+	}
+
+	guard.GuardResponse(nil, text)
+	if len(text.Content) > TextContentMaxBytes || !utf8.ValidString(text.Content) || !strings.HasSuffix(text.Content, "…") {
+		t.Errorf("truncated text, len: %d, valid utf8: %v", len(text.Content), utf8.ValidString(text.Content))
+	}
+
+	guard.GuardResponse(nil, news)
+	if title := news.Articles[1].Title; len(title) > ArticleTitleMaxBytes || !utf8.ValidString(title) {
+		t.Errorf("truncated title, have: %q", title)
+	}
+	if news.Articles[0].Title != "short" {
+		t.Errorf("short title changed, have: %q", news.Articles[0].Title)
+	}
+	if v := guard.Validate(news); v != nil {
+		t.Errorf("Validate after truncate, have: %+v", v)
+	}
+}
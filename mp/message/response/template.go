@@ -0,0 +1,67 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+)
+
+// 用 text/template 渲染被动回复的内容, 比如:
+//  tmpl, err := response.NewTemplate("order", "你好, {{.NickName}}! 你的订单 {{.OrderId}} 已经准备好了.")
+//  content, err := tmpl.RenderText(data)
+//  text := response.NewText(to, from, timestamp, content)
+//
+// Template 可以并发使用.
+type Template struct {
+	tmpl *template.Template
+}
+
+// 模板里可以使用的函数:
+//  json: 把参数编码成 JSON, 用于 RenderArticle 的模板
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func NewTemplate(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// 渲染文本, 一般用于 NewText 的 content.
+func (t *Template) RenderText(data interface{}) (text string, err error) {
+	var buf bytes.Buffer
+	if err = t.tmpl.Execute(&buf, data); err != nil {
+		return
+	}
+	text = buf.String()
+	return
+}
+
+// 渲染图文消息的文章, 要求模板渲染的结果是 Article 的 JSON, 比如:
+//  {"Title": {{json .Title}}, "Url": {{json .URL}}}
+//
+//  NOTE: 模板里的字符串需要用 json 函数转义成 JSON 字符串.
+func (t *Template) RenderArticle(data interface{}) (article *Article, err error) {
+	var buf bytes.Buffer
+	if err = t.tmpl.Execute(&buf, data); err != nil {
+		return
+	}
+
+	article = new(Article)
+	if err = json.Unmarshal(buf.Bytes(), article); err != nil {
+		article = nil
+		return
+	}
+	return
+}
@@ -0,0 +1,113 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package subscribe
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ErrCodeUserNotSubscribed = 43101 // 用户拒绝接受消息, 如果用户之前曾经订阅过, 则表示用户取消了订阅关系
+)
+
+// 用户没有订阅该模板(或者取消了订阅)时 Send 返回这个错误, 可以用 err == ErrUserNotSubscribed 判断.
+var ErrUserNotSubscribed = &mp.Error{
+	ErrCode: ErrCodeUserNotSubscribed,
+	ErrMsg:  "user refuse to accept the msg",
+}
+
+type Client mp.Client
+
+func NewClient(srv mp.AccessTokenServer, clt *http.Client) *Client {
+	return (*Client)(mp.NewClient(srv, clt))
+}
+
+// 发送订阅通知.
+//  用户没有订阅该模板时返回 ErrUserNotSubscribed.
+func (clt *Client) Send(msg *SubscribeMsg) (err error) {
+	if msg == nil {
+		return errors.New("nil SubscribeMsg")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/subscribe/bizsend?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, msg, &result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		return
+	case ErrCodeUserNotSubscribed:
+		err = ErrUserNotSubscribed
+		return
+	default:
+		err = &result
+		return
+	}
+}
+
+// 获取帐号下的订阅通知模板列表.
+func (clt *Client) GetTemplateList() (TemplateList []SubscribeTemplate, err error) {
+	var result struct {
+		mp.Error
+		TemplateList []SubscribeTemplate `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/gettemplate?access_token="
+	if err = ((*mp.Client)(clt)).GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	TemplateList = result.TemplateList
+	return
+}
+
+// 从公共模板库选用模板到帐号下, 并返回添加至帐号下的模板id.
+//  tid:       模板标题 id, 可通过公共模板库的接口获取;
+//  kidList:   开发者自行组合好的模板关键词列表, 关键词顺序可以自由搭配, 最多支持 5 个, 最少 2 个关键词组合;
+//  sceneDesc: 服务场景描述, 15 个字以内.
+func (clt *Client) AddTemplate(tid string, kidList []int, sceneDesc string) (templateId string, err error) {
+	if n := len(kidList); n < 2 || n > 5 {
+		err = errors.New("kidList 的个数必须在 2 到 5 之间")
+		return
+	}
+
+	var request = struct {
+		Tid       string `json:"tid"`
+		KidList   []int  `json:"kidList"`
+		SceneDesc string `json:"sceneDesc,omitempty"`
+	}{
+		Tid:       tid,
+		KidList:   kidList,
+		SceneDesc: sceneDesc,
+	}
+
+	var result struct {
+		mp.Error
+		PriTmplId string `json:"priTmplId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/addtemplate?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templateId = result.PriTmplId
+	return
+}
@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package subscribe
+
+const (
+	MiniprogramStateDeveloper = "developer" // 开发版
+	MiniprogramStateTrial     = "trial"     // 体验版
+	MiniprogramStateFormal    = "formal"    // 正式版
+)
+
+// 订阅通知的模板数据项
+type DataItem struct {
+	Value string `json:"value"`
+}
+
+// 跳转小程序的信息
+type Miniprogram struct {
+	AppId    string `json:"appid"`    // 所需跳转到的小程序appid, 该小程序必须与发模板消息的公众号是绑定关联关系
+	PagePath string `json:"pagepath"` // 所需跳转到小程序的具体页面路径, 支持带参数, 示例 index?foo=bar
+}
+
+type SubscribeMsg struct {
+	ToUser           string              `json:"touser"`                      // 必须, 接收者(用户)的 openid
+	TemplateId       string              `json:"template_id"`                 // 必须, 所需下发的订阅模板id
+	Page             string              `json:"page,omitempty"`              // 可选, 跳转网页时填写
+	Miniprogram      *Miniprogram        `json:"miniprogram,omitempty"`       // 可选, 跳转小程序时填写
+	MiniprogramState string              `json:"miniprogram_state,omitempty"` // 可选, 跳转小程序类型: developer, trial, formal; 默认为正式版
+	Data             map[string]DataItem `json:"data"`                        // 必须, 模板内容, 格式形如 { "key1": { "value": any }, "key2": { "value": any } }
+}
+
+// 订阅通知的模板
+type SubscribeTemplate struct {
+	PriTmplId string `json:"priTmplId"` // 添加至帐号下的模板id, 发送订阅通知时所需
+	Title     string `json:"title"`     // 模版标题
+	Content   string `json:"content"`   // 模版内容
+	Example   string `json:"example"`   // 模板内容示例
+	Type      int    `json:"type"`      // 模版类型, 2 为一次性订阅, 3 为长期订阅
+}
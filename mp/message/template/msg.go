@@ -10,10 +10,29 @@ import (
 )
 
 type TemplateMessage struct {
-	ToUser     string `json:"touser"`             // 必须, 接受者OpenID
-	TemplateId string `json:"template_id"`        // 必须, 模版ID
-	URL        string `json:"url,omitempty"`      // 可选, 用户点击后跳转的URL, 该URL必须处于开发者在公众平台网站中设置的域中
-	TopColor   string `json:"topcolor,omitempty"` // 可选, 整个消息的颜色, 可以不设置
+	ToUser      string       `json:"touser"`                // 必须, 接受者OpenID
+	TemplateId  string       `json:"template_id"`           // 必须, 模版ID
+	URL         string       `json:"url,omitempty"`         // 可选, 用户点击后跳转的URL, 该URL必须处于开发者在公众平台网站中设置的域中
+	MiniProgram *MiniProgram `json:"miniprogram,omitempty"` // 可选, 跳转小程序, 同时设置 URL 时优先跳转小程序
+	TopColor    string       `json:"topcolor,omitempty"`    // 可选, 整个消息的颜色, 可以不设置
 
 	RawJSONData json.RawMessage `json:"data"` // 必须, JSON 格式的 []byte, 满足特定的模板需求
 }
+
+// 模板消息跳转的小程序
+type MiniProgram struct {
+	AppId    string `json:"appid"`              // 必须, 小程序的 appid, 该小程序必须已经和公众号关联
+	PagePath string `json:"pagepath,omitempty"` // 可选, 小程序的页面路径, 支持带参数, 比如 index?foo=bar
+}
+
+// 模板里一个参数的值
+type DataItem struct {
+	Value string `json:"value"`
+	Color string `json:"color,omitempty"` // 可选, 比如 #173177
+}
+
+// 用 data 设置 RawJSONData, key 是模板里的参数名, 比如 {{first.DATA}} 的 first.
+func (msg *TemplateMessage) SetData(data map[string]DataItem) (err error) {
+	msg.RawJSONData, err = json.Marshal(data)
+	return
+}
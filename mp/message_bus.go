@@ -0,0 +1,126 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// MessageBus 把消息(事件)按照 MsgType 分发给订阅者, 用于把接收消息和处理消息分开:
+//  bus := NewMessageBus(100)
+//  texts := bus.Subscribe(request.MsgTypeText)
+//  go func() {
+//      for r := range texts {
+//          ... // 通过客服消息接口回复
+//      }
+//  }()
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, bus.AsHandler())
+//
+//  NOTE: 订阅者处理太慢, channel 满了的时候消息直接丢弃, 不会阻塞 Publish, 丢弃的个数可以通过 Stats 查看.
+type MessageBus struct {
+	bufSize int
+
+	rwmutex     sync.RWMutex
+	subscribers map[string][]*subscriber // map[MsgType][]*subscriber
+}
+
+type subscriber struct {
+	msgType string
+	ch      chan *Request
+	dropped uint64 // 原子操作
+}
+
+// 创建一个新的 MessageBus, bufSize 为每个订阅者 channel 的容量.
+func NewMessageBus(bufSize int) *MessageBus {
+	if bufSize <= 0 {
+		panic("bufSize must be positive")
+	}
+	return &MessageBus{
+		bufSize:     bufSize,
+		subscribers: make(map[string][]*subscriber),
+	}
+}
+
+// 订阅 msgType 类型的消息, 事件的 msgType 为 "event".
+//  同一个 msgType 可以有多个订阅者, 每个订阅者都会收到所有的消息.
+func (bus *MessageBus) Subscribe(msgType string) <-chan *Request {
+	if msgType == "" {
+		panic("empty msgType")
+	}
+	sub := &subscriber{
+		msgType: msgType,
+		ch:      make(chan *Request, bus.bufSize),
+	}
+
+	bus.rwmutex.Lock()
+	bus.subscribers[msgType] = append(bus.subscribers[msgType], sub)
+	bus.rwmutex.Unlock()
+	return sub.ch
+}
+
+// 把 r 分发给订阅了 r.MixedMsg.MsgType 的订阅者, 不会阻塞.
+//  r 会直接放到 channel 里, 如果在 MessageHandler 里调用, 需要先 r.Clone().
+func (bus *MessageBus) Publish(r *Request) {
+	if r == nil || r.MixedMsg == nil {
+		return
+	}
+
+	bus.rwmutex.RLock()
+	subs := bus.subscribers[r.MixedMsg.MsgType]
+	bus.rwmutex.RUnlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.ch <- r:
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+		}
+	}
+}
+
+// 返回一个把消息的拷贝 Publish 到 bus, 然后立即回复 "success" 的 MessageHandler.
+func (bus *MessageBus) AsHandler() MessageHandler {
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		bus.Publish(r.Clone())
+		io.WriteString(w, "success")
+	})
+}
+
+// 一个订阅者的统计信息
+type SubscriberStats struct {
+	MsgType  string
+	Depth    int    // channel 里还没有处理的消息个数
+	Capacity int    // channel 的容量
+	Dropped  uint64 // channel 满了而丢弃的消息个数
+}
+
+// MessageBus 的统计信息
+type BusStats struct {
+	Subscribers     []SubscriberStats
+	DroppedMessages uint64 // 所有订阅者丢弃的消息个数的和
+}
+
+func (bus *MessageBus) Stats() (stats BusStats) {
+	bus.rwmutex.RLock()
+	defer bus.rwmutex.RUnlock()
+
+	for _, subs := range bus.subscribers {
+		for _, sub := range subs {
+			dropped := atomic.LoadUint64(&sub.dropped)
+			stats.Subscribers = append(stats.Subscribers, SubscriberStats{
+				MsgType:  sub.msgType,
+				Depth:    len(sub.ch),
+				Capacity: cap(sub.ch),
+				Dropped:  dropped,
+			})
+			stats.DroppedMessages += dropped
+		}
+	}
+	return
+}
@@ -0,0 +1,74 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// 消息的一个字段的差异
+type FieldDiff struct {
+	Field    string // 字段名, 比如 Content, ScanCodeInfo
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// 比较两个消息(事件)的所有导出字段, 返回不同的字段, 按照 MixedMessage 里字段定义的顺序排列.
+//  嵌入的 MessageHeader 按照 ToUserName 这样的字段名比较, 其他结构体字段(比如 ScanCodeInfo)整体比较;
+//  nil 当作空的 MixedMessage 处理.
+//
+//  一般用于排查重试的消息为什么和第一次处理的结果不一样, 或者在测试里输出有差异的字段.
+func Diff(a, b *MixedMessage) []FieldDiff {
+	if a == nil {
+		a = &MixedMessage{}
+	}
+	if b == nil {
+		b = &MixedMessage{}
+	}
+	var diffs []FieldDiff
+	diffStruct(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), &diffs)
+	return diffs
+}
+
+func diffStruct(a, b reflect.Value, diffs *[]FieldDiff) {
+	typ := a.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" || field.Name == "XMLName" { // 未导出的字段
+			continue
+		}
+		va, vb := a.Field(i), b.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			diffStruct(va, vb, diffs)
+			continue
+		}
+		if !reflect.DeepEqual(va.Interface(), vb.Interface()) {
+			*diffs = append(*diffs, FieldDiff{
+				Field:    field.Name,
+				OldValue: va.Interface(),
+				NewValue: vb.Interface(),
+			})
+		}
+	}
+}
+
+// 把 Diff 的结果格式化成一行, 比如: MsgId: 123 → 456, Content: 'hello' → 'Hello'
+func DiffSummary(diffs []FieldDiff) string {
+	parts := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		parts = append(parts, diff.Field+": "+formatDiffValue(diff.OldValue)+" → "+formatDiffValue(diff.NewValue))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatDiffValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return "'" + s + "'"
+	}
+	return fmt.Sprintf("%+v", v)
+}
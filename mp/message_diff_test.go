@@ -0,0 +1,36 @@
+package mp
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := &MixedMessage{MsgId: 123, Content: "hello"}
+	a.FromUserName = "oUser"
+	a.MsgType = "text"
+	b := &MixedMessage{MsgId: 456, Content: "Hello"}
+	b.FromUserName = "oUser"
+	b.MsgType = "text"
+	b.ScanCodeInfo.ScanType = "qrcode"
+
+	diffs := Diff(a, b)
+	if len(diffs) != 3 {
+		t.Fatalf("Diff returned %d diffs, want 3: %+v", len(diffs), diffs)
+	}
+	want := "MsgId: 123 → 456, Content: 'hello' → 'Hello', ScanCodeInfo: {ScanType: ScanResult:} → {ScanType:qrcode ScanResult:}"
+	if summary := DiffSummary(diffs); summary != want {
+		t.Errorf("DiffSummary:\nhave: %s\nwant: %s", summary, want)
+	}
+
+	if diffs := Diff(a, a); len(diffs) != 0 {
+		t.Errorf("Diff(a, a) = %+v, want no diffs", diffs)
+	}
+
+	diffs = Diff(nil, a)
+	if summary := DiffSummary(diffs); summary != "FromUserName: '' → 'oUser', MsgType: '' → 'text', MsgId: 0 → 123, Content: '' → 'hello'" {
+		t.Errorf("Diff(nil, a) summary: %s", summary)
+	}
+	if diffs := Diff(nil, nil); len(diffs) != 0 {
+		t.Errorf("Diff(nil, nil) = %+v, want no diffs", diffs)
+	}
+}
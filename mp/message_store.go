@@ -0,0 +1,151 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 消息(事件)的存储, 一般用于客服系统保存用户的历史消息.
+type MessageStore interface {
+	// 保存一个消息(事件).
+	Save(msg *MixedMessage) error
+
+	// 查询满足 query 的消息(事件), 按照保存的先后顺序排列.
+	//  total 为满足条件的消息总数, 不受 query.Limit 和 query.Offset 的影响.
+	Query(query *StoreQuery) (msgs []*MixedMessage, total int, err error)
+}
+
+// MessageStore 的查询条件, 字段为零值表示不限制.
+type StoreQuery struct {
+	FromUser  string    // 消息的发送者 openid
+	MsgType   string    // 消息类型
+	StartTime time.Time // 消息创建时间 >= StartTime
+	EndTime   time.Time // 消息创建时间 < EndTime
+	Limit     int       // 最多返回的消息个数
+	Offset    int       // 跳过前面 Offset 个满足条件的消息
+}
+
+func (query *StoreQuery) match(msg *MixedMessage) bool {
+	if query.FromUser != "" && msg.FromUserName != query.FromUser {
+		return false
+	}
+	if query.MsgType != "" && msg.MsgType != query.MsgType {
+		return false
+	}
+	if !query.StartTime.IsZero() && msg.CreateTime < query.StartTime.Unix() {
+		return false
+	}
+	if !query.EndTime.IsZero() && msg.CreateTime >= query.EndTime.Unix() {
+		return false
+	}
+	return true
+}
+
+// 在 msgs 里查询满足 query 的消息, 返回分页后的结果和总数.
+func queryMessages(msgs []*MixedMessage, query *StoreQuery) (ret []*MixedMessage, total int) {
+	if query == nil {
+		query = &StoreQuery{}
+	}
+	for _, msg := range msgs {
+		if !query.match(msg) {
+			continue
+		}
+		total++
+		if total <= query.Offset {
+			continue
+		}
+		if query.Limit > 0 && len(ret) >= query.Limit {
+			continue
+		}
+		ret = append(ret, msg)
+	}
+	return
+}
+
+var _ MessageHandler = (*MessageStoreHandler)(nil)
+
+// MessageStoreHandler 先把消息(事件)保存到 MessageStore, 然后交给后端的 MessageHandler 处理.
+//  保存失败只记录日志, 不影响消息的处理.
+//
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, NewMessageStoreHandler(store, messageServeMux))
+type MessageStoreHandler struct {
+	store   MessageStore
+	handler MessageHandler
+}
+
+func NewMessageStoreHandler(store MessageStore, handler MessageHandler) *MessageStoreHandler {
+	if store == nil {
+		panic("nil MessageStore")
+	}
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return &MessageStoreHandler{
+		store:   store,
+		handler: handler,
+	}
+}
+
+// MessageStoreHandler 实现了 MessageHandler 接口.
+func (h *MessageStoreHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	if err := h.store.Save(r.MixedMsg); err != nil {
+		LogInfoln("[WECHAT_MESSAGE_STORE]", err)
+	}
+	h.handler.ServeMessage(w, r)
+}
+
+var _ MessageStore = (*MemoryMessageStore)(nil)
+
+// MemoryMessageStore 是保存在内存里的 MessageStore, 最多保存 maxSize 个消息,
+// 满了以后新的消息会覆盖最老的消息.
+type MemoryMessageStore struct {
+	mutex sync.Mutex
+	buf   []*MixedMessage // 环形缓冲区
+	start int             // 最老的消息在 buf 里的位置
+	size  int             // 当前保存的消息个数
+}
+
+func NewMemoryMessageStore(maxSize int) *MemoryMessageStore {
+	if maxSize <= 0 {
+		panic("maxSize must be positive")
+	}
+	return &MemoryMessageStore{
+		buf: make([]*MixedMessage, maxSize),
+	}
+}
+
+func (store *MemoryMessageStore) Save(msg *MixedMessage) error {
+	if msg == nil {
+		return errors.New("nil MixedMessage")
+	}
+
+	store.mutex.Lock()
+	if store.size < len(store.buf) {
+		store.buf[(store.start+store.size)%len(store.buf)] = msg
+		store.size++
+	} else {
+		store.buf[store.start] = msg
+		store.start = (store.start + 1) % len(store.buf)
+	}
+	store.mutex.Unlock()
+	return nil
+}
+
+func (store *MemoryMessageStore) Query(query *StoreQuery) (msgs []*MixedMessage, total int, err error) {
+	store.mutex.Lock()
+	all := make([]*MixedMessage, store.size)
+	for i := 0; i < store.size; i++ {
+		all[i] = store.buf[(store.start+i)%len(store.buf)]
+	}
+	store.mutex.Unlock()
+
+	msgs, total = queryMessages(all, query)
+	return
+}
@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+var _ MessageStore = (*FileMessageStore)(nil)
+
+// FileMessageStore 把消息(事件)以 JSON 格式追加到文件里, 每行一个消息.
+//  Query 每次都会读取整个文件, 适合消息量不大或者离线分析的场景.
+type FileMessageStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func NewFileMessageStore(path string) *FileMessageStore {
+	if path == "" {
+		panic("empty path")
+	}
+	return &FileMessageStore{
+		path: path,
+	}
+}
+
+func (store *FileMessageStore) Save(msg *MixedMessage) (err error) {
+	if msg == nil {
+		return errors.New("nil MixedMessage")
+	}
+
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	file, err := os.OpenFile(store.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	if _, err = file.Write(line); err != nil {
+		file.Close()
+		return
+	}
+	return file.Close()
+}
+
+func (store *FileMessageStore) Query(query *StoreQuery) (msgs []*MixedMessage, total int, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	file, err := os.Open(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer file.Close()
+
+	var all []*MixedMessage
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		msg := new(MixedMessage)
+		if err = json.Unmarshal(line, msg); err != nil {
+			return
+		}
+		all = append(all, msg)
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	msgs, total = queryMessages(all, query)
+	return
+}
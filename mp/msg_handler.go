@@ -6,6 +6,7 @@
 package mp
 
 import (
+	"encoding/xml"
 	"net/http"
 	"net/url"
 )
@@ -159,3 +160,32 @@ type AroundBeacon struct {
 	Minor    int     `xml:"Minor"    json:"Minor"`
 	Distance float64 `xml:"Distance" json:"Distance"`
 }
+
+// 返回 r 的深拷贝, 用于在 MessageHandler 返回后继续使用这个消息(比如异步处理).
+//  NOTE: HttpRequest 在 MessageHandler 返回后就不再有效, 所以拷贝的 HttpRequest 为 nil.
+func (r *Request) Clone() *Request {
+	r2 := *r
+	r2.HttpRequest = nil
+
+	if r.QueryValues != nil {
+		r2.QueryValues = make(url.Values, len(r.QueryValues))
+		for k, vs := range r.QueryValues {
+			r2.QueryValues[k] = append([]string(nil), vs...)
+		}
+	}
+	if r.RawMsgXML != nil {
+		r2.RawMsgXML = append([]byte(nil), r.RawMsgXML...)
+	}
+	if r.Random != nil {
+		r2.Random = append([]byte(nil), r.Random...)
+	}
+	if r.MixedMsg != nil {
+		// MixedMessage 里面有 slice, 重新解析 RawMsgXML 得到完全独立的拷贝
+		msg := new(MixedMessage)
+		if err := xml.Unmarshal(r2.RawMsgXML, msg); err != nil {
+			*msg = *r.MixedMsg
+		}
+		r2.MixedMsg = msg
+	}
+	return &r2
+}
@@ -0,0 +1,31 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+// MessageHandler 的装饰器, 用于在一个地方给所有的 MessageHandler 加上日志, recover, 权限检查这样的通用逻辑.
+//  NewLocalTimeHandler 这样的 NewXxx(handler, ...) 可以用闭包转换成 MessageHandlerDecorator:
+//  func(handler MessageHandler) MessageHandler { return NewLocalTimeHandler(handler, loc) }
+//
+//  NOTE: 装饰器返回以后不能再使用 http.ResponseWriter, 如果需要在另外的 goroutine 里使用 *Request,
+//  请复制需要的字段(比如 *r.MixedMsg, r.RawMsgXML), 不要保存 r.HttpRequest 和 w.
+type MessageHandlerDecorator func(handler MessageHandler) MessageHandler
+
+// 把 decorators 组合成一个装饰器, 左边的在外层: ChainDecorators(a, b, c)(h) 等价于 a(b(c(h))).
+func ChainDecorators(decorators ...MessageHandlerDecorator) MessageHandlerDecorator {
+	for _, decorator := range decorators {
+		if decorator == nil {
+			panic("nil MessageHandlerDecorator")
+		}
+	}
+	decorators = append([]MessageHandlerDecorator(nil), decorators...)
+
+	return func(handler MessageHandler) MessageHandler {
+		for i := len(decorators) - 1; i >= 0; i-- {
+			handler = decorators[i](handler)
+		}
+		return handler
+	}
+}
@@ -0,0 +1,37 @@
+package mp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMessageServeMuxUse(t *testing.T) {
+	var trace []string
+	decorator := func(name string) MessageHandlerDecorator {
+		return func(handler MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+				trace = append(trace, name)
+				handler.ServeMessage(w, r)
+			})
+		}
+	}
+	handler := func(http.ResponseWriter, *Request) { trace = append(trace, "handler") }
+
+	mux := NewMessageServeMux()
+	mux.MessageHandleFunc("image", handler) // Use 之前注册的不受影响
+	mux.Use(ChainDecorators(decorator("a"), decorator("b")))
+	mux.Use(decorator("c"))
+	mux.MessageHandleFunc("text", handler)
+
+	mux.ServeMessage(nil, &Request{MixedMsg: &MixedMessage{MessageHeader: MessageHeader{MsgType: "text"}}})
+	if have, want := strings.Join(trace, ","), "a,b,c,handler"; have != want {
+		t.Errorf("text trace, have: %s, want: %s", have, want)
+	}
+
+	trace = nil
+	mux.ServeMessage(nil, &Request{MixedMsg: &MixedMessage{MessageHeader: MessageHeader{MsgType: "image"}}})
+	if have, want := strings.Join(trace, ","), "handler"; have != want {
+		t.Errorf("image trace, have: %s, want: %s", have, want)
+	}
+}
@@ -19,6 +19,7 @@ type MessageServeMux struct {
 	eventHandlerMap       map[string]MessageHandler // map[EventType]MessageHandler
 	defaultMessageHandler MessageHandler
 	defaultEventHandler   MessageHandler
+	decorators            []MessageHandlerDecorator // Use 添加的装饰器
 }
 
 func NewMessageServeMux() *MessageServeMux {
@@ -41,7 +42,7 @@ func (mux *MessageServeMux) MessageHandle(msgType string, handler MessageHandler
 	if mux.messageHandlerMap == nil {
 		mux.messageHandlerMap = make(map[string]MessageHandler)
 	}
-	mux.messageHandlerMap[msgType] = handler
+	mux.messageHandlerMap[msgType] = mux.decorate(handler)
 	mux.rwmutex.Unlock()
 }
 
@@ -57,7 +58,7 @@ func (mux *MessageServeMux) DefaultMessageHandle(handler MessageHandler) {
 	}
 
 	mux.rwmutex.Lock()
-	mux.defaultMessageHandler = handler
+	mux.defaultMessageHandler = mux.decorate(handler)
 	mux.rwmutex.Unlock()
 }
 
@@ -79,7 +80,7 @@ func (mux *MessageServeMux) EventHandle(eventType string, handler MessageHandler
 	if mux.eventHandlerMap == nil {
 		mux.eventHandlerMap = make(map[string]MessageHandler)
 	}
-	mux.eventHandlerMap[eventType] = handler
+	mux.eventHandlerMap[eventType] = mux.decorate(handler)
 	mux.rwmutex.Unlock()
 }
 
@@ -95,7 +96,7 @@ func (mux *MessageServeMux) DefaultEventHandle(handler MessageHandler) {
 	}
 
 	mux.rwmutex.Lock()
-	mux.defaultEventHandler = handler
+	mux.defaultEventHandler = mux.decorate(handler)
 	mux.rwmutex.Unlock()
 }
 
@@ -104,6 +105,30 @@ func (mux *MessageServeMux) DefaultEventHandleFunc(handler func(http.ResponseWri
 	mux.DefaultEventHandle(MessageHandlerFunc(handler))
 }
 
+// 添加装饰器, 之后注册的 MessageHandler 都会被 decorators 包装, 之前注册的不受影响, 所以一般在注册之前调用:
+//  mux.Use(logDecorator, recoverDecorator) // 注册的 handler 变成 logDecorator(recoverDecorator(handler))
+//
+//  多次调用 Use 时, 先添加的装饰器在外层.
+func (mux *MessageServeMux) Use(decorators ...MessageHandlerDecorator) {
+	for _, decorator := range decorators {
+		if decorator == nil {
+			panic("nil MessageHandlerDecorator")
+		}
+	}
+
+	mux.rwmutex.Lock()
+	mux.decorators = append(mux.decorators, decorators...)
+	mux.rwmutex.Unlock()
+}
+
+// 用 mux.decorators 包装 handler, 调用者需要持有 mux.rwmutex.
+func (mux *MessageServeMux) decorate(handler MessageHandler) MessageHandler {
+	if len(mux.decorators) == 0 {
+		return handler
+	}
+	return ChainDecorators(mux.decorators...)(handler)
+}
+
 // 获取 msgType 对应的 MessageHandler, 如果没有找到返回 nil.
 func (mux *MessageServeMux) getMessageHandler(msgType string) (handler MessageHandler) {
 	if msgType == "" {
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// 微信服务器推送的普通消息类型, 和 message/request 里的 MsgTypeXXX 一致.
+var knownMsgTypes = map[string]bool{
+	"text":       true,
+	"image":      true,
+	"voice":      true,
+	"video":      true,
+	"shortvideo": true,
+	"location":   true,
+	"link":       true,
+}
+
+// 和 MessageHandle 一样注册特定类型消息的 MessageHandler, 但是 msgType 不是已知的消息类型(比如拼写错误)
+// 或者已经注册过的时候 panic, 用于在程序启动的时候发现错误:
+//  mux.MustMessageHandle(request.MsgTypeText, textHandler)
+func (mux *MessageServeMux) MustMessageHandle(msgType string, handler MessageHandler) {
+	if !knownMsgTypes[msgType] {
+		panic(fmt.Sprintf("MustMessageHandle: unknown msgType %q", msgType))
+	}
+	if handler == nil {
+		panic(fmt.Sprintf("MustMessageHandle: nil MessageHandler for msgType %q", msgType))
+	}
+
+	mux.rwmutex.RLock()
+	_, ok := mux.messageHandlerMap[msgType]
+	mux.rwmutex.RUnlock()
+	if ok {
+		panic(fmt.Sprintf("MustMessageHandle: MessageHandler for msgType %q already registered", msgType))
+	}
+	mux.MessageHandle(msgType, handler)
+}
+
+// 和 MessageHandleFunc 一样注册特定类型消息的 MessageHandler, 参考 MustMessageHandle.
+func (mux *MessageServeMux) MustMessageHandleFunc(msgType string, handler func(http.ResponseWriter, *Request)) {
+	if handler == nil {
+		panic(fmt.Sprintf("MustMessageHandleFunc: nil handler for msgType %q", msgType))
+	}
+	mux.MustMessageHandle(msgType, MessageHandlerFunc(handler))
+}
+
+// 检查 msgTypes 和 eventTypes 是否都注册了对应的 MessageHandler, 返回没有注册(只能用默认 MessageHandler 处理)的类型:
+//  if err := mux.CheckAllHandled([]string{request.MsgTypeText}, []string{request.EventTypeSubscribe}); err != nil {
+//      panic(err)
+//  }
+func (mux *MessageServeMux) CheckAllHandled(msgTypes, eventTypes []string) error {
+	mux.rwmutex.RLock()
+	defer mux.rwmutex.RUnlock()
+
+	var missing []string
+	for _, msgType := range msgTypes {
+		if mux.messageHandlerMap[msgType] == nil {
+			missing = append(missing, "msgType "+msgType)
+		}
+	}
+	for _, eventType := range eventTypes {
+		if mux.eventHandlerMap[eventType] == nil {
+			missing = append(missing, "eventType "+eventType)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return errors.New("MessageHandler not registered for: " + strings.Join(missing, ", "))
+}
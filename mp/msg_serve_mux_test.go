@@ -0,0 +1,39 @@
+package mp
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// go test -race 检查运行中重新注册 MessageHandler 没有数据竞争.
+func TestMessageServeMuxConcurrentHandle(t *testing.T) {
+	mux := NewMessageServeMux()
+	handler := func(http.ResponseWriter, *Request) {}
+	mux.MessageHandleFunc("text", handler)
+
+	text := &Request{MixedMsg: &MixedMessage{MessageHeader: MessageHeader{MsgType: "text"}}}
+	event := &Request{MixedMsg: &MixedMessage{MessageHeader: MessageHeader{MsgType: "event"}, Event: "subscribe"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mux.MessageHandleFunc("text", handler)
+				mux.EventHandleFunc("subscribe", handler)
+				mux.DefaultMessageHandleFunc(handler)
+				mux.DefaultEventHandleFunc(handler)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mux.ServeMessage(nil, text)
+				mux.ServeMessage(nil, event)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,108 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// 文本里识别出来的实体
+type Entity struct {
+	Type  string // 实体的类型, 比如 city, date
+	Value string // 实体的值, 比如 北京
+	Start int    // 在文本里的开始位置(按照 rune 计算)
+	End   int    // 在文本里的结束位置(不包含)
+}
+
+// 文本分析的结果
+type Annotation struct {
+	Intent     string  // 意图, 比如 query_weather
+	Confidence float64 // 意图的置信度, 0 到 1
+	Entities   []Entity
+}
+
+// 文本分析接口, 比如对接一个 NLP 服务.
+//  实现需要在 ctx 被取消的时候尽快返回.
+type TextAnalyzer interface {
+	Analyze(ctx context.Context, text string) (*Annotation, error)
+}
+
+type annotationContextKey struct{}
+
+// 获取 NLPHandler 保存的文本分析结果, 没有分析(不是文本消息, 分析失败或者超时)时返回 nil.
+func AnnotationFromRequest(r *Request) *Annotation {
+	if r == nil || r.HttpRequest == nil {
+		return nil
+	}
+	annotation, _ := r.HttpRequest.Context().Value(annotationContextKey{}).(*Annotation)
+	return annotation
+}
+
+// 返回一个先分析文本消息, 再调用 handler 的 MessageHandler, handler 里可以用 AnnotationFromRequest 获取分析结果:
+//  mux.MessageHandle(request.MsgTypeText, NewNLPHandler(textHandler, analyzer, 2*time.Second))
+//
+//  timeout: 分析的超时时间, 超时或者分析失败的时候不保存分析结果, 直接调用 handler; <= 0 时只受 r.HttpRequest 的 Context 限制
+//
+//  NOTE: 分析结果保存在 r.HttpRequest 的 Context 里, r.HttpRequest 为 nil 的时候不做分析, 直接调用 handler;
+//  非文本消息也直接调用 handler.
+func NewNLPHandler(handler MessageHandler, analyzer TextAnalyzer, timeout time.Duration) MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if analyzer == nil {
+		panic("nil TextAnalyzer")
+	}
+	return MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		if r.HttpRequest == nil || r.MixedMsg == nil || r.MixedMsg.MsgType != "text" {
+			handler.ServeMessage(w, r)
+			return
+		}
+
+		parent := r.HttpRequest.Context()
+		annotation := analyze(parent, analyzer, r.MixedMsg.Content, timeout)
+		if annotation == nil {
+			handler.ServeMessage(w, r)
+			return
+		}
+
+		req := *r
+		req.HttpRequest = r.HttpRequest.WithContext(context.WithValue(parent, annotationContextKey{}, annotation))
+		handler.ServeMessage(w, &req)
+	})
+}
+
+// 调用 analyzer 分析 text, 超时的时候不等待 analyzer 返回.
+func analyze(parent context.Context, analyzer TextAnalyzer, text string, timeout time.Duration) *Annotation {
+	ctx, cancel := parent, context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	}
+	defer cancel()
+
+	type result struct {
+		annotation *Annotation
+		err        error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		annotation, err := analyzer.Analyze(ctx, text)
+		ch <- result{annotation, err}
+	}()
+
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			LogInfoln("[WECHAT_NLP_HANDLER]", res.err)
+			return nil
+		}
+		return res.annotation
+	case <-ctx.Done():
+		LogInfoln("[WECHAT_NLP_HANDLER]", ctx.Err())
+		return nil
+	}
+}
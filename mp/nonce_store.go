@@ -0,0 +1,101 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 回调请求的 nonce + timestamp 存储, 用于防止重放攻击.
+type NonceStore interface {
+	// 如果 nonce + timestamp 已经出现过, 或者 timestamp 超出允许的误差, 返回 true;
+	// 否则记录 nonce + timestamp 并返回 false.
+	//  NOTE: 检查和记录必须是原子的, 否则同时到达的两个相同请求可能都返回 false.
+	SeenOrMark(nonce, timestamp string) (bool, error)
+}
+
+var ErrReplayedRequest = errors.New("replayed request or timestamp out of tolerance")
+
+// Server 实现了 NonceStore() NonceStore 方法并且返回值不为 nil 时, ServeHTTP 在验证签名成功之后
+// 调用 SeenOrMark, 返回 true 或者出错时交给 ErrorHandler 处理; DefaultServer 通过 SetNonceStore 设置.
+func checkNonce(srv Server, nonce, timestamp string) error {
+	x, ok := srv.(interface {
+		NonceStore() NonceStore
+	})
+	if !ok {
+		return nil
+	}
+	store := x.NonceStore()
+	if store == nil {
+		return nil
+	}
+	seen, err := store.SeenOrMark(nonce, timestamp)
+	if err != nil {
+		return err
+	}
+	if seen {
+		return ErrReplayedRequest
+	}
+	return nil
+}
+
+var _ NonceStore = (*MemoryNonceStore)(nil)
+
+// 基于内存的 NonceStore, 只适合单进程部署.
+//  timestamp 和当前时间相差超过 tolerance 的请求认为是重放, 记录也在 tolerance 之后清除.
+type MemoryNonceStore struct {
+	tolerance time.Duration
+
+	mutex     sync.Mutex
+	nonces    map[string]time.Time // map[nonce:timestamp]expiresAt
+	lastPrune time.Time
+}
+
+// 创建一个新的 MemoryNonceStore, 微信文档建议 tolerance 为 5 分钟.
+func NewMemoryNonceStore(tolerance time.Duration) *MemoryNonceStore {
+	if tolerance <= 0 {
+		panic("tolerance must be positive")
+	}
+	return &MemoryNonceStore{
+		tolerance: tolerance,
+		nonces:    make(map[string]time.Time),
+	}
+}
+
+func (store *MemoryNonceStore) SeenOrMark(nonce, timestamp string) (bool, error) {
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false, errors.New("can not parse timestamp to int64: " + timestamp)
+	}
+	now := time.Now()
+	if d := now.Sub(time.Unix(t, 0)); d > store.tolerance || d < -store.tolerance {
+		return true, nil
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	// 每隔 tolerance 清除一次失效的记录
+	if now.Sub(store.lastPrune) >= store.tolerance {
+		for k, expiresAt := range store.nonces {
+			if !now.Before(expiresAt) {
+				delete(store.nonces, k)
+			}
+		}
+		store.lastPrune = now
+	}
+
+	key := nonce + ":" + timestamp
+	if expiresAt, ok := store.nonces[key]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+	// timestamp 超出 tolerance 之后直接返回 true, 所以记录保存 2 倍的 tolerance 就足够了
+	store.nonces[key] = now.Add(2 * store.tolerance)
+	return false, nil
+}
@@ -0,0 +1,81 @@
+package mp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	store := NewMemoryNonceStore(5 * time.Minute)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	if seen, err := store.SeenOrMark("nonce1", now); err != nil || seen {
+		t.Fatalf("first SeenOrMark, have: %v, %v, want: false, nil", seen, err)
+	}
+	if seen, _ := store.SeenOrMark("nonce1", now); !seen {
+		t.Error("second SeenOrMark, have: false, want: true")
+	}
+	if seen, _ := store.SeenOrMark("nonce2", now); seen {
+		t.Error("SeenOrMark for another nonce, have: true, want: false")
+	}
+
+	old := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	if seen, _ := store.SeenOrMark("nonce3", old); !seen {
+		t.Error("SeenOrMark for an old timestamp, have: false, want: true")
+	}
+	if _, err := store.SeenOrMark("nonce4", "not a number"); err == nil {
+		t.Error("SeenOrMark for an invalid timestamp, want error")
+	}
+}
+
+func TestServeHTTPConcurrentReplay(t *testing.T) {
+	var served, rejected int32
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		atomic.AddInt32(&served, 1)
+	})
+	errHandler := ErrorHandlerFunc(func(_ http.ResponseWriter, _ *http.Request, err error) {
+		if err == ErrReplayedRequest {
+			atomic.AddInt32(&rejected, 1)
+			return
+		}
+		t.Error(err)
+	})
+	srv := NewDefaultServer("", "token", "", nil, handler)
+	srv.SetNonceStore(NewMemoryNonceStore(5 * time.Minute))
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	queryValues := url.Values{
+		"signature": {util.Sign("token", timestamp, "nonce")},
+		"timestamp": {timestamp},
+		"nonce":     {"nonce"},
+	}
+	body := []byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><MsgType><![CDATA[text]]></MsgType></xml>`)
+
+	const n = 50
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+			<-start
+			ServeHTTP(httptest.NewRecorder(), r, queryValues, srv, errHandler)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if served != 1 || rejected != n-1 {
+		t.Errorf("served: %d, rejected: %d, want: 1, %d", served, rejected, n-1)
+	}
+}
@@ -0,0 +1,9 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 图像识别(OCR)接口, 识别身份证, 银行卡, 行驶证和车牌号.
+//
+//  img_url 必须是微信服务器可以访问的公网地址, 每个接口都有调用次数的限制.
+package ocr
@@ -0,0 +1,150 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package ocr
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 图片的类型
+const (
+	ModePhoto = "photo" // 拍照模式
+	ModeScan  = "scan"  // 扫描模式
+)
+
+// 身份证的正反面
+const (
+	IDCardTypeFront = "Front" // 正面
+	IDCardTypeBack  = "Back"  // 反面
+)
+
+// 身份证, 正面只有 Name, Id, Addr, Gender, Nationality; 反面只有 ValidDate.
+type IDCard struct {
+	Type        string `json:"type"`        // IDCardTypeFront, IDCardTypeBack
+	Name        string `json:"name"`        // 姓名
+	Id          string `json:"id"`          // 身份证号
+	Addr        string `json:"addr"`        // 住址
+	Gender      string `json:"gender"`      // 性别
+	Nationality string `json:"nationality"` // 民族
+	ValidDate   string `json:"valid_date"`  // 有效期, 比如 20070105-20270105
+}
+
+// 银行卡
+type BankCard struct {
+	Number string `json:"number"` // 银行卡号
+}
+
+// 车牌
+type PlateNumber struct {
+	Number string `json:"number"` // 车牌号
+}
+
+// 行驶证
+type VehicleLicense struct {
+	PlateNum      string `json:"plate_num"`      // 车牌号码
+	VehicleType   string `json:"vehicle_type"`   // 车辆类型
+	Owner         string `json:"owner"`          // 所有人
+	Addr          string `json:"addr"`           // 住址
+	UseCharacter  string `json:"use_character"`  // 使用性质
+	Model         string `json:"model"`          // 品牌型号
+	Vin           string `json:"vin"`            // 车辆识别代号
+	EngineNum     string `json:"engine_num"`     // 发动机号码
+	RegisterDate  string `json:"register_date"`  // 注册日期
+	IssueDate     string `json:"issue_date"`     // 发证日期
+	PlateNumB     string `json:"plate_num_b"`    // 副页的车牌号码
+	Record        string `json:"record"`         // 副页的档案编号
+	PassengersNum string `json:"passengers_num"` // 核定载人数
+	TotalQuality  string `json:"total_quality"`  // 总质量
+}
+
+// 识别身份证, mode 为 ModePhoto 或者 ModeScan; 正反面都可以识别, 根据返回的 Type 区分.
+func (clt *Client) IDCard(mode, imgURL string) (card *IDCard, err error) {
+	var result struct {
+		mp.Error
+		IDCard
+	}
+	if err = clt.ocr("idcard", mode, imgURL, &result); err != nil {
+		return
+	}
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	card = &result.IDCard
+	return
+}
+
+// 识别银行卡, mode 为 ModePhoto 或者 ModeScan.
+func (clt *Client) BankCard(mode, imgURL string) (card *BankCard, err error) {
+	var result struct {
+		mp.Error
+		BankCard
+	}
+	if err = clt.ocr("bankcard", mode, imgURL, &result); err != nil {
+		return
+	}
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	card = &result.BankCard
+	return
+}
+
+// 识别行驶证, mode 为 ModePhoto 或者 ModeScan.
+func (clt *Client) VehicleLicense(mode, imgURL string) (license *VehicleLicense, err error) {
+	var result struct {
+		mp.Error
+		VehicleLicense
+	}
+	if err = clt.ocr("driving", mode, imgURL, &result); err != nil {
+		return
+	}
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	license = &result.VehicleLicense
+	return
+}
+
+// 识别车牌号.
+func (clt *Client) PlateNumber(imgURL string) (plate *PlateNumber, err error) {
+	var result struct {
+		mp.Error
+		PlateNumber
+	}
+	if err = clt.ocr("platenum", "", imgURL, &result); err != nil {
+		return
+	}
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	plate = &result.PlateNumber
+	return
+}
+
+func (clt *Client) ocr(api, mode, imgURL string, result interface{}) (err error) {
+	if imgURL == "" {
+		return errors.New("empty imgURL")
+	}
+	switch mode {
+	case "", ModePhoto, ModeScan:
+	default:
+		return errors.New("invalid mode: " + mode)
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cv/ocr/" + api + "?img_url=" + url.QueryEscape(imgURL)
+	if mode != "" {
+		incompleteURL += "&type=" + mode
+	}
+	incompleteURL += "&access_token="
+	return ((*mp.Client)(clt)).PostJSON(incompleteURL, struct{}{}, result)
+}
@@ -0,0 +1,129 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// 多个 Server 的前端, http.Handler 的实现, 通过回调 URL 的 path 来索引对应的 Server.
+//
+//  和 MultiServerFrontend 不同, 回调 URL 不需要额外的查询参数, 每个公众号配置不同的 path 即可:
+//    http://www.xxx.com/wechat/account1
+//    http://www.xxx.com/wechat/account2
+//  frontend.SetServer("/wechat/account1", server1)
+//  frontend.SetServer("/wechat/account2", server2)
+//  http.Handle("/wechat/", frontend)
+//
+//  pattern 的匹配规则和 net/http.ServeMux 一样: 不以 / 结尾的 pattern 只匹配这个 path,
+//  以 / 结尾的 pattern 匹配这个 path 下面所有的 path, 多个 pattern 都匹配时取最长的.
+//
+//  PathServerFrontend 并发安全, 可以在运行中动态增加和删除 Server.
+type PathServerFrontend struct {
+	errHandler  ErrorHandler
+	interceptor Interceptor
+
+	rwmutex   sync.RWMutex
+	serverMap map[string]Server // map[pattern]Server
+	prefixes  []string          // 以 / 结尾的 pattern, 按照长度从长到短排列
+}
+
+// NewPathServerFrontend 创建一个新的 PathServerFrontend.
+//  errHandler:  错误处理 handler, 没有匹配的 Server 时也会调用, 可以为 nil
+//  interceptor: 拦截器, 可以为 nil
+func NewPathServerFrontend(errHandler ErrorHandler, interceptor Interceptor) *PathServerFrontend {
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+	return &PathServerFrontend{
+		errHandler:  errHandler,
+		interceptor: interceptor,
+		serverMap:   make(map[string]Server),
+	}
+}
+
+func (frontend *PathServerFrontend) SetServer(pattern string, server Server) (err error) {
+	if pattern == "" || pattern[0] != '/' {
+		return errors.New("pattern must begin with /")
+	}
+	if server == nil {
+		return errors.New("nil Server")
+	}
+
+	frontend.rwmutex.Lock()
+	if _, ok := frontend.serverMap[pattern]; !ok && strings.HasSuffix(pattern, "/") {
+		frontend.prefixes = insertPrefix(frontend.prefixes, pattern)
+	}
+	frontend.serverMap[pattern] = server
+	frontend.rwmutex.Unlock()
+	return
+}
+
+func (frontend *PathServerFrontend) DeleteServer(pattern string) {
+	frontend.rwmutex.Lock()
+	if _, ok := frontend.serverMap[pattern]; ok {
+		delete(frontend.serverMap, pattern)
+		for i, prefix := range frontend.prefixes {
+			if prefix == pattern {
+				frontend.prefixes = append(frontend.prefixes[:i:i], frontend.prefixes[i+1:]...)
+				break
+			}
+		}
+	}
+	frontend.rwmutex.Unlock()
+}
+
+// 按照长度从长到短插入 prefix, 返回新的 slice; 不修改原来的 slice, 因为 match 可能正在读取.
+func insertPrefix(prefixes []string, prefix string) []string {
+	i := 0
+	for i < len(prefixes) && len(prefixes[i]) >= len(prefix) {
+		i++
+	}
+	result := make([]string, 0, len(prefixes)+1)
+	result = append(result, prefixes[:i]...)
+	result = append(result, prefix)
+	return append(result, prefixes[i:]...)
+}
+
+// 获取 path 对应的 Server, 没有找到返回 nil.
+func (frontend *PathServerFrontend) match(path string) Server {
+	frontend.rwmutex.RLock()
+	defer frontend.rwmutex.RUnlock()
+
+	if server := frontend.serverMap[path]; server != nil {
+		return server
+	}
+	for _, prefix := range frontend.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return frontend.serverMap[prefix]
+		}
+	}
+	return nil
+}
+
+func (frontend *PathServerFrontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server := frontend.match(r.URL.Path)
+	if server == nil {
+		frontend.errHandler.ServeError(w, r, errors.New("Not found Server for path: "+r.URL.Path))
+		return
+	}
+
+	queryValues, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		frontend.errHandler.ServeError(w, r, err)
+		return
+	}
+
+	if interceptor := frontend.interceptor; interceptor != nil && !interceptor.Intercept(w, r, queryValues) {
+		return
+	}
+
+	ServeHTTP(w, r, queryValues, server, frontend.errHandler)
+}
@@ -0,0 +1,38 @@
+package mp
+
+import (
+	"testing"
+)
+
+func TestPathServerFrontendMatch(t *testing.T) {
+	mux := NewMessageServeMux()
+	srvA := NewDefaultServer("gh_a", "token", "", nil, mux)
+	srvB := NewDefaultServer("gh_b", "token", "", nil, mux)
+	srvC := NewDefaultServer("gh_c", "token", "", nil, mux)
+
+	frontend := NewPathServerFrontend(nil, nil)
+	frontend.SetServer("/wechat/", srvA)
+	frontend.SetServer("/wechat/b/", srvB)
+	frontend.SetServer("/wechat/c", srvC)
+
+	tests := []struct {
+		path string
+		want Server
+	}{
+		{"/wechat/x", srvA},
+		{"/wechat/b/1", srvB},
+		{"/wechat/c", srvC},
+		{"/wechat/c/1", srvA}, // 不以 / 结尾的 pattern 只精确匹配
+		{"/other", nil},
+	}
+	for _, tt := range tests {
+		if have := frontend.match(tt.path); have != tt.want {
+			t.Errorf("match(%q), have: %v, want: %v", tt.path, have, tt.want)
+		}
+	}
+
+	frontend.DeleteServer("/wechat/b/")
+	if have := frontend.match("/wechat/b/1"); have != Server(srvA) {
+		t.Errorf("match after DeleteServer, have: %v, want: %v", have, srvA)
+	}
+}
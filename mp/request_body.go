@@ -0,0 +1,75 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+var maxRequestBodySize int64
+
+// 设置消息请求 http body 的最大字节数, <= 0 表示不限制(默认).
+//  微信推送的消息一般只有几 KB, 超过限制的请求返回 ErrRequestBodyTooLarge, 交给 ErrorHandler 处理.
+//  沒有加锁, 请确保在初始化阶段调用!
+func SetMaxRequestBodySize(n int64) {
+	maxRequestBodySize = n
+}
+
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+// 根据 Content-Length 预先分配的 buffer 的最大字节数, 防止伪造的 Content-Length 导致分配过多的内存.
+const maxPreallocBodySize = 64 << 10
+
+// 返回 r.Body, 设置了 SetMaxRequestBodySize 的时候读取超过限制返回 ErrRequestBodyTooLarge.
+func requestBody(r *http.Request) io.Reader {
+	if n := maxRequestBodySize; n > 0 {
+		return &bodyLimitReader{r: r.Body, n: n}
+	}
+	return r.Body
+}
+
+// 读取 r.Body 的全部内容.
+//  返回的 []byte 会作为 Request.RawMsgXML 交给 MessageHandler, MessageHandler 可能在另外的 goroutine 里
+//  继续使用, 所以不能放回 buffer pool; 这里只根据 Content-Length 一次分配好内存, 避免 ioutil.ReadAll 多次扩容.
+func readRequestBody(r *http.Request) ([]byte, error) {
+	size := r.ContentLength
+	if size <= 0 || size > maxPreallocBodySize {
+		return ioutil.ReadAll(requestBody(r))
+	}
+	if n := maxRequestBodySize; n > 0 && size > n {
+		return nil, ErrRequestBodyTooLarge
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, size+bytes.MinRead))
+	_, err := buf.ReadFrom(requestBody(r))
+	return buf.Bytes(), err
+}
+
+type bodyLimitReader struct {
+	r io.Reader
+	n int64 // 剩余可以读取的字节数
+}
+
+func (lr *bodyLimitReader) Read(p []byte) (n int, err error) {
+	if lr.n <= 0 {
+		// 已经读到限制, 再读一个字节判断是否还有数据
+		var b [1]byte
+		n, err = lr.r.Read(b[:])
+		if n > 0 {
+			return 0, ErrRequestBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > lr.n {
+		p = p[:lr.n]
+	}
+	n, err = lr.r.Read(p)
+	lr.n -= int64(n)
+	return
+}
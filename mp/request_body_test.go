@@ -0,0 +1,67 @@
+package mp
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+func TestReadRequestBodyLimit(t *testing.T) {
+	defer SetMaxRequestBodySize(0)
+
+	body := strings.Repeat("x", 100)
+	for _, contentLength := range []int64{100, -1} { // 有和没有 Content-Length
+		newRequest := func() *http.Request {
+			r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+			r.ContentLength = contentLength
+			return r
+		}
+
+		SetMaxRequestBodySize(0)
+		if data, err := readRequestBody(newRequest()); err != nil || string(data) != body {
+			t.Errorf("ContentLength %d, no limit, have: %d bytes, %v", contentLength, len(data), err)
+		}
+
+		SetMaxRequestBodySize(100)
+		if data, err := readRequestBody(newRequest()); err != nil || string(data) != body {
+			t.Errorf("ContentLength %d, limit 100, have: %d bytes, %v", contentLength, len(data), err)
+		}
+
+		SetMaxRequestBodySize(99)
+		if _, err := readRequestBody(newRequest()); err != ErrRequestBodyTooLarge {
+			t.Errorf("ContentLength %d, limit 99, have err: %v, want: %v", contentLength, err, ErrRequestBodyTooLarge)
+		}
+	}
+}
+
+func BenchmarkServeHTTPText(b *testing.B) {
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		io.WriteString(w, "success")
+	})
+	srv := NewDefaultServer("gh_123456789abc", "token", "", nil, handler)
+	errHandler := ErrorHandlerFunc(func(_ http.ResponseWriter, _ *http.Request, err error) {
+		b.Fatal(err)
+	})
+
+	body := []byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[o_user]]></FromUserName>` +
+		`<CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content><MsgId>1234567890123456</MsgId></xml>`)
+	queryValues := url.Values{
+		"signature": {util.Sign("token", "1348831860", "nonce")},
+		"timestamp": {"1348831860"},
+		"nonce":     {"nonce"},
+	}
+	w := util.HttpResponseWriter(ioutil.Discard)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+		ServeHTTP(w, r, queryValues, srv, errHandler)
+	}
+}
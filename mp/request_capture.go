@@ -0,0 +1,204 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 被捕获的消息(事件)请求
+type CapturedRequest struct {
+	Timestamp       int64             `json:"timestamp"`        // 捕获的时间戳
+	MsgType         string            `json:"msg_type"`         // 消息类型
+	RawXML          string            `json:"raw_xml"`          // 消息的明文 XML, 对于加密模式是解密后的消息
+	SignatureParams map[string]string `json:"signature_params"` // 回调请求 URL 中和签名相关的参数: signature, timestamp, nonce, encrypt_type, msg_signature
+}
+
+var _ MessageHandler = (*RequestCapture)(nil)
+
+// RequestCapture 捕获所有经过它的消息(事件)请求, 然后交给后端的 MessageHandler 处理.
+//  一般用于开发阶段录制微信服务器推送过来的真实消息, 然后用 Replay 在测试中回放.
+//
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, NewRequestCapture(messageServeMux))
+type RequestCapture struct {
+	handler MessageHandler
+
+	mutex    sync.Mutex
+	requests []*CapturedRequest
+}
+
+// 创建一个新的 RequestCapture, handler 为后端真正处理消息的 MessageHandler.
+func NewRequestCapture(handler MessageHandler) *RequestCapture {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return &RequestCapture{
+		handler: handler,
+	}
+}
+
+// RequestCapture 实现了 MessageHandler 接口.
+func (capture *RequestCapture) ServeMessage(w http.ResponseWriter, r *Request) {
+	captured := &CapturedRequest{
+		Timestamp: time.Now().Unix(),
+		MsgType:   r.MixedMsg.MsgType,
+		RawXML:    string(r.RawMsgXML),
+		SignatureParams: map[string]string{
+			"signature":     r.Signature,
+			"timestamp":     strconv.FormatInt(r.Timestamp, 10),
+			"nonce":         r.Nonce,
+			"encrypt_type":  r.EncryptType,
+			"msg_signature": r.MsgSignature,
+		},
+	}
+
+	capture.mutex.Lock()
+	capture.requests = append(capture.requests, captured)
+	capture.mutex.Unlock()
+
+	capture.handler.ServeMessage(w, r)
+}
+
+// 获取已经捕获的消息(事件)请求, 按照捕获的先后顺序排列.
+func (capture *RequestCapture) Requests() []*CapturedRequest {
+	capture.mutex.Lock()
+	requests := make([]*CapturedRequest, len(capture.requests))
+	copy(requests, capture.requests)
+	capture.mutex.Unlock()
+	return requests
+}
+
+// 把已经捕获的消息(事件)请求以 JSON 格式写入 w, 每行一个请求.
+func (capture *RequestCapture) Dump(w io.Writer) (err error) {
+	if w == nil {
+		return errors.New("nil io.Writer")
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, captured := range capture.Requests() {
+		if err = encoder.Encode(captured); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 从 r 中读取 Dump 写入的数据, 追加到已经捕获的消息(事件)请求中, 并返回读取到的请求.
+func (capture *RequestCapture) Load(r io.Reader) (requests []*CapturedRequest, err error) {
+	if r == nil {
+		err = errors.New("nil io.Reader")
+		return
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var captured CapturedRequest
+		if err = json.Unmarshal(line, &captured); err != nil {
+			return
+		}
+		requests = append(requests, &captured)
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	capture.mutex.Lock()
+	capture.requests = append(capture.requests, requests...)
+	capture.mutex.Unlock()
+	return
+}
+
+// 把捕获的消息(事件)请求重新提交给 srv 处理.
+//  NOTE:
+//  1. 用 srv 当前的 Token 重新签名, 使用原始请求的 timestamp 和 nonce;
+//  2. 明文模式的请求以明文模式回放; 加密模式(encrypt_type=aes)的请求捕获的是解密后的消息,
+//     用 srv 当前的 AESKey 和 AppId 重新加密, 重新计算 msg_signature 后以加密模式回放;
+//  3. srv 设置了 NonceStore 的时候, 已经处理过的 timestamp 和 nonce 会被拒绝;
+//  4. 遇到第一个错误就返回.
+func Replay(captured []*CapturedRequest, srv Server) (err error) {
+	if srv == nil {
+		return errors.New("nil Server")
+	}
+
+	errHandler := ErrorHandlerFunc(func(_ http.ResponseWriter, _ *http.Request, e error) {
+		err = e
+	})
+
+	for _, c := range captured {
+		if c == nil {
+			continue
+		}
+
+		httpReq, queryValues, e := newReplayRequest(c, srv)
+		if e != nil {
+			return e
+		}
+
+		ServeHTTP(util.HttpResponseWriter(ioutil.Discard), httpReq, queryValues, srv, errHandler)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func newReplayRequest(c *CapturedRequest, srv Server) (httpReq *http.Request, queryValues url.Values, err error) {
+	timestamp := c.SignatureParams["timestamp"]
+	nonce := c.SignatureParams["nonce"]
+	token := srv.Token()
+
+	queryValues = make(url.Values)
+	queryValues.Set("signature", util.Sign(token, timestamp, nonce))
+	queryValues.Set("timestamp", timestamp)
+	queryValues.Set("nonce", nonce)
+
+	body := []byte(c.RawXML)
+	if encryptType := c.SignatureParams["encrypt_type"]; encryptType == "aes" {
+		var msg MixedMessage
+		if err = xml.Unmarshal(body, &msg); err != nil {
+			return
+		}
+
+		random := make([]byte, 16)
+		if _, err = io.ReadFull(rand.Reader, random); err != nil {
+			return
+		}
+		encryptedMsg := base64.StdEncoding.EncodeToString(util.AESEncryptMsg(random, body, srv.AppId(), srv.CurrentAESKey()))
+
+		if body, err = xml.Marshal(&RequestHttpBody{
+			ToUserName:   msg.ToUserName,
+			EncryptedMsg: encryptedMsg,
+		}); err != nil {
+			return
+		}
+		queryValues.Set("encrypt_type", encryptType)
+		queryValues.Set("msg_signature", util.MsgSign(token, timestamp, nonce, encryptedMsg))
+	}
+
+	httpReq, err = http.NewRequest("POST", "/?"+queryValues.Encode(), bytes.NewReader(body))
+	return
+}
@@ -0,0 +1,69 @@
+package mp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	var served []string
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		served = append(served, r.EncryptType+":"+r.MixedMsg.Content)
+	})
+	capture := NewRequestCapture(handler)
+
+	aesKey := make([]byte, 32)
+	for i := range aesKey {
+		aesKey[i] = byte(i)
+	}
+	safe, err := NewSafeModeServer("gh_123456789abc", "token", "wx123", base64.StdEncoding.EncodeToString(aesKey)[:43], capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentAESKey := safe.CurrentAESKey()
+	plain := NewDefaultServer("gh_123456789abc", "token", "wx123", currentAESKey[:], capture)
+
+	newCaptured := func(encryptType, content string) *CapturedRequest {
+		return &CapturedRequest{
+			MsgType: "text",
+			RawXML: `<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[o_user]]></FromUserName>` +
+				`<CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[` + content + `]]></Content></xml>`,
+			SignatureParams: map[string]string{
+				"signature":     "captured",
+				"timestamp":     "1348831860",
+				"nonce":         "nonce",
+				"encrypt_type":  encryptType,
+				"msg_signature": "captured",
+			},
+		}
+	}
+
+	// 加密模式捕获的请求可以回放给 SafeModeServer, 明文的不行
+	if err = Replay([]*CapturedRequest{newCaptured("aes", "hello")}, safe); err != nil {
+		t.Fatalf("Replay aes to SafeModeServer: %v", err)
+	}
+	if err = Replay([]*CapturedRequest{newCaptured("", "plain")}, safe); err == nil {
+		t.Error("Replay plaintext to SafeModeServer, want error")
+	}
+	if err = Replay([]*CapturedRequest{newCaptured("", "plain"), newCaptured("aes", "again")}, plain); err != nil {
+		t.Fatalf("Replay to DefaultServer: %v", err)
+	}
+	if have, want := served, []string{"aes:hello", ":plain", "aes:again"}; len(have) != len(want) || have[0] != want[0] || have[1] != want[1] || have[2] != want[2] {
+		t.Errorf("served, have: %v, want: %v", have, want)
+	}
+
+	// Dump 之后 Load 得到同样的请求
+	var buf bytes.Buffer
+	if err = capture.Dump(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := NewRequestCapture(handler).Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 3 || loaded[0].SignatureParams["encrypt_type"] != "aes" || loaded[1].RawXML != capture.Requests()[1].RawXML {
+		t.Errorf("Load after Dump, have: %d requests", len(loaded))
+	}
+}
@@ -0,0 +1,243 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// ResponseCache 使用的存储接口.
+type CacheStore interface {
+	Get(key string) (value []byte, ok bool, err error)
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+var _ MessageHandler = (*ResponseCache)(nil)
+
+// ResponseCache 缓存事件的回复, 同一个用户的同一个事件(Event + EventKey)在 ttl 内直接回复缓存的内容, 不再调用后端的 MessageHandler.
+//  适合回复只由事件决定的场景, 比如 subscribe 回复欢迎语, CLICK 菜单回复固定的图文, SCAN 带参数二维码回复固定的内容,
+//  同时也避免了微信服务器重试推送同一个事件时重复处理.
+//
+//  NOTE:
+//  1. 只缓存事件, 普通消息(比如文本消息每次的内容不一样)直接交给后端处理;
+//  2. LOCATION 事件每次的经纬度不一样, 不缓存;
+//  3. 只缓存 http 状态码为 200 并且有内容的回复, 连同 MessageHandler 设置的 http header 一起缓存;
+//  4. 安全模式下缓存的是解密后的明文回复, 每次命中都用当前请求的 AESKey, Timestamp, Nonce 重新加密,
+//     所以 UpdateAESKey, UpdateCredentials 之后缓存的回复仍然有效;
+//  5. 兼容模式下同一个事件可能是明文也可能是密文, 缓存的 key 包含 EncryptType, 两者分开缓存.
+type ResponseCache struct {
+	handler MessageHandler
+	store   CacheStore
+	ttl     time.Duration
+}
+
+// 创建一个新的 ResponseCache.
+//  handler: 后端真正处理消息的 MessageHandler
+//  store:   缓存的存储, 单机可以用 NewMemoryCacheStore
+//  ttl:     缓存的时间
+func NewResponseCache(handler MessageHandler, store CacheStore, ttl time.Duration) *ResponseCache {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if store == nil {
+		panic("nil CacheStore")
+	}
+	if ttl <= 0 {
+		panic("ttl must be positive")
+	}
+	return &ResponseCache{
+		handler: handler,
+		store:   store,
+		ttl:     ttl,
+	}
+}
+
+// ResponseCache 实现了 MessageHandler 接口.
+func (cache *ResponseCache) ServeMessage(w http.ResponseWriter, r *Request) {
+	msg := r.MixedMsg
+	if msg == nil || msg.MsgType != "event" || msg.Event == "LOCATION" {
+		cache.handler.ServeMessage(w, r)
+		return
+	}
+
+	key := r.EncryptType + "\x00" + msg.ToUserName + "\x00" + msg.FromUserName + "\x00" + msg.Event + "\x00" + msg.EventKey
+	if value, ok, err := cache.store.Get(key); err != nil {
+		LogInfoln("[WECHAT_RESPONSE_CACHE]", err)
+	} else if ok {
+		header, body, err := decodeCachedResponse(value)
+		if err == nil {
+			for k, vv := range header {
+				w.Header()[k] = vv
+			}
+			if r.EncryptType == "aes" {
+				err = writeAESResponse(w, r, body)
+			} else {
+				_, err = w.Write(body)
+			}
+			if err != nil {
+				LogInfoln("[WECHAT_RESPONSE_CACHE]", err)
+			}
+			return
+		}
+		LogInfoln("[WECHAT_RESPONSE_CACHE]", err)
+	}
+
+	rw := &recordResponseWriter{ResponseWriter: w}
+	cache.handler.ServeMessage(rw, r)
+	if rw.statusCode != http.StatusOK || rw.body.Len() == 0 {
+		return
+	}
+	body := rw.body.Bytes()
+	if r.EncryptType == "aes" {
+		var err error
+		if body, err = decryptAESResponse(body, r.AESKey); err != nil {
+			LogInfoln("[WECHAT_RESPONSE_CACHE]", err)
+			return
+		}
+	}
+	if err := cache.store.Set(key, encodeCachedResponse(rw.header, body), cache.ttl); err != nil {
+		LogInfoln("[WECHAT_RESPONSE_CACHE]", err)
+	}
+}
+
+// 解密 WriteAESResponse 写入的回复, 返回明文的回复消息.
+func decryptAESResponse(body []byte, aesKey [32]byte) (rawMsgXML []byte, err error) {
+	var responseHttpBody ResponseHttpBody
+	if err = xml.Unmarshal(body, &responseHttpBody); err != nil {
+		return
+	}
+	encryptedMsg, err := base64.StdEncoding.DecodeString(responseHttpBody.EncryptedMsg)
+	if err != nil {
+		return
+	}
+	_, rawMsgXML, _, err = util.AESDecryptMsg(encryptedMsg, aesKey)
+	return
+}
+
+// 缓存的格式和 http 报文一样: header, 空行, body.
+func encodeCachedResponse(header http.Header, body []byte) []byte {
+	var buf bytes.Buffer
+	header.Write(&buf)
+	buf.WriteString("\r\n")
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func decodeCachedResponse(value []byte) (header http.Header, body []byte, err error) {
+	br := bufio.NewReader(bytes.NewReader(value))
+	mimeHeader, err := textproto.NewReader(br).ReadMIMEHeader()
+	if err != nil {
+		return
+	}
+	if body, err = ioutil.ReadAll(br); err != nil {
+		return
+	}
+	header = http.Header(mimeHeader)
+	return
+}
+
+// 把写入的内容同时记录下来.
+type recordResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header // 第一次写入时的 header
+	body       bytes.Buffer
+}
+
+func (rw *recordResponseWriter) WriteHeader(statusCode int) {
+	if rw.statusCode == 0 {
+		rw.statusCode = statusCode
+		rw.header = cloneHeader(rw.ResponseWriter.Header())
+	}
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *recordResponseWriter) Write(p []byte) (n int, err error) {
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+		rw.header = cloneHeader(rw.ResponseWriter.Header())
+	}
+	n, err = rw.ResponseWriter.Write(p)
+	rw.body.Write(p[:n])
+	return
+}
+
+func cloneHeader(header http.Header) http.Header {
+	h := make(http.Header, len(header))
+	for k, vv := range header {
+		h[k] = append([]string(nil), vv...)
+	}
+	return h
+}
+
+var _ CacheStore = (*MemoryCacheStore)(nil)
+
+// 基于内存的 CacheStore 实现, 失效的数据在 Get 和 Prune 的时候删除.
+type MemoryCacheStore struct {
+	mutex   sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (store *MemoryCacheStore) Get(key string) (value []byte, ok bool, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, ok := store.entries[key]
+	if !ok {
+		return
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(store.entries, key)
+		ok = false
+		return
+	}
+	value = entry.value
+	return
+}
+
+func (store *MemoryCacheStore) Set(key string, value []byte, ttl time.Duration) (err error) {
+	store.mutex.Lock()
+	store.entries[key] = cacheEntry{
+		value:     append([]byte(nil), value...),
+		expiresAt: time.Now().Add(ttl),
+	}
+	store.mutex.Unlock()
+	return
+}
+
+// 清除所有已经失效的数据, 用户比较多的时候需要定期调用, 否则只被 Set 过的数据一直不会删除.
+func (store *MemoryCacheStore) Prune() {
+	now := time.Now()
+
+	store.mutex.Lock()
+	for key, entry := range store.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(store.entries, key)
+		}
+	}
+	store.mutex.Unlock()
+}
@@ -0,0 +1,87 @@
+package mp
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	type reply struct {
+		XMLName struct{} `xml:"xml"`
+		Content string   `xml:"Content"`
+	}
+
+	var calls int
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		if r.EncryptType == "aes" {
+			WriteAESResponse(w, r, &reply{Content: "aes reply"})
+			return
+		}
+		io.WriteString(w, "raw reply")
+	})
+	cache := NewResponseCache(handler, NewMemoryCacheStore(), time.Minute)
+
+	serve := func(encryptType string, aesKey [32]byte) (*httptest.ResponseRecorder, *Request) {
+		w := httptest.NewRecorder()
+		r := &Request{
+			Token:       "token",
+			Timestamp:   time.Now().Unix(),
+			Nonce:       "nonce",
+			EncryptType: encryptType,
+			AESKey:      aesKey,
+			Random:      make([]byte, 16),
+			AppId:       "wx123",
+			MixedMsg: &MixedMessage{
+				MessageHeader: MessageHeader{ToUserName: "gh_123456789abc", FromUserName: "o_user", MsgType: "event"},
+				Event:         "CLICK",
+				EventKey:      "V1001",
+			},
+		}
+		cache.ServeMessage(w, r)
+		return w, r
+	}
+
+	var key1, key2 [32]byte
+	key2[0] = 1
+	for i, tc := range []struct {
+		encryptType string
+		aesKey      [32]byte
+		wantCalls   int
+	}{
+		{"", key1, 1},
+		{"", key1, 1},    // 命中缓存
+		{"aes", key1, 2}, // 兼容模式同一个事件的密文请求不能回复明文的缓存
+		{"aes", key1, 2},
+		{"aes", key2, 2}, // 更换 AESKey 之后命中的缓存用新的 AESKey 加密
+	} {
+		w, r := serve(tc.encryptType, tc.aesKey)
+		if calls != tc.wantCalls {
+			t.Errorf("#%d: handler calls, have: %d, want: %d", i, calls, tc.wantCalls)
+		}
+		if have := w.Header().Get("Content-Type"); have != "application/xml; charset=utf-8" {
+			t.Errorf("#%d: Content-Type, have: %q", i, have)
+		}
+
+		if tc.encryptType != "aes" {
+			if have := w.Body.String(); have != "raw reply" {
+				t.Errorf("#%d: body, have: %q, want: %q", i, have, "raw reply")
+			}
+			continue
+		}
+		rawMsgXML, err := decryptAESResponse(w.Body.Bytes(), r.AESKey)
+		if err != nil {
+			t.Errorf("#%d: decrypt reply with the request's AESKey: %v", i, err)
+			continue
+		}
+		var msg reply
+		if err = xml.Unmarshal(rawMsgXML, &msg); err != nil || msg.Content != "aes reply" {
+			t.Errorf("#%d: decrypted reply, have: %q, %v", i, rawMsgXML, err)
+		}
+	}
+}
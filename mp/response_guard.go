@@ -0,0 +1,26 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+// 被动回复消息的检查接口, WriteRawResponse 和 WriteAESResponse 在序列化 msg 之前调用,
+// 可以直接修改 msg, 比如 response.MessageSizeGuard 截断超过长度限制的字段.
+type ResponseGuard interface {
+	GuardResponse(r *Request, msg interface{})
+}
+
+var responseGuard ResponseGuard
+
+// 设置被动回复消息的检查接口, nil 表示不检查.
+//  沒有加锁, 请确保在初始化阶段调用!
+func SetResponseGuard(guard ResponseGuard) {
+	responseGuard = guard
+}
+
+func guardResponse(r *Request, msg interface{}) {
+	if responseGuard != nil {
+		responseGuard.GuardResponse(r, msg)
+	}
+}
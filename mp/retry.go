@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 记录已经处理过的消息.
+type SeenCache interface {
+	// 记录 key, 并且返回 key 之前是否已经被记录过.
+	WasSeen(key string) bool
+}
+
+// 返回消息(事件)用于判断重试的 key.
+//  普通消息使用 MsgId, 事件没有 MsgId, 使用 FromUserName + Event + CreateTime.
+func RetryKey(r *Request) string {
+	msg := r.MixedMsg
+	if msg == nil {
+		return ""
+	}
+	if msg.MsgType != "event" && msg.MsgId != 0 {
+		return msg.ToUserName + ":" + strconv.FormatInt(msg.MsgId, 10)
+	}
+	return msg.ToUserName + ":" + msg.FromUserName + ":" + msg.Event + ":" + strconv.FormatInt(msg.CreateTime, 10)
+}
+
+// 判断 r 是不是微信服务器重试推送的消息(事件).
+//
+//  微信服务器在 5 秒内收不到回复会断开连接并且重新推送, 一共推送三次, 重试的消息和第一次的 MsgId(事件为
+//  FromUserName + CreateTime)相同. 如果不处理重试, 比较慢的 MessageHandler 可能对同一条消息执行多次
+//  (比如重复扣积分), 重试的消息一般直接回复空串或者 "success".
+//
+//  NOTE: 第一次调用会把 r 记录到 cache 里, 所以每条消息只能调用一次.
+func IsRetry(r *Request, cache SeenCache) bool {
+	key := RetryKey(r)
+	if key == "" {
+		return false
+	}
+	return cache.WasSeen(key)
+}
+
+var _ SeenCache = (*MemorySeenCache)(nil)
+
+// 基于内存的 SeenCache, key 在 ttl 之后失效.
+//  微信的三次推送一般在 15 秒内完成, ttl 设置为 30 秒左右就可以.
+type MemorySeenCache struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	keys      map[string]time.Time // map[key]expiresAt
+	lastPrune time.Time
+}
+
+func NewMemorySeenCache(ttl time.Duration) *MemorySeenCache {
+	if ttl <= 0 {
+		panic("ttl must be positive")
+	}
+	return &MemorySeenCache{
+		ttl:  ttl,
+		keys: make(map[string]time.Time),
+	}
+}
+
+func (cache *MemorySeenCache) WasSeen(key string) bool {
+	now := time.Now()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// 每隔 ttl 清除一次失效的 key
+	if now.Sub(cache.lastPrune) >= cache.ttl {
+		for k, expiresAt := range cache.keys {
+			if !now.Before(expiresAt) {
+				delete(cache.keys, k)
+			}
+		}
+		cache.lastPrune = now
+	}
+
+	if expiresAt, ok := cache.keys[key]; ok && now.Before(expiresAt) {
+		return true
+	}
+	cache.keys[key] = now.Add(cache.ttl)
+	return false
+}
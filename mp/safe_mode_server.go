@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+var _ Server = (*SafeModeServer)(nil)
+
+// SafeModeServer 是只接受安全模式消息的 Server.
+//  ServeHTTP 遇到 SafeModeServer 时, 所有明文模式(encrypt_type 为空或者 raw)的消息请求都交给
+//  ErrorHandler 处理, 不会调用 MessageHandler, 避免因为后台配置错误而以明文模式运行.
+//
+//  NOTE:
+//  1. 兼容模式的消息带有 encrypt_type=aes, 按照安全模式处理;
+//  2. ServeHTTP 通过 AcceptPlaintext 方法判断, 嵌入了 SafeModeServer 的类型同样只接受安全模式消息.
+type SafeModeServer struct {
+	*DefaultServer
+}
+
+// 返回 false, 实现了 interface{ AcceptPlaintext() bool } 接口.
+func (srv *SafeModeServer) AcceptPlaintext() bool {
+	return false
+}
+
+// Server 实现了 AcceptPlaintext() bool 方法并且返回 false 时, ServeHTTP 拒绝明文模式的消息请求.
+func acceptPlaintext(srv Server) bool {
+	if x, ok := srv.(interface {
+		AcceptPlaintext() bool
+	}); ok {
+		return x.AcceptPlaintext()
+	}
+	return true
+}
+
+// NewSafeModeServer 创建一个新的 SafeModeServer.
+//  encodedAESKey: 微信管理后台的 EncodingAESKey, 43 个字符, 由 a-z,A-Z,0-9 组成.
+//  和 NewDefaultServer 不同, 参数不合法的时候返回错误而不是 panic.
+func NewSafeModeServer(oriId, token, appId, encodedAESKey string, handler MessageHandler) (srv *SafeModeServer, err error) {
+	if token == "" {
+		err = errors.New("empty token")
+		return
+	}
+	if handler == nil {
+		err = errors.New("nil MessageHandler")
+		return
+	}
+	if len(encodedAESKey) != 43 {
+		err = errors.New("the length of encodedAESKey must be equal to 43")
+		return
+	}
+	for i := 0; i < len(encodedAESKey); i++ {
+		switch c := encodedAESKey[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		default:
+			err = errors.New("invalid character in encodedAESKey: " + string(c))
+			return
+		}
+	}
+
+	aesKey, err := util.AESKeyDecode(encodedAESKey)
+	if err != nil {
+		return
+	}
+
+	srv = &SafeModeServer{
+		DefaultServer: NewDefaultServer(oriId, token, appId, aesKey, handler),
+	}
+	return
+}
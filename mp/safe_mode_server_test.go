@@ -0,0 +1,43 @@
+package mp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+func TestSafeModeServerRejectsPlaintext(t *testing.T) {
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		t.Error("MessageHandler should not be called for plaintext message")
+	})
+	aesKey := make([]byte, 32)
+	safe, err := NewSafeModeServer("", "token", "", base64.StdEncoding.EncodeToString(aesKey)[:43], handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 嵌入 SafeModeServer 的 Server 同样不接受明文消息
+	wrapped := struct{ *SafeModeServer }{safe}
+
+	queryValues := url.Values{
+		"signature": {util.Sign("token", "1348831860", "nonce")},
+		"timestamp": {"1348831860"},
+		"nonce":     {"nonce"},
+	}
+	body := []byte(`<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><MsgType><![CDATA[text]]></MsgType></xml>`)
+	for _, srv := range []Server{safe, wrapped} {
+		var errs int
+		errHandler := ErrorHandlerFunc(func(_ http.ResponseWriter, _ *http.Request, err error) {
+			errs++
+		})
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+		ServeHTTP(httptest.NewRecorder(), r, queryValues, srv, errHandler)
+		if errs != 1 {
+			t.Errorf("%T: ErrorHandler calls, have: %d, want: 1", srv, errs)
+		}
+	}
+}
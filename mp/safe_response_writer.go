@@ -0,0 +1,111 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+)
+
+// 已经回复过消息
+var ErrAlreadyReplied = errors.New("the message has already been replied")
+
+var (
+	_ http.ResponseWriter = (*SafeResponseWriter)(nil)
+	_ http.Flusher        = (*SafeResponseWriter)(nil)
+)
+
+// SafeResponseWriter 只允许回复一次消息, 防止多个 MessageHandler 都写入回复, 导致微信服务器收到无效的 XML.
+//  一次回复指的是一次 WriteRawResponse 或者 WriteAESResponse(包括 response.Write) 调用:
+//  1. 已经回复过或者已经直接 Write 过内容的时候, 再调用 WriteRawResponse, WriteAESResponse 返回 ErrAlreadyReplied;
+//  2. 回复之后再直接 Write 返回 ErrAlreadyReplied;
+//  3. 没有通过上面的函数回复的时候直接 Write 不受限制, 比如 xml.NewEncoder(w).Encode(msg) 可能分多次 Write;
+//  被拒绝的调用都会记录调用者的调用栈, 方便找到重复回复的代码.
+//
+//  ServeHTTP 总是用 SafeResponseWriter 包装 http.ResponseWriter 传给 MessageHandler.
+type SafeResponseWriter struct {
+	w          http.ResponseWriter
+	written    bool // 是否调用过 Write
+	replied    bool // 是否通过 WriteRawResponse, WriteAESResponse 回复过
+	statusCode int
+}
+
+func NewSafeResponseWriter(w http.ResponseWriter) *SafeResponseWriter {
+	if w == nil {
+		panic("nil http.ResponseWriter")
+	}
+	if sw, ok := w.(*SafeResponseWriter); ok {
+		return sw
+	}
+	return &SafeResponseWriter{
+		w: w,
+	}
+}
+
+func (sw *SafeResponseWriter) Header() http.Header {
+	return sw.w.Header()
+}
+
+func (sw *SafeResponseWriter) WriteHeader(statusCode int) {
+	if sw.statusCode != 0 || sw.written {
+		LogInfoln("[WECHAT_SAFE_RESPONSE_WRITER] ignored superfluous WriteHeader call, stack:\n" + string(debug.Stack()))
+		return
+	}
+	sw.statusCode = statusCode
+	sw.w.WriteHeader(statusCode)
+}
+
+func (sw *SafeResponseWriter) Write(p []byte) (n int, err error) {
+	if sw.replied {
+		LogInfoln("[WECHAT_SAFE_RESPONSE_WRITER] rejected Write after the message has been replied, stack:\n" + string(debug.Stack()))
+		return 0, ErrAlreadyReplied
+	}
+	return sw.write(p)
+}
+
+func (sw *SafeResponseWriter) write(p []byte) (n int, err error) {
+	sw.written = true
+	if sw.statusCode == 0 {
+		sw.statusCode = http.StatusOK
+	}
+	return sw.w.Write(p)
+}
+
+// 写入一次完整的回复, 见 SafeResponseWriter 的说明.
+func (sw *SafeResponseWriter) writeReply(p []byte) (n int, err error) {
+	if sw.replied || sw.written {
+		LogInfoln("[WECHAT_SAFE_RESPONSE_WRITER] rejected repeated reply, stack:\n" + string(debug.Stack()))
+		return 0, ErrAlreadyReplied
+	}
+	sw.replied = true
+	return sw.write(p)
+}
+
+// 如果包装的 http.ResponseWriter 实现了 http.Flusher 则调用它的 Flush.
+func (sw *SafeResponseWriter) Flush() {
+	if flusher, ok := sw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// 是否已经调用过 Write.
+func (sw *SafeResponseWriter) Written() bool {
+	return sw.written
+}
+
+// 回复的 http 状态码, 还没有回复时返回 0.
+func (sw *SafeResponseWriter) StatusCode() int {
+	return sw.statusCode
+}
+
+// WriteRawResponse, WriteAESResponse 写入回复, w 是 SafeResponseWriter 的时候检查是否重复回复.
+func writeReply(w http.ResponseWriter, p []byte) (n int, err error) {
+	if sw, ok := w.(*SafeResponseWriter); ok {
+		return sw.writeReply(p)
+	}
+	return w.Write(p)
+}
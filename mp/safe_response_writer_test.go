@@ -0,0 +1,51 @@
+package mp
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSafeResponseWriter(t *testing.T) {
+	type reply struct {
+		XMLName struct{} `xml:"xml"`
+		Content string   `xml:"Content"`
+	}
+	long := strings.Repeat("x", 10<<10) // xml.Encoder 每 4KB 写一次
+
+	// 直接 Write 多次不受限制, 内容不能丢失
+	recorder := httptest.NewRecorder()
+	w := NewSafeResponseWriter(recorder)
+	if err := xml.NewEncoder(w).Encode(&reply{Content: long}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(recorder.Body.String(), long+"</Content></xml>") {
+		t.Errorf("multi-write reply truncated, have %d bytes", recorder.Body.Len())
+	}
+	// 已经写过内容, 不能再回复
+	if err := WriteRawResponse(w, &Request{}, &reply{Content: "again"}); err != ErrAlreadyReplied {
+		t.Errorf("WriteRawResponse after Write, have: %v, want: %v", err, ErrAlreadyReplied)
+	}
+
+	// 回复之后的回复和 Write 都返回 ErrAlreadyReplied
+	recorder = httptest.NewRecorder()
+	w = NewSafeResponseWriter(recorder)
+	if err := WriteRawResponse(w, &Request{}, &reply{Content: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteRawResponse(w, &Request{}, &reply{Content: "second"}); err != ErrAlreadyReplied {
+		t.Errorf("second WriteRawResponse, have: %v, want: %v", err, ErrAlreadyReplied)
+	}
+	if n, err := w.Write([]byte("success")); n != 0 || err != ErrAlreadyReplied {
+		t.Errorf("Write after reply, have: %d, %v, want: 0, %v", n, err, ErrAlreadyReplied)
+	}
+	if have, want := recorder.Body.String(), "<xml><Content>first</Content></xml>"; have != want {
+		t.Errorf("body, have: %q, want: %q", have, want)
+	}
+
+	w.Flush()
+	if !recorder.Flushed {
+		t.Error("Flush was not forwarded")
+	}
+}
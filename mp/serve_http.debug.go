@@ -13,7 +13,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -42,6 +41,14 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 	case "POST": // 消息处理
 		switch encryptType := queryValues.Get("encrypt_type"); encryptType {
 		case "aes": // 安全模式, 兼容模式
+			// 目前只支持微信公开的加密方案, 如果以后微信推出新的加密版本, 不能用现有的方案去解密
+			switch encryptVersion := queryValues.Get("encrypt_version"); encryptVersion {
+			case "", "1":
+			default:
+				errHandler.ServeError(w, r, errors.New("unsupported encrypt_version: "+encryptVersion))
+				return
+			}
+
 			signature := queryValues.Get("signature") // 只读取, 不做校验
 
 			msgSignature1 := queryValues.Get("msg_signature")
@@ -69,7 +76,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				return
 			}
 
-			reqBody, err := ioutil.ReadAll(r.Body)
+			reqBody, err := readRequestBody(r)
 			if err != nil {
 				errHandler.ServeError(w, r, err)
 				return
@@ -101,6 +108,11 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				return
 			}
 
+			if err := checkNonce(srv, nonce, timestampStr); err != nil {
+				errHandler.ServeError(w, r, err)
+				return
+			}
+
 			// 解密
 			encryptedMsgBytes, err := base64.StdEncoding.DecodeString(requestHttpBody.EncryptedMsg)
 			if err != nil {
@@ -114,6 +126,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				// 尝试用上一次的 AESKey 来解密
 				lastAESKey, isLastAESKeyValid := srv.LastAESKey()
 				if !isLastAESKeyValid {
+					auditDecrypt(srv.AppId(), encryptedMsgBytes, nil, err)
 					errHandler.ServeError(w, r, err)
 					return
 				}
@@ -122,11 +135,13 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 				random, rawMsgXML, haveAppIdBytes, err = util.AESDecryptMsg(encryptedMsgBytes, aesKey)
 				if err != nil {
+					auditDecrypt(srv.AppId(), encryptedMsgBytes, nil, err)
 					errHandler.ServeError(w, r, err)
 					return
 				}
 			}
 			haveAppId := string(haveAppIdBytes)
+			auditDecrypt(haveAppId, encryptedMsgBytes, rawMsgXML, nil)
 			wantAppId := srv.AppId()
 			if wantAppId != "" && !security.SecureCompareString(haveAppId, wantAppId) {
 				err := fmt.Errorf("the message's appid mismatch, have: %s, want: %s", haveAppId, wantAppId)
@@ -170,9 +185,14 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				Random:       random,
 				AppId:        haveAppId,
 			}
-			srv.MessageHandler().ServeMessage(w, req)
+			srv.MessageHandler().ServeMessage(NewSafeResponseWriter(w), req)
 
 		case "", "raw": // 明文模式
+			if !acceptPlaintext(srv) {
+				errHandler.ServeError(w, r, errors.New("the Server does not accept plaintext message, encrypt_type: "+encryptType))
+				return
+			}
+
 			signature1 := queryValues.Get("signature")
 			if signature1 == "" {
 				errHandler.ServeError(w, r, errors.New("signature is empty"))
@@ -207,8 +227,13 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				return
 			}
 
+			if err := checkNonce(srv, nonce, timestampStr); err != nil {
+				errHandler.ServeError(w, r, err)
+				return
+			}
+
 			// 验证签名成功, 解析 MixedMessage
-			rawMsgXML, err := ioutil.ReadAll(r.Body)
+			rawMsgXML, err := readRequestBody(r)
 			if err != nil {
 				errHandler.ServeError(w, r, err)
 				return
@@ -246,7 +271,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				RawMsgXML:   rawMsgXML,
 				MixedMsg:    &mixedMsg,
 			}
-			srv.MessageHandler().ServeMessage(w, req)
+			srv.MessageHandler().ServeMessage(NewSafeResponseWriter(w), req)
 
 		default: // 未知的加密类型
 			err := errors.New("unknown encrypt_type: " + encryptType)
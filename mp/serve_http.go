@@ -13,7 +13,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -38,6 +37,14 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 	case "POST": // 消息处理
 		switch encryptType := queryValues.Get("encrypt_type"); encryptType {
 		case "aes": // 安全模式, 兼容模式
+			// 目前只支持微信公开的加密方案, 如果以后微信推出新的加密版本, 不能用现有的方案去解密
+			switch encryptVersion := queryValues.Get("encrypt_version"); encryptVersion {
+			case "", "1":
+			default:
+				errHandler.ServeError(w, r, errors.New("unsupported encrypt_version: "+encryptVersion))
+				return
+			}
+
 			signature := queryValues.Get("signature") // 只读取, 不做校验
 
 			msgSignature1 := queryValues.Get("msg_signature")
@@ -66,7 +73,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 			}
 
 			var requestHttpBody RequestHttpBody
-			if err := xml.NewDecoder(r.Body).Decode(&requestHttpBody); err != nil {
+			if err := xml.NewDecoder(requestBody(r)).Decode(&requestHttpBody); err != nil {
 				errHandler.ServeError(w, r, err)
 				return
 			}
@@ -90,6 +97,11 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				return
 			}
 
+			if err := checkNonce(srv, nonce, timestampStr); err != nil {
+				errHandler.ServeError(w, r, err)
+				return
+			}
+
 			// 解密
 			encryptedMsgBytes, err := base64.StdEncoding.DecodeString(requestHttpBody.EncryptedMsg)
 			if err != nil {
@@ -103,6 +115,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				// 尝试用上一次的 AESKey 来解密
 				lastAESKey, isLastAESKeyValid := srv.LastAESKey()
 				if !isLastAESKeyValid {
+					auditDecrypt(srv.AppId(), encryptedMsgBytes, nil, err)
 					errHandler.ServeError(w, r, err)
 					return
 				}
@@ -111,11 +124,13 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 
 				random, rawMsgXML, haveAppIdBytes, err = util.AESDecryptMsg(encryptedMsgBytes, aesKey)
 				if err != nil {
+					auditDecrypt(srv.AppId(), encryptedMsgBytes, nil, err)
 					errHandler.ServeError(w, r, err)
 					return
 				}
 			}
 			haveAppId := string(haveAppIdBytes)
+			auditDecrypt(haveAppId, encryptedMsgBytes, rawMsgXML, nil)
 			wantAppId := srv.AppId()
 			if wantAppId != "" && !security.SecureCompareString(haveAppId, wantAppId) {
 				err := fmt.Errorf("the message's appid mismatch, have: %s, want: %s", haveAppId, wantAppId)
@@ -157,9 +172,14 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				Random:       random,
 				AppId:        haveAppId,
 			}
-			srv.MessageHandler().ServeMessage(w, req)
+			srv.MessageHandler().ServeMessage(NewSafeResponseWriter(w), req)
 
 		case "", "raw": // 明文模式
+			if !acceptPlaintext(srv) {
+				errHandler.ServeError(w, r, errors.New("the Server does not accept plaintext message, encrypt_type: "+encryptType))
+				return
+			}
+
 			signature1 := queryValues.Get("signature")
 			if signature1 == "" {
 				errHandler.ServeError(w, r, errors.New("signature is empty"))
@@ -194,8 +214,13 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				return
 			}
 
+			if err := checkNonce(srv, nonce, timestampStr); err != nil {
+				errHandler.ServeError(w, r, err)
+				return
+			}
+
 			// 验证签名成功, 解析 MixedMessage
-			rawMsgXML, err := ioutil.ReadAll(r.Body)
+			rawMsgXML, err := readRequestBody(r)
 			if err != nil {
 				errHandler.ServeError(w, r, err)
 				return
@@ -231,7 +256,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, queryValues url.Values, s
 				RawMsgXML:   rawMsgXML,
 				MixedMsg:    &mixedMsg,
 			}
-			srv.MessageHandler().ServeMessage(w, req)
+			srv.MessageHandler().ServeMessage(NewSafeResponseWriter(w), req)
 
 		default: // 未知的加密类型
 			err := errors.New("unknown encrypt_type: " + encryptType)
@@ -27,12 +27,13 @@ var _ Server = (*DefaultServer)(nil)
 type DefaultServer struct {
 	oriId string
 	appId string
-	token string
 
 	rwmutex           sync.RWMutex
+	token             string
 	currentAESKey     [32]byte
 	lastAESKey        [32]byte
 	isLastAESKeyValid bool
+	nonceStore        NonceStore
 
 	messageHandler MessageHandler
 }
@@ -63,8 +64,11 @@ func (srv *DefaultServer) OriId() string {
 func (srv *DefaultServer) AppId() string {
 	return srv.appId
 }
-func (srv *DefaultServer) Token() string {
-	return srv.token
+func (srv *DefaultServer) Token() (token string) {
+	srv.rwmutex.RLock()
+	token = srv.token
+	srv.rwmutex.RUnlock()
+	return
 }
 func (srv *DefaultServer) MessageHandler() MessageHandler {
 	return srv.messageHandler
@@ -83,6 +87,25 @@ func (srv *DefaultServer) LastAESKey() (key [32]byte, valid bool) {
 	return
 }
 
+// 设置防止重放攻击的 NonceStore, nil 表示不检查(默认).
+//  设置以后 ServeHTTP 在验证签名成功之后调用 store.SeenOrMark, 已经出现过的 nonce + timestamp 交给 ErrorHandler 处理.
+//
+//  NOTE:
+//  1. 签名只保证请求来自微信服务器, 截获的请求在不检查 nonce 的情况下可以一直重放;
+//  2. 每个 Server 单独设置, 多个公众号可以使用不同的 NonceStore 和 tolerance.
+func (srv *DefaultServer) SetNonceStore(store NonceStore) {
+	srv.rwmutex.Lock()
+	srv.nonceStore = store
+	srv.rwmutex.Unlock()
+}
+
+func (srv *DefaultServer) NonceStore() (store NonceStore) {
+	srv.rwmutex.RLock()
+	store = srv.nonceStore
+	srv.rwmutex.RUnlock()
+	return
+}
+
 func (srv *DefaultServer) UpdateAESKey(aesKey []byte) (err error) {
 	if len(aesKey) != 32 {
 		return errors.New("the length of aesKey must equal to 32")
@@ -91,6 +114,34 @@ func (srv *DefaultServer) UpdateAESKey(aesKey []byte) (err error) {
 	srv.rwmutex.Lock()
 	defer srv.rwmutex.Unlock()
 
+	srv.updateAESKey(aesKey)
+	return
+}
+
+// 同时更新 Token 和 AES 加密 Key, 用于不重启服务轮换凭证.
+//  aesKey 为 nil 时只更新 Token; 和 UpdateAESKey 一样, 之前的 AES 加密 Key 作为 LastAESKey 继续有效.
+//
+//  ServeHTTP 在校验签名和解密的时候才读取凭证, 正在处理的请求不受影响.
+//  NOTE: 微信公众平台后台修改 Token 之后立即调用, 修改之前推送的消息会因为签名不对返回失败, 微信服务器会重试.
+func (srv *DefaultServer) UpdateCredentials(token string, aesKey []byte) (err error) {
+	if token == "" {
+		return errors.New("empty token")
+	}
+	if aesKey != nil && len(aesKey) != 32 {
+		return errors.New("the length of aesKey must equal to 32")
+	}
+
+	srv.rwmutex.Lock()
+	defer srv.rwmutex.Unlock()
+
+	srv.token = token
+	if aesKey != nil {
+		srv.updateAESKey(aesKey)
+	}
+	return
+}
+
+func (srv *DefaultServer) updateAESKey(aesKey []byte) {
 	if bytes.Equal(aesKey, srv.currentAESKey[:]) {
 		return
 	}
@@ -98,5 +149,4 @@ func (srv *DefaultServer) UpdateAESKey(aesKey []byte) (err error) {
 	srv.isLastAESKeyValid = true
 	srv.lastAESKey = srv.currentAESKey
 	copy(srv.currentAESKey[:], aesKey)
-	return
 }
@@ -0,0 +1,103 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const redacted = "***"
+
+// DefaultServer 的配置, 用于调试和审计, 敏感字段已经脱敏.
+type ServerConfig struct {
+	OriId          string         `json:"oriId"`
+	AppId          string         `json:"appId"`
+	Token          string         `json:"token"`          // 设置了为 "***", 没有设置为 ""
+	EncodingAESKey string         `json:"encodingAESKey"` // 设置了为 "***", 没有设置(明文模式)为 ""
+	LastAESKey     string         `json:"lastAESKey"`     // 有效为 "***", 无效为 ""
+	SafeMode       bool           `json:"safeMode"`       // 是否为 SafeModeServer
+	MessageHandler string         `json:"messageHandler"` // MessageHandler 的类型, 比如 *mp.MessageServeMux
+	Handlers       *HandlerConfig `json:"handlers,omitempty"`
+}
+
+// MessageServeMux 里注册的 MessageHandler 的类型.
+type HandlerConfig struct {
+	Messages       map[string]string `json:"messages,omitempty"` // map[MsgType]handler type
+	Events         map[string]string `json:"events,omitempty"`   // map[EventType]handler type
+	DefaultMessage string            `json:"defaultMessage,omitempty"`
+	DefaultEvent   string            `json:"defaultEvent,omitempty"`
+}
+
+// 获取脱敏后的配置.
+//  Token 和 AES Key 只返回是否设置, 不会返回任何部分内容.
+func (srv *DefaultServer) Config() *ServerConfig {
+	config := &ServerConfig{
+		OriId:          srv.oriId,
+		AppId:          srv.appId,
+		MessageHandler: handlerName(srv.messageHandler),
+	}
+	if srv.Token() != "" {
+		config.Token = redacted
+	}
+	if currentAESKey := srv.CurrentAESKey(); currentAESKey != [32]byte{} {
+		config.EncodingAESKey = redacted
+	}
+	if _, valid := srv.LastAESKey(); valid {
+		config.LastAESKey = redacted
+	}
+	if mux, ok := srv.messageHandler.(*MessageServeMux); ok {
+		config.Handlers = mux.handlerConfig()
+	}
+	return config
+}
+
+// 把 Config() 编码成 JSON, 可以用于 /debug/config 这样的调试接口.
+func (srv *DefaultServer) ConfigJSON() ([]byte, error) {
+	return json.Marshal(srv.Config())
+}
+
+// 获取脱敏后的配置, SafeMode 为 true.
+func (srv *SafeModeServer) Config() *ServerConfig {
+	config := srv.DefaultServer.Config()
+	config.SafeMode = true
+	return config
+}
+
+// 把 Config() 编码成 JSON, 可以用于 /debug/config 这样的调试接口.
+func (srv *SafeModeServer) ConfigJSON() ([]byte, error) {
+	return json.Marshal(srv.Config())
+}
+
+func (mux *MessageServeMux) handlerConfig() *HandlerConfig {
+	mux.rwmutex.RLock()
+	defer mux.rwmutex.RUnlock()
+
+	config := &HandlerConfig{
+		DefaultMessage: handlerName(mux.defaultMessageHandler),
+		DefaultEvent:   handlerName(mux.defaultEventHandler),
+	}
+	if len(mux.messageHandlerMap) > 0 {
+		config.Messages = make(map[string]string, len(mux.messageHandlerMap))
+		for msgType, handler := range mux.messageHandlerMap {
+			config.Messages[msgType] = handlerName(handler)
+		}
+	}
+	if len(mux.eventHandlerMap) > 0 {
+		config.Events = make(map[string]string, len(mux.eventHandlerMap))
+		for eventType, handler := range mux.eventHandlerMap {
+			config.Events[eventType] = handlerName(handler)
+		}
+	}
+	return config
+}
+
+func handlerName(handler MessageHandler) string {
+	if handler == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", handler)
+}
@@ -0,0 +1,75 @@
+package mp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"testing"
+)
+
+func TestDefaultServerConfigJSONRedacted(t *testing.T) {
+	const token = "s3cr3tT0kenValue"
+	aesKey := []byte("0123456789abcdefghijklmnopqrstuv")
+	newAESKey := []byte("vutsrqponmlkjihgfedcba9876543210")
+
+	mux := NewMessageServeMux()
+	mux.MessageHandleFunc("text", func(http.ResponseWriter, *Request) {})
+	mux.EventHandleFunc("subscribe", func(http.ResponseWriter, *Request) {})
+
+	servers := []*DefaultServer{
+		NewDefaultServer("gh_xxx", token, "wx123", nil, mux),
+		NewDefaultServer("gh_xxx", token, "wx123", aesKey, mux),
+		NewDefaultServer("", token, "", aesKey, MessageHandlerFunc(func(http.ResponseWriter, *Request) {})),
+	}
+	rotated := NewDefaultServer("gh_xxx", token, "wx123", aesKey, mux)
+	if err := rotated.UpdateAESKey(newAESKey); err != nil {
+		t.Fatal(err)
+	}
+	servers = append(servers, rotated)
+
+	var secrets [][]byte
+	for _, secret := range [][]byte{[]byte(token), aesKey, newAESKey} {
+		secrets = append(secrets, secret, []byte(base64.StdEncoding.EncodeToString(secret)))
+		// 部分内容也不能出现
+		secrets = append(secrets, secret[:len(secret)/2], secret[len(secret)/2:])
+	}
+
+	for i, srv := range servers {
+		data, err := srv.ConfigJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range secrets {
+			if bytes.Contains(data, secret) {
+				t.Errorf("server %d: ConfigJSON() = %s, contains %q", i, data, secret)
+			}
+		}
+		if !bytes.Contains(data, []byte(`"token":"***"`)) {
+			t.Errorf("server %d: ConfigJSON() = %s, token not redacted", i, data)
+		}
+	}
+
+	safe, err := NewSafeModeServer("gh_xxx", token, "wx123", base64.StdEncoding.EncodeToString(aesKey)[:43], mux)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := safe.ConfigJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range secrets {
+		if bytes.Contains(data, secret) {
+			t.Errorf("SafeModeServer: ConfigJSON() = %s, contains %q", data, secret)
+		}
+	}
+	if !bytes.Contains(data, []byte(`"safeMode":true`)) {
+		t.Errorf("SafeModeServer: ConfigJSON() = %s, want safeMode true", data)
+	}
+
+	data, _ = servers[1].ConfigJSON()
+	for _, want := range []string{`"encodingAESKey":"***"`, `"text":"mp.MessageHandlerFunc"`, `"subscribe":"mp.MessageHandlerFunc"`} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("ConfigJSON() = %s, want %s", data, want)
+		}
+	}
+}
@@ -15,6 +15,9 @@ type ServerFrontend struct {
 	server      Server
 	errHandler  ErrorHandler
 	interceptor Interceptor
+
+	routeMux *http.ServeMux                     // AddRoute 注册的路由, 可能为 nil
+	routes   map[string]map[string]http.Handler // map[path]map[method]http.Handler
 }
 
 // NOTE: errHandler, interceptor 均可以为 nil
@@ -33,8 +36,63 @@ func NewServerFrontend(server Server, errHandler ErrorHandler, interceptor Inter
 	}
 }
 
+// 注册一个和公众号回调共用 ServerFrontend 的路由, 方便简单的部署不需要在外面再套一层 http.ServeMux.
+//
+//  method: http 方法, 比如 "GET", "POST", 为空表示匹配所有方法
+//  path:   路由规则, 和 http.ServeMux 的 pattern 一样
+//
+//  NOTE:
+//  1. 带有 signature 或者 msg_signature 查询参数的请求总是按照公众号回调处理, 即使 path 匹配到注册的路由,
+//     所以 AddRoute("", "/", h) 这样覆盖了回调 URL 的路由也不会截获微信服务器的请求;
+//  2. 其他请求匹配到注册的路由时交给 h 处理, 不做签名校验, 也不经过 Interceptor; 匹配不到的请求按照公众号回调处理;
+//  3. AddRoute 不是并发安全的, 需要在开始服务之前调用.
+func (frontend *ServerFrontend) AddRoute(method, path string, h http.Handler) {
+	if h == nil {
+		panic("nil http.Handler")
+	}
+
+	if frontend.routeMux == nil {
+		frontend.routeMux = http.NewServeMux()
+		frontend.routes = make(map[string]map[string]http.Handler)
+	}
+	methods, ok := frontend.routes[path]
+	if !ok {
+		methods = make(map[string]http.Handler)
+		frontend.routes[path] = methods
+		frontend.routeMux.Handle(path, http.NotFoundHandler()) // 只用于匹配 path
+	}
+	methods[method] = h
+}
+
+// 微信服务器的回调请求(验证 URL 和推送消息)总是带有签名参数.
+func isCallbackRequest(queryValues url.Values) bool {
+	return queryValues.Get("signature") != "" || queryValues.Get("msg_signature") != ""
+}
+
+// 查找 r 匹配的 AddRoute 注册的 http.Handler, 没有找到返回 nil.
+func (frontend *ServerFrontend) routeHandler(r *http.Request) http.Handler {
+	if frontend.routeMux == nil {
+		return nil
+	}
+	_, pattern := frontend.routeMux.Handler(r)
+	if pattern == "" {
+		return nil
+	}
+	methods := frontend.routes[pattern]
+	if h := methods[r.Method]; h != nil {
+		return h
+	}
+	return methods[""]
+}
+
 func (frontend *ServerFrontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	queryValues, err := url.ParseQuery(r.URL.RawQuery)
+	if !isCallbackRequest(queryValues) {
+		if h := frontend.routeHandler(r); h != nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+	}
 	if err != nil {
 		frontend.errHandler.ServeError(w, r, err)
 		return
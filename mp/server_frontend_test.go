@@ -0,0 +1,41 @@
+package mp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+func TestServerFrontendAddRoute(t *testing.T) {
+	srv := NewDefaultServer("", "token", "", nil, NewMessageServeMux())
+	frontend := NewServerFrontend(srv, nil, nil)
+	// 覆盖了所有 path 的路由
+	frontend.AddRoute("", "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "route")
+	}))
+
+	query := url.Values{
+		"signature": {util.Sign("token", "1348831860", "nonce")},
+		"timestamp": {"1348831860"},
+		"nonce":     {"nonce"},
+		"echostr":   {"echostr"},
+	}
+	for _, tc := range []struct {
+		target string
+		want   string
+	}{
+		{"/api/users", "route"},
+		{"/wechat/callback", "route"},
+		{"/wechat/callback?" + query.Encode(), "echostr"}, // 验证 URL 的请求不能被路由截获
+	} {
+		w := httptest.NewRecorder()
+		frontend.ServeHTTP(w, httptest.NewRequest("GET", tc.target, nil))
+		if have := w.Body.String(); have != tc.want {
+			t.Errorf("GET %s, have: %q, want: %q", tc.target, have, tc.want)
+		}
+	}
+}
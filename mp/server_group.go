@@ -0,0 +1,126 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ServerGroup 管理多个公众号的 Server, 按照 AppId 索引.
+//
+//  和 MultiServerFrontend 不同, ServerGroup 不要求回调 URL 上带有固定的查询参数,
+//  由 Handler 的 appIdExtractor 从请求中取出 AppId, 比如从 URL 路径里取.
+//
+//  NOTE: 所有的 Server 共用本包的 buffer pool, 处理消息的 Request 每次请求都是新分配的,
+//  不会在 Server 之间复用.
+//
+//  ServerGroup 并发安全, 可以在运行中动态增加和删除 Server.
+type ServerGroup struct {
+	errHandler ErrorHandler
+
+	rwmutex   sync.RWMutex
+	serverMap map[string]Server
+}
+
+// NOTE: errHandler 可以为 nil
+func NewServerGroup(errHandler ErrorHandler) *ServerGroup {
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+	return &ServerGroup{
+		errHandler: errHandler,
+		serverMap:  make(map[string]Server),
+	}
+}
+
+// 创建一个 DefaultServer 并且注册到 ServerGroup, 参数和 NewDefaultServer 一样.
+//  如果已经有相同 appId 的 Server, 会被替换掉.
+func (group *ServerGroup) AddServer(oriId, token, appId string, aesKey []byte, handler MessageHandler) (srv *DefaultServer, err error) {
+	if appId == "" {
+		err = errors.New("empty appId")
+		return
+	}
+	srv = NewDefaultServer(oriId, token, appId, aesKey, handler)
+
+	group.rwmutex.Lock()
+	group.serverMap[appId] = srv
+	group.rwmutex.Unlock()
+	return
+}
+
+// 注册一个已经创建好的 Server, 以 server.AppId() 作为索引.
+func (group *ServerGroup) SetServer(server Server) (err error) {
+	if server == nil {
+		return errors.New("nil Server")
+	}
+	appId := server.AppId()
+	if appId == "" {
+		return errors.New("empty AppId")
+	}
+
+	group.rwmutex.Lock()
+	group.serverMap[appId] = server
+	group.rwmutex.Unlock()
+	return
+}
+
+func (group *ServerGroup) DeleteServer(appId string) {
+	group.rwmutex.Lock()
+	delete(group.serverMap, appId)
+	group.rwmutex.Unlock()
+}
+
+// 获取 appId 对应的 Server, 没有找到返回 nil.
+func (group *ServerGroup) Server(appId string) Server {
+	group.rwmutex.RLock()
+	server := group.serverMap[appId]
+	group.rwmutex.RUnlock()
+	return server
+}
+
+// 返回处理回调请求的 http.Handler.
+//  appIdExtractor: 从请求中获取 AppId, 比如从 URL 路径 /wechat/{appid} 中获取
+func (group *ServerGroup) Handler(appIdExtractor func(r *http.Request) string) http.Handler {
+	if appIdExtractor == nil {
+		panic("nil appIdExtractor")
+	}
+	return &serverGroupHandler{
+		group:          group,
+		appIdExtractor: appIdExtractor,
+	}
+}
+
+type serverGroupHandler struct {
+	group          *ServerGroup
+	appIdExtractor func(r *http.Request) string
+}
+
+func (handler *serverGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	errHandler := handler.group.errHandler
+
+	appId := handler.appIdExtractor(r)
+	if appId == "" {
+		errHandler.ServeError(w, r, errors.New("the AppId extracted from request is empty"))
+		return
+	}
+
+	server := handler.group.Server(appId)
+	if server == nil {
+		errHandler.ServeError(w, r, fmt.Errorf("Not found Server for AppId == %s", appId))
+		return
+	}
+
+	queryValues, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errHandler.ServeError(w, r, err)
+		return
+	}
+	ServeHTTP(w, r, queryValues, server, errHandler)
+}
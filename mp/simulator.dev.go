@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build wechatdev
+
+package mp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// Simulator 在本地模拟微信服务器推送消息(事件), 用于开发扫码关注等需要真机操作的流程, 不需要测试号:
+//  sim := mp.NewSimulator(srv)
+//  resp, err := sim.SimulateScan("o_user_openid", "login_123") // 已关注用户扫描带参数二维码
+//  resp, err = sim.SimulateSubscribeByScan("o_user_openid", "login_123") // 未关注用户扫码并关注
+//
+//  消息以明文模式签名之后直接交给 ServeHTTP 处理, 返回的 *http.Response 是 MessageHandler 的回复.
+//  NOTE: 只在 wechatdev 这个 build tag 下编译(go build -tags wechatdev); SafeModeServer 不接受明文模式, 不能用于模拟.
+type Simulator struct {
+	srv Server
+}
+
+func NewSimulator(srv Server) *Simulator {
+	if srv == nil {
+		panic("nil Server")
+	}
+	return &Simulator{
+		srv: srv,
+	}
+}
+
+type simulatedMessage struct {
+	XMLName struct{} `xml:"xml"`
+	MessageHeader
+
+	MsgId   int64  `xml:"MsgId,omitempty"`
+	Content string `xml:"Content,omitempty"`
+
+	Event     string  `xml:"Event,omitempty"`
+	EventKey  string  `xml:"EventKey,omitempty"`
+	Ticket    string  `xml:"Ticket,omitempty"`
+	Latitude  float64 `xml:"Latitude,omitempty"`
+	Longitude float64 `xml:"Longitude,omitempty"`
+	Precision float64 `xml:"Precision,omitempty"`
+}
+
+func (sim *Simulator) newMessage(fromUser, msgType string) *simulatedMessage {
+	return &simulatedMessage{
+		MessageHeader: MessageHeader{
+			ToUserName:   sim.srv.OriId(),
+			FromUserName: fromUser,
+			CreateTime:   time.Now().Unix(),
+			MsgType:      msgType,
+		},
+	}
+}
+
+// 模拟已关注的用户扫描带参数二维码, scene 为二维码的场景值.
+func (sim *Simulator) SimulateScan(fromUser, scene string) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "event")
+	msg.Event = "SCAN"
+	msg.EventKey = scene
+	msg.Ticket = randomString()
+	return sim.post(msg)
+}
+
+// 模拟未关注的用户扫描带参数二维码并关注, EventKey 为 qrscene_ 加上 scene.
+func (sim *Simulator) SimulateSubscribeByScan(fromUser, scene string) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "event")
+	msg.Event = "subscribe"
+	msg.EventKey = "qrscene_" + scene
+	msg.Ticket = randomString()
+	return sim.post(msg)
+}
+
+// 模拟用户关注.
+func (sim *Simulator) SimulateSubscribe(fromUser string) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "event")
+	msg.Event = "subscribe"
+	return sim.post(msg)
+}
+
+// 模拟用户取消关注.
+func (sim *Simulator) SimulateUnsubscribe(fromUser string) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "event")
+	msg.Event = "unsubscribe"
+	return sim.post(msg)
+}
+
+// 模拟用户发送文本消息.
+func (sim *Simulator) SimulateText(fromUser, content string) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "text")
+	msg.MsgId = time.Now().UnixNano()
+	msg.Content = content
+	return sim.post(msg)
+}
+
+// 模拟上报地理位置事件.
+func (sim *Simulator) SimulateLocation(fromUser string, latitude, longitude, precision float64) (*http.Response, error) {
+	msg := sim.newMessage(fromUser, "event")
+	msg.Event = "LOCATION"
+	msg.Latitude = latitude
+	msg.Longitude = longitude
+	msg.Precision = precision
+	return sim.post(msg)
+}
+
+func (sim *Simulator) post(msg *simulatedMessage) (resp *http.Response, err error) {
+	if msg.FromUserName == "" {
+		err = errors.New("empty fromUser")
+		return
+	}
+	body, err := xml.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	timestamp := strconv.FormatInt(msg.CreateTime, 10)
+	nonce := randomString()
+
+	queryValues := make(url.Values)
+	queryValues.Set("signature", util.Sign(sim.srv.Token(), timestamp, nonce))
+	queryValues.Set("timestamp", timestamp)
+	queryValues.Set("nonce", nonce)
+
+	httpReq, err := http.NewRequest("POST", "/?"+queryValues.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	errHandler := ErrorHandlerFunc(func(_ http.ResponseWriter, _ *http.Request, e error) {
+		err = e
+	})
+	recorder := httptest.NewRecorder()
+	ServeHTTP(recorder, httpReq, queryValues, sim.srv, errHandler)
+	if err != nil {
+		return
+	}
+	resp = recorder.Result()
+	return
+}
+
+func randomString() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
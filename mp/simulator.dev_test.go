@@ -0,0 +1,35 @@
+// +build wechatdev
+
+package mp
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestSimulatorScan(t *testing.T) {
+	var have *MixedMessage
+	handler := MessageHandlerFunc(func(w http.ResponseWriter, r *Request) {
+		have = r.MixedMsg
+		io.WriteString(w, "success")
+	})
+	srv := NewDefaultServer("gh_123456789abc", "token", "", nil, handler)
+	sim := NewSimulator(srv)
+
+	resp, err := sim.SimulateSubscribeByScan("o_user", "login_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "success" {
+		t.Errorf("response body, have: %q, want: %q", body, "success")
+	}
+	if have == nil {
+		t.Fatal("MessageHandler not called")
+	}
+	if have.Event != "subscribe" || have.EventKey != "qrscene_login_1" || have.Ticket == "" || have.ToUserName != "gh_123456789abc" {
+		t.Errorf("unexpected message: %+v", have)
+	}
+}
@@ -0,0 +1,219 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// 消息不满足微信文档约束的错误
+type ConstraintError struct {
+	Field      string // 字段名, 和 XML 里的名字一样, 比如 CreateTime
+	Constraint string // 约束的描述
+	Got        string // 实际的值
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Field + " " + e.Constraint + ", got: " + strconv.Quote(e.Got)
+}
+
+// 一个字段约束.
+//  msgType 不为空时只对这个类型的消息做检查.
+type constraint struct {
+	msgType string
+	field   string
+	value   func(msg *MixedMessage) string
+	check   func(msg *MixedMessage) bool
+	desc    string
+}
+
+const minCreateTime = 1262304000 // 2010-01-01 00:00:00 UTC
+
+var strictConstraints = []constraint{
+	{
+		field: "ToUserName",
+		value: func(msg *MixedMessage) string { return msg.ToUserName },
+		check: func(msg *MixedMessage) bool { return msg.ToUserName != "" },
+		desc:  "must not be empty",
+	},
+	{
+		field: "FromUserName",
+		value: func(msg *MixedMessage) string { return msg.FromUserName },
+		check: func(msg *MixedMessage) bool { return msg.FromUserName != "" },
+		desc:  "must not be empty",
+	},
+	{
+		field: "CreateTime",
+		value: func(msg *MixedMessage) string { return strconv.FormatInt(msg.CreateTime, 10) },
+		check: func(msg *MixedMessage) bool { return msg.CreateTime > minCreateTime },
+		desc:  "must be a Unix timestamp after 2010-01-01",
+	},
+	{
+		field: "MsgType",
+		value: func(msg *MixedMessage) string { return msg.MsgType },
+		check: func(msg *MixedMessage) bool {
+			switch msg.MsgType {
+			case "text", "image", "voice", "video", "shortvideo", "location", "link", "event":
+				return true
+			}
+			return false
+		},
+		desc: "must be one of text, image, voice, video, shortvideo, location, link, event",
+	},
+	{
+		field: "MsgId",
+		value: func(msg *MixedMessage) string { return strconv.FormatInt(msg.MsgId, 10) },
+		check: func(msg *MixedMessage) bool { return msg.MsgType == "event" || msg.MsgId > 0 },
+		desc:  "must be a positive 64-bit integer for non-event messages",
+	},
+	{
+		msgType: "event",
+		field:   "Event",
+		value:   func(msg *MixedMessage) string { return msg.Event },
+		check:   func(msg *MixedMessage) bool { return msg.Event != "" },
+		desc:    "must not be empty for event messages",
+	},
+	{
+		msgType: "text",
+		field:   "Content",
+		value:   func(msg *MixedMessage) string { return msg.Content },
+		check:   func(msg *MixedMessage) bool { return msg.Content != "" },
+		desc:    "must not be empty for text messages",
+	},
+	{
+		msgType: "image",
+		field:   "PicUrl",
+		value:   func(msg *MixedMessage) string { return msg.PicURL },
+		check:   func(msg *MixedMessage) bool { return msg.PicURL != "" },
+		desc:    "must not be empty for image messages",
+	},
+	{
+		msgType: "image",
+		field:   "MediaId",
+		value:   func(msg *MixedMessage) string { return msg.MediaId },
+		check:   func(msg *MixedMessage) bool { return msg.MediaId != "" },
+		desc:    "must not be empty for image messages",
+	},
+	{
+		msgType: "voice",
+		field:   "MediaId",
+		value:   func(msg *MixedMessage) string { return msg.MediaId },
+		check:   func(msg *MixedMessage) bool { return msg.MediaId != "" },
+		desc:    "must not be empty for voice messages",
+	},
+	{
+		msgType: "voice",
+		field:   "Format",
+		value:   func(msg *MixedMessage) string { return msg.Format },
+		check:   func(msg *MixedMessage) bool { return msg.Format != "" },
+		desc:    "must not be empty for voice messages",
+	},
+	{
+		msgType: "video",
+		field:   "MediaId",
+		value:   func(msg *MixedMessage) string { return msg.MediaId },
+		check:   func(msg *MixedMessage) bool { return msg.MediaId != "" },
+		desc:    "must not be empty for video messages",
+	},
+	{
+		msgType: "video",
+		field:   "ThumbMediaId",
+		value:   func(msg *MixedMessage) string { return msg.ThumbMediaId },
+		check:   func(msg *MixedMessage) bool { return msg.ThumbMediaId != "" },
+		desc:    "must not be empty for video messages",
+	},
+	{
+		msgType: "shortvideo",
+		field:   "MediaId",
+		value:   func(msg *MixedMessage) string { return msg.MediaId },
+		check:   func(msg *MixedMessage) bool { return msg.MediaId != "" },
+		desc:    "must not be empty for shortvideo messages",
+	},
+	{
+		msgType: "shortvideo",
+		field:   "ThumbMediaId",
+		value:   func(msg *MixedMessage) string { return msg.ThumbMediaId },
+		check:   func(msg *MixedMessage) bool { return msg.ThumbMediaId != "" },
+		desc:    "must not be empty for shortvideo messages",
+	},
+	{
+		msgType: "location",
+		field:   "Location_X",
+		value:   func(msg *MixedMessage) string { return strconv.FormatFloat(msg.LocationX, 'f', -1, 64) },
+		check:   func(msg *MixedMessage) bool { return -90 <= msg.LocationX && msg.LocationX <= 90 },
+		desc:    "must be a latitude in [-90, 90]",
+	},
+	{
+		msgType: "location",
+		field:   "Location_Y",
+		value:   func(msg *MixedMessage) string { return strconv.FormatFloat(msg.LocationY, 'f', -1, 64) },
+		check:   func(msg *MixedMessage) bool { return -180 <= msg.LocationY && msg.LocationY <= 180 },
+		desc:    "must be a longitude in [-180, 180]",
+	},
+	{
+		msgType: "link",
+		field:   "Url",
+		value:   func(msg *MixedMessage) string { return msg.URL },
+		check:   func(msg *MixedMessage) bool { return msg.URL != "" },
+		desc:    "must not be empty for link messages",
+	},
+}
+
+// 按照微信文档的字段约束严格校验消息, 返回所有不满足的约束, 全部满足时返回 nil.
+//  NOTE: 事件类型太多而且经常增加, 这里只检查 Event 不为空, 不检查具体的取值.
+func StrictValidate(r *Request) (errs []*ConstraintError) {
+	if r == nil || r.MixedMsg == nil {
+		return []*ConstraintError{{Field: "xml", Constraint: "must not be empty"}}
+	}
+
+	msg := r.MixedMsg
+	for i := range strictConstraints {
+		c := &strictConstraints[i]
+		if c.msgType != "" && c.msgType != msg.MsgType {
+			continue
+		}
+		if !c.check(msg) {
+			errs = append(errs, &ConstraintError{
+				Field:      c.field,
+				Constraint: c.desc,
+				Got:        c.value(msg),
+			})
+		}
+	}
+	return
+}
+
+var _ MessageHandler = (*StrictValidator)(nil)
+
+// StrictValidator 先用 StrictValidate 校验消息, 校验通过后交给后端的 MessageHandler 处理.
+//  校验失败时记录日志并返回 400, 不会调用后端的 MessageHandler.
+//
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, NewStrictValidator(messageServeMux))
+type StrictValidator struct {
+	handler MessageHandler
+}
+
+func NewStrictValidator(handler MessageHandler) *StrictValidator {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	return &StrictValidator{
+		handler: handler,
+	}
+}
+
+// StrictValidator 实现了 MessageHandler 接口.
+func (validator *StrictValidator) ServeMessage(w http.ResponseWriter, r *Request) {
+	if errs := StrictValidate(r); len(errs) > 0 {
+		for _, err := range errs {
+			LogInfoln("[WECHAT_STRICT_VALIDATE]", err)
+		}
+		http.Error(w, errs[0].Error(), http.StatusBadRequest)
+		return
+	}
+	validator.handler.ServeMessage(w, r)
+}
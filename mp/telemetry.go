@@ -0,0 +1,162 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// 观测消息(事件)处理的钩子, 用于对接 DataDog, Sentry 这样有自己客户端的系统.
+//
+//  钩子在处理消息的 goroutine 里同步调用, 实现需要尽快返回, 比较慢的操作请自己放到后台处理.
+type TelemetryHook interface {
+	OnRequest(msgType, eventType string)                         // 开始处理消息之前调用
+	OnResponse(msgType, eventType string, latency time.Duration) // 处理完消息之后调用
+	OnError(kind string, err error)                              // 出错的时候调用, kind 为错误的来源, 比如 "serve_http"
+}
+
+var _ MessageHandler = (*TelemetryHandler)(nil)
+
+// TelemetryHandler 在调用后端 MessageHandler 前后调用 TelemetryHook, 钩子 panic 的时候只记录日志, 不影响消息的处理:
+//
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, NewTelemetryHandler(messageServeMux, hook))
+//  frontend := NewServerFrontend(srv, NewTelemetryErrorHandler(errHandler, hook), nil)
+type TelemetryHandler struct {
+	handler MessageHandler
+	hook    TelemetryHook
+}
+
+// 创建一个新的 TelemetryHandler, hook 为 nil 时使用 NopHook.
+func NewTelemetryHandler(handler MessageHandler, hook TelemetryHook) *TelemetryHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if hook == nil {
+		hook = NopHook
+	}
+	return &TelemetryHandler{
+		handler: handler,
+		hook:    hook,
+	}
+}
+
+// TelemetryHandler 实现了 MessageHandler 接口.
+func (h *TelemetryHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	var msgType, eventType string
+	if r.MixedMsg != nil {
+		msgType, eventType = r.MixedMsg.MsgType, r.MixedMsg.Event
+	}
+
+	callHook(func() { h.hook.OnRequest(msgType, eventType) })
+	start := time.Now()
+	h.handler.ServeMessage(w, r)
+	latency := time.Since(start)
+	callHook(func() { h.hook.OnResponse(msgType, eventType, latency) })
+}
+
+// 返回一个先调用 hook.OnError("serve_http", err) 再调用 errHandler 的 ErrorHandler.
+//
+//  errHandler 为 nil 时使用 DefaultErrorHandler.
+func NewTelemetryErrorHandler(errHandler ErrorHandler, hook TelemetryHook) ErrorHandler {
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+	if hook == nil {
+		hook = NopHook
+	}
+	return ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		callHook(func() { hook.OnError("serve_http", err) })
+		errHandler.ServeError(w, r, err)
+	})
+}
+
+// 调用钩子, 钩子 panic 的时候只记录日志.
+func callHook(fn func()) {
+	defer func() {
+		if v := recover(); v != nil {
+			LogInfoln("[WECHAT_TELEMETRY_HOOK]", v, string(debug.Stack()))
+		}
+	}()
+	fn()
+}
+
+// 什么都不做的 TelemetryHook.
+var NopHook TelemetryHook = nopHook{}
+
+type nopHook struct{}
+
+func (nopHook) OnRequest(msgType, eventType string)                         {}
+func (nopHook) OnResponse(msgType, eventType string, latency time.Duration) {}
+func (nopHook) OnError(kind string, err error)                              {}
+
+// 返回依次调用 hooks 的 TelemetryHook, nil 会被忽略.
+//
+//  一个钩子 panic 不影响后面的钩子.
+func MultiHook(hooks ...TelemetryHook) TelemetryHook {
+	var list multiHook
+	for _, hook := range hooks {
+		if hook != nil {
+			list = append(list, hook)
+		}
+	}
+	return list
+}
+
+type multiHook []TelemetryHook
+
+func (hooks multiHook) OnRequest(msgType, eventType string) {
+	for _, hook := range hooks {
+		hook := hook
+		callHook(func() { hook.OnRequest(msgType, eventType) })
+	}
+}
+
+func (hooks multiHook) OnResponse(msgType, eventType string, latency time.Duration) {
+	for _, hook := range hooks {
+		hook := hook
+		callHook(func() { hook.OnResponse(msgType, eventType, latency) })
+	}
+}
+
+func (hooks multiHook) OnError(kind string, err error) {
+	for _, hook := range hooks {
+		hook := hook
+		callHook(func() { hook.OnError(kind, err) })
+	}
+}
+
+// 返回用 logln 记录日志的 TelemetryHook, 可以作为实现其他钩子的参考.
+//
+//  logln 为 nil 时使用 LogInfoln.
+func LoggingHook(logln func(v ...interface{})) TelemetryHook {
+	return loggingHook{logln: logln}
+}
+
+type loggingHook struct {
+	logln func(v ...interface{})
+}
+
+func (hook loggingHook) log(v ...interface{}) {
+	if hook.logln != nil {
+		hook.logln(v...)
+		return
+	}
+	LogInfoln(v...)
+}
+
+func (hook loggingHook) OnRequest(msgType, eventType string) {
+	hook.log("[WECHAT_TELEMETRY] request msgType:", msgType, "eventType:", eventType)
+}
+
+func (hook loggingHook) OnResponse(msgType, eventType string, latency time.Duration) {
+	hook.log("[WECHAT_TELEMETRY] response msgType:", msgType, "eventType:", eventType, "latency:", latency)
+}
+
+func (hook loggingHook) OnError(kind string, err error) {
+	hook.log("[WECHAT_TELEMETRY] error kind:", kind, "err:", err)
+}
@@ -0,0 +1,174 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 保存用户的语言, 避免每条消息都调用获取用户基本信息接口.
+type LocaleStore interface {
+	// 获取 openId 的语言, 没有保存过返回空字符串.
+	GetLocale(openId string) (locale string, err error)
+	SetLocale(openId, locale string) error
+}
+
+type localeContextKey struct{}
+
+// 获取 LocaleHandler 保存的用户语言, 没有保存时返回空字符串.
+func LocaleFromRequest(r *mp.Request) string {
+	if r == nil || r.HttpRequest == nil {
+		return ""
+	}
+	locale, _ := r.HttpRequest.Context().Value(localeContextKey{}).(string)
+	return locale
+}
+
+// 返回一个先确定用户的语言, 再调用 handler 的 mp.MessageHandler, handler 里可以用 LocaleFromRequest 获取用户的语言,
+// 按照 zh_CN, zh_TW, en 等生成不同语言的回复:
+//  srv := mp.NewDefaultServer(oriId, token, appId, aesKey, user.NewLocaleHandler(messageServeMux, clt, user.Language_zh_CN, store))
+//
+//  用户的第一条消息会调用 UserInfo 获取用户的 language 并且保存到 store, 之后的消息直接使用 store 里的语言.
+//  store 或者 UserInfo 出错, 以及用户没有关注公众号(获取不到 language)的时候使用 defaultLocale, 这时不保存到 store;
+//  没有关注的用户在 LocaleNotFoundTTL 内不再调用 UserInfo, 直接使用 defaultLocale, 收到 subscribe 事件时重新获取.
+//
+//  NOTE: 每个用户的第一条消息会多一次接口调用, 增加回复的耗时; r.HttpRequest 为 nil 的时候直接调用 handler.
+func NewLocaleHandler(handler mp.MessageHandler, clt *Client, defaultLocale string, store LocaleStore) mp.MessageHandler {
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+	if clt == nil {
+		panic("nil Client")
+	}
+	if store == nil {
+		panic("nil LocaleStore")
+	}
+	notFound := &localeNotFoundCache{
+		expiresAt: make(map[string]time.Time),
+	}
+	return mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		if r.HttpRequest == nil || r.MixedMsg == nil {
+			handler.ServeMessage(w, r)
+			return
+		}
+
+		openId := r.MixedMsg.FromUserName
+		if r.MixedMsg.MsgType == "event" && r.MixedMsg.Event == "subscribe" {
+			notFound.Delete(openId)
+		}
+		locale := userLocale(clt, store, notFound, openId)
+		if locale == "" {
+			locale = defaultLocale
+		}
+		req := *r
+		req.HttpRequest = r.HttpRequest.WithContext(context.WithValue(r.HttpRequest.Context(), localeContextKey{}, locale))
+		handler.ServeMessage(w, &req)
+	})
+}
+
+func userLocale(clt *Client, store LocaleStore, notFound *localeNotFoundCache, openId string) string {
+	locale, err := store.GetLocale(openId)
+	if err != nil {
+		mp.LogInfoln("[WECHAT_LOCALE]", err)
+		return ""
+	}
+	if locale != "" {
+		return locale
+	}
+	if notFound.Contains(openId) {
+		return ""
+	}
+
+	info, err := clt.UserInfo(openId, "")
+	if err != nil {
+		mp.LogInfoln("[WECHAT_LOCALE]", err)
+		return ""
+	}
+	if info.IsSubscriber == 0 || info.Language == "" { // 没有关注
+		notFound.Add(openId)
+		return ""
+	}
+	if err = store.SetLocale(openId, info.Language); err != nil {
+		mp.LogInfoln("[WECHAT_LOCALE]", err)
+	}
+	return info.Language
+}
+
+// 没有关注的用户获取不到 language, 多久之内不再调用 UserInfo.
+//  没有关注的用户也可以发送消息(比如从客服会话或者模板消息进入), 每条消息都调用 UserInfo 会增加被动回复的耗时.
+const LocaleNotFoundTTL = 10 * time.Minute
+
+// 记录获取不到 language 的用户, 每个用户在 LocaleNotFoundTTL 之后失效.
+type localeNotFoundCache struct {
+	mutex     sync.Mutex
+	expiresAt map[string]time.Time // map[openId]expiresAt
+	lastPrune time.Time
+}
+
+func (cache *localeNotFoundCache) Contains(openId string) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	expiresAt, ok := cache.expiresAt[openId]
+	return ok && time.Now().Before(expiresAt)
+}
+
+func (cache *localeNotFoundCache) Add(openId string) {
+	now := time.Now()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// 每隔 LocaleNotFoundTTL 清除一次失效的记录
+	if now.Sub(cache.lastPrune) >= LocaleNotFoundTTL {
+		for k, expiresAt := range cache.expiresAt {
+			if !now.Before(expiresAt) {
+				delete(cache.expiresAt, k)
+			}
+		}
+		cache.lastPrune = now
+	}
+	cache.expiresAt[openId] = now.Add(LocaleNotFoundTTL)
+}
+
+func (cache *localeNotFoundCache) Delete(openId string) {
+	cache.mutex.Lock()
+	delete(cache.expiresAt, openId)
+	cache.mutex.Unlock()
+}
+
+var _ LocaleStore = (*MemoryLocaleStore)(nil)
+
+// 基于内存的 LocaleStore, 只适合单进程部署, 进程重启以后数据会丢失.
+type MemoryLocaleStore struct {
+	rwmutex sync.RWMutex
+	m       map[string]string // map[openId]locale
+}
+
+func NewMemoryLocaleStore() *MemoryLocaleStore {
+	return &MemoryLocaleStore{
+		m: make(map[string]string),
+	}
+}
+
+func (store *MemoryLocaleStore) GetLocale(openId string) (locale string, err error) {
+	store.rwmutex.RLock()
+	locale = store.m[openId]
+	store.rwmutex.RUnlock()
+	return
+}
+
+func (store *MemoryLocaleStore) SetLocale(openId, locale string) error {
+	store.rwmutex.Lock()
+	store.m[openId] = locale
+	store.rwmutex.Unlock()
+	return nil
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type testAccessTokenServer string
+
+func (srv testAccessTokenServer) Token() (string, error)               { return string(srv), nil }
+func (srv testAccessTokenServer) TokenRefresh() (string, error)        { return string(srv), nil }
+func (srv testAccessTokenServer) TagCE90001AFE9C11E48611A4DB30FED8E1() {}
+
+// 把所有请求都转发到 target, 用来模拟微信服务器.
+type testRedirectTransport struct {
+	target *url.URL
+}
+
+func (t testRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestLocaleHandlerNotSubscribed(t *testing.T) {
+	var userInfoCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cgi-bin/user/info" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		userInfoCalls++
+		io.WriteString(w, `{"subscribe":0,"openid":"o_user"}`)
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	clt := NewClient(testAccessTokenServer("ACCESS_TOKEN"), &http.Client{Transport: testRedirectTransport{target}})
+
+	var locale string
+	handler := NewLocaleHandler(mp.MessageHandlerFunc(func(w http.ResponseWriter, r *mp.Request) {
+		locale = LocaleFromRequest(r)
+	}), clt, Language_zh_CN, NewMemoryLocaleStore())
+
+	serve := func(msgType, event string) {
+		handler.ServeMessage(httptest.NewRecorder(), &mp.Request{
+			HttpRequest: httptest.NewRequest("POST", "/", nil),
+			MixedMsg: &mp.MixedMessage{
+				MessageHeader: mp.MessageHeader{FromUserName: "o_user", MsgType: msgType},
+				Event:         event,
+			},
+		})
+	}
+
+	// 没有关注的用户只调用一次 UserInfo
+	serve("text", "")
+	serve("text", "")
+	if userInfoCalls != 1 || locale != Language_zh_CN {
+		t.Errorf("UserInfo calls: %d, locale: %q, want: 1, %q", userInfoCalls, locale, Language_zh_CN)
+	}
+
+	// subscribe 事件重新获取
+	serve("event", "subscribe")
+	if userInfoCalls != 2 {
+		t.Errorf("UserInfo calls after subscribe, have: %d, want: 2", userInfoCalls)
+	}
+}
@@ -0,0 +1,168 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	UserInfoBatchGetLimit = 100 // 批量获取用户基本信息每次最多拉取 100 个用户
+)
+
+type userListFetchResult struct {
+	data *UserListResult
+	err  error
+}
+
+// UserInfoIterator 遍历所有关注者的用户基本信息.
+//  内部用 UserList 分页获取 OPENID 列表, 然后用 UserInfoBatchGet 每次获取 100 个用户的基本信息;
+//  在处理当前页的用户基本信息的同时会预先获取下一页的 OPENID 列表.
+//
+//  iter := Client.UserInfoIterator(user.Language_zh_CN)
+//  for {
+//      info, err := iter.Next()
+//      if err == io.EOF {
+//          break
+//      }
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+//
+//  NOTE: UserInfoIterator 不是并发安全的.
+type UserInfoIterator struct {
+	clt  *Client
+	lang string
+
+	started     bool   // 是否已经获取过第一页的 OPENID 列表
+	hasNextPage bool   // 是否还有下一页的 OPENID 列表
+	nextOpenId  string // 获取下一页 OPENID 列表的 next_openid
+
+	prefetch chan userListFetchResult // 正在预先获取的下一页 OPENID 列表, nil 表示没有
+
+	openIdList   []string   // 当前页还没有获取基本信息的 OPENID 列表
+	userInfoList []UserInfo // 已经获取但还没有返回的用户基本信息
+}
+
+// 获取用户基本信息遍历器, 从头开始遍历所有的关注者.
+//  lang 的取值可以为 "", Language_zh_CN, Language_zh_TW, Language_en
+func (clt *Client) UserInfoIterator(lang string) *UserInfoIterator {
+	return &UserInfoIterator{
+		clt:  clt,
+		lang: lang,
+	}
+}
+
+// 返回下一个用户的基本信息, 没有更多的用户时返回 io.EOF.
+func (iter *UserInfoIterator) Next() (info *UserInfo, err error) {
+	if err = iter.fill(); err != nil {
+		return
+	}
+
+	info = new(UserInfo)
+	*info = iter.userInfoList[0]
+	iter.userInfoList = iter.userInfoList[1:]
+	return
+}
+
+// 返回最多 n 个用户的基本信息, 没有更多的用户时返回 io.EOF.
+func (iter *UserInfoIterator) NextBatch(n int) (UserInfoList []UserInfo, err error) {
+	if n <= 0 {
+		err = errors.New("n must be positive")
+		return
+	}
+
+	for len(UserInfoList) < n {
+		if err = iter.fill(); err != nil {
+			if err == io.EOF && len(UserInfoList) > 0 {
+				err = nil
+			}
+			return
+		}
+
+		m := n - len(UserInfoList)
+		if m > len(iter.userInfoList) {
+			m = len(iter.userInfoList)
+		}
+		UserInfoList = append(UserInfoList, iter.userInfoList[:m]...)
+		iter.userInfoList = iter.userInfoList[m:]
+	}
+	return
+}
+
+// 保证 iter.userInfoList 不为空, 没有更多的用户时返回 io.EOF.
+func (iter *UserInfoIterator) fill() (err error) {
+	for len(iter.userInfoList) == 0 {
+		if len(iter.openIdList) == 0 {
+			if err = iter.nextPage(); err != nil {
+				return
+			}
+		}
+
+		openIdList := iter.openIdList
+		if len(openIdList) > UserInfoBatchGetLimit {
+			openIdList = openIdList[:UserInfoBatchGetLimit]
+		}
+
+		UserInfoList, err := iter.clt.UserInfoBatchGet(NewUserInfoBatchGetRequest(openIdList, iter.lang))
+		if err != nil {
+			return err
+		}
+
+		// 成功后才移除, 出错的话下次调用会重试这一批
+		iter.openIdList = iter.openIdList[len(openIdList):]
+		iter.userInfoList = UserInfoList
+	}
+	return
+}
+
+// 获取下一页非空的 OPENID 列表, 并开始预先获取再下一页, 没有更多的 OPENID 时返回 io.EOF.
+func (iter *UserInfoIterator) nextPage() (err error) {
+	for len(iter.openIdList) == 0 {
+		if iter.started && !iter.hasNextPage {
+			return io.EOF
+		}
+
+		var data *UserListResult
+		if iter.prefetch != nil {
+			result := <-iter.prefetch
+			iter.prefetch = nil
+			data, err = result.data, result.err
+		} else {
+			data, err = iter.clt.UserList(iter.nextOpenId)
+		}
+		if err != nil {
+			return // iter.nextOpenId 没有改变, 下次调用会重试这一页
+		}
+
+		iter.started = true
+		iter.openIdList = data.Data.OpenIdList
+
+		// 参考 UserIterator.HasNext 的说明, 微信返回的 next_openid 是列表的最后一个用户,
+		// 只有返回 next_openid == "" 才表示没有更多的用户了
+		iter.nextOpenId = data.NextOpenId
+		iter.hasNextPage = data.NextOpenId != "" && len(data.Data.OpenIdList) > 0
+
+		if iter.hasNextPage {
+			iter.startPrefetch(iter.nextOpenId)
+		}
+	}
+	return
+}
+
+func (iter *UserInfoIterator) startPrefetch(nextOpenId string) {
+	ch := make(chan userListFetchResult, 1) // 带缓冲, 即使没有人接收 goroutine 也能退出
+	iter.prefetch = ch
+
+	clt := iter.clt
+	go func() {
+		data, err := clt.UserList(nextOpenId)
+		ch <- userListFetchResult{data: data, err: err}
+	}()
+}
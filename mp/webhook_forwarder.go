@@ -0,0 +1,276 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 把消息(事件)格式化成 webhook 请求的 JSON 对象.
+type WebhookFormatter func(r *Request) interface{}
+
+var _ MessageHandler = (*WebhookForwarder)(nil)
+
+// WebhookForwarder 把收到的消息(事件)转发到 Discord, Slack, Teams 这样的聊天工具的 webhook, 然后调用后端的 MessageHandler:
+//  forwarder := NewWebhookForwarder(messageServeMux, webhookURL, SlackWebhookFormatter, 100)
+//  srv := NewDefaultServer(oriId, token, appId, aesKey, forwarder)
+//  ...
+//  forwarder.Flush(ctx) // 退出之前把缓冲的消息发送完
+//  forwarder.Close()    // 停止后台的 goroutine
+//
+//  转发在后台的 goroutine 里进行, 不会阻塞 ServeHTTP; 缓冲满了的时候消息直接丢弃.
+//  webhook 返回 429 的时候按照 Retry-After 或者指数退避重试, 最多重试 WebhookMaxRetries 次.
+type WebhookForwarder struct {
+	handler    MessageHandler
+	webhookURL string
+	format     WebhookFormatter
+
+	ch      chan []byte
+	pending int64 // 原子操作, 还没有发送完的个数
+
+	closeMutex sync.RWMutex // 保证 Close 之后不会再有消息进入 ch
+	closed     bool
+	done       chan struct{} // Close 的时候关闭, 通知 loop 退出
+	stopped    chan struct{} // loop 退出的时候关闭
+}
+
+// 429 的时候最多重试的次数
+const WebhookMaxRetries = 5
+
+// 创建一个新的 WebhookForwarder, bufSize 为缓冲的消息个数.
+//  handler 为 nil 时只转发, 回复 "success".
+func NewWebhookForwarder(handler MessageHandler, webhookURL string, format WebhookFormatter, bufSize int) *WebhookForwarder {
+	if webhookURL == "" {
+		panic("empty webhookURL")
+	}
+	if format == nil {
+		panic("nil WebhookFormatter")
+	}
+	if bufSize <= 0 {
+		panic("bufSize must be positive")
+	}
+	forwarder := &WebhookForwarder{
+		handler:    handler,
+		webhookURL: webhookURL,
+		format:     format,
+		ch:         make(chan []byte, bufSize),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	go forwarder.loop()
+	return forwarder
+}
+
+// WebhookForwarder 实现了 MessageHandler 接口.
+func (forwarder *WebhookForwarder) ServeMessage(w http.ResponseWriter, r *Request) {
+	if r.MixedMsg != nil {
+		forwarder.enqueue(r)
+	}
+	if forwarder.handler == nil {
+		io.WriteString(w, "success")
+		return
+	}
+	forwarder.handler.ServeMessage(w, r)
+}
+
+func (forwarder *WebhookForwarder) enqueue(r *Request) {
+	body, err := json.Marshal(forwarder.format(r))
+	if err != nil {
+		LogInfoln("[WECHAT_WEBHOOK_FORWARDER]", err)
+		return
+	}
+
+	forwarder.closeMutex.RLock()
+	defer forwarder.closeMutex.RUnlock()
+
+	if forwarder.closed {
+		LogInfoln("[WECHAT_WEBHOOK_FORWARDER] forwarder is closed, message dropped")
+		return
+	}
+	atomic.AddInt64(&forwarder.pending, 1)
+	select {
+	case forwarder.ch <- body:
+	default:
+		atomic.AddInt64(&forwarder.pending, -1)
+		LogInfoln("[WECHAT_WEBHOOK_FORWARDER] buffer is full, message dropped")
+	}
+}
+
+func (forwarder *WebhookForwarder) loop() {
+	defer close(forwarder.stopped)
+
+	for {
+		// 优先检查 done, 两个都就绪的时候 select 是随机选择的
+		select {
+		case <-forwarder.done:
+			forwarder.drain()
+			return
+		default:
+		}
+
+		select {
+		case <-forwarder.done:
+			forwarder.drain()
+			return
+		case body := <-forwarder.ch:
+			if err := forwarder.post(body); err != nil {
+				LogInfoln("[WECHAT_WEBHOOK_FORWARDER]", err)
+			}
+			atomic.AddInt64(&forwarder.pending, -1)
+		}
+	}
+}
+
+// 丢弃缓冲里还没有发送的消息.
+func (forwarder *WebhookForwarder) drain() {
+	for {
+		select {
+		case <-forwarder.ch:
+			atomic.AddInt64(&forwarder.pending, -1)
+		default:
+			return
+		}
+	}
+}
+
+func (forwarder *WebhookForwarder) post(body []byte) error {
+	backoff := time.Second
+	for i := 0; ; i++ {
+		httpResp, err := TextHttpClient.Post(forwarder.webhookURL, "application/json; charset=utf-8", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		io.Copy(ioutil.Discard, httpResp.Body)
+		httpResp.Body.Close()
+
+		switch {
+		case httpResp.StatusCode/100 == 2:
+			return nil
+		case httpResp.StatusCode != http.StatusTooManyRequests:
+			return fmt.Errorf("http.Status: %s", httpResp.Status)
+		case i >= WebhookMaxRetries:
+			return fmt.Errorf("http.Status: %s, gave up after %d retries", httpResp.Status, i)
+		}
+
+		wait := backoff
+		if seconds, err := strconv.Atoi(httpResp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-forwarder.done:
+			timer.Stop()
+			return fmt.Errorf("http.Status: %s, forwarder is closed", httpResp.Status)
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// 等待缓冲的消息发送完, 或者 ctx 被取消.
+//  一般在程序退出之前, 停止接收新的消息之后调用.
+func (forwarder *WebhookForwarder) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for atomic.LoadInt64(&forwarder.pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// 停止后台转发的 goroutine, 丢弃缓冲里还没有发送的消息, 等待正在发送的请求结束后返回.
+//  需要把缓冲的消息发送完的话先调用 Flush; Close 之后收到的消息只交给后端的 MessageHandler, 不再转发.
+//  可以多次调用.
+func (forwarder *WebhookForwarder) Close() error {
+	forwarder.closeMutex.Lock()
+	if !forwarder.closed {
+		forwarder.closed = true
+		close(forwarder.done)
+	}
+	forwarder.closeMutex.Unlock()
+
+	<-forwarder.stopped
+	return nil
+}
+
+// 消息(事件)的摘要, 用于 webhook 的标题和内容.
+func webhookSummary(r *Request) (title, text string) {
+	msg := r.MixedMsg
+	if msg.MsgType == "event" {
+		title = "event." + msg.Event + " from " + msg.FromUserName
+		text = msg.EventKey
+		return
+	}
+
+	title = msg.MsgType + " from " + msg.FromUserName
+	switch msg.MsgType {
+	case "text":
+		text = msg.Content
+	case "image":
+		text = msg.PicURL
+	case "voice":
+		text = msg.Recognition
+	case "location":
+		text = fmt.Sprintf("%s (%v, %v)", msg.Label, msg.LocationX, msg.LocationY)
+	case "link":
+		text = msg.Title + " " + msg.URL
+	default:
+		text = msg.MediaId
+	}
+	return
+}
+
+// Discord 的 webhook 格式, 每个消息一个 embed.
+func DiscordWebhookFormatter(r *Request) interface{} {
+	title, text := webhookSummary(r)
+	return map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"title":       title,
+				"description": text,
+				"timestamp":   time.Unix(r.MixedMsg.CreateTime, 0).UTC().Format(time.RFC3339),
+				"footer": map[string]string{
+					"text": r.MixedMsg.ToUserName,
+				},
+			},
+		},
+	}
+}
+
+// Slack 的 incoming webhook 格式.
+func SlackWebhookFormatter(r *Request) interface{} {
+	title, text := webhookSummary(r)
+	return map[string]string{
+		"text": "*" + title + "*\n" + text,
+	}
+}
+
+// Microsoft Teams 的 incoming webhook 格式(MessageCard).
+func TeamsWebhookFormatter(r *Request) interface{} {
+	title, text := webhookSummary(r)
+	return map[string]string{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  title,
+		"title":    title,
+		"text":     text,
+	}
+}
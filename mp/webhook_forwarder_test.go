@@ -0,0 +1,58 @@
+package mp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookForwarderClose(t *testing.T) {
+	var posts int32
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		received <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	forwarder := NewWebhookForwarder(nil, server.URL, SlackWebhookFormatter, 10)
+	newRequest := func() *Request {
+		return &Request{MixedMsg: &MixedMessage{MessageHeader: MessageHeader{FromUserName: "o_user", MsgType: "text"}}}
+	}
+	for i := 0; i < 3; i++ {
+		forwarder.ServeMessage(httptest.NewRecorder(), newRequest())
+	}
+	<-received // 第一条消息正在发送, 另外两条在缓冲里
+
+	closed := make(chan struct{})
+	go func() {
+		forwarder.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Close returned before the in-flight request finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-closed
+
+	// Close 之后的消息不再转发, 缓冲的消息被丢弃
+	forwarder.ServeMessage(httptest.NewRecorder(), newRequest())
+	if n := atomic.LoadInt32(&posts); n != 1 {
+		t.Errorf("webhook posts, have: %d, want: 1", n)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := forwarder.Flush(ctx); err != nil {
+		t.Errorf("Flush after Close: %v", err)
+	}
+	if err := forwarder.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wxa
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 插件的申请状态
+const (
+	PluginStatusApplying = 1 // 申请中
+	PluginStatusApproved = 2 // 申请通过
+	PluginStatusRefused  = 3 // 被拒绝
+	PluginStatusTimeout  = 4 // 已超时
+)
+
+// 小程序添加的插件
+type Plugin struct {
+	AppId      string `json:"appid"`      // 插件 appid
+	Status     int    `json:"status"`     // 申请状态, PluginStatusApplying 等
+	Nickname   string `json:"nickname"`   // 插件昵称
+	HeadImgURL string `json:"headimgurl"` // 插件头像
+}
+
+// 申请使用插件.
+//  reason: 申请使用的理由, 可以为空
+func (clt *Client) ApplyPlugin(pluginAppId, reason string) (err error) {
+	if pluginAppId == "" {
+		return errors.New("empty pluginAppId")
+	}
+
+	var request = struct {
+		Action      string `json:"action"`
+		PluginAppId string `json:"plugin_appid"`
+		Reason      string `json:"reason,omitempty"`
+	}{
+		Action:      "apply",
+		PluginAppId: pluginAppId,
+		Reason:      reason,
+	}
+	return clt.postPlugin("https://api.weixin.qq.com/wxa/plugin?access_token=", &request)
+}
+
+// 查询已添加的插件.
+func (clt *Client) GetPluginList() (list []Plugin, err error) {
+	var request = struct {
+		Action string `json:"action"`
+	}{
+		Action: "list",
+	}
+
+	var result struct {
+		mp.Error
+		PluginList []Plugin `json:"plugin_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/plugin?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.PluginList
+	return
+}
+
+// 删除已添加的插件.
+func (clt *Client) UnbindPlugin(pluginAppId string) (err error) {
+	if pluginAppId == "" {
+		return errors.New("empty pluginAppId")
+	}
+
+	var request = struct {
+		Action      string `json:"action"`
+		PluginAppId string `json:"plugin_appid"`
+	}{
+		Action:      "unbind",
+		PluginAppId: pluginAppId,
+	}
+	return clt.postPlugin("https://api.weixin.qq.com/wxa/plugin?access_token=", &request)
+}
+
+// 申请使用插件的小程序, 插件开发者使用
+type PluginApply struct {
+	AppId      string `json:"appid"`      // 使用者的 appid
+	Status     int    `json:"status"`     // 申请状态, PluginStatusApplying 等
+	Nickname   string `json:"nickname"`   // 使用者的昵称
+	HeadImgURL string `json:"headimgurl"` // 使用者的头像
+	Categories []struct {
+		First  string `json:"first"`
+		Second string `json:"second"`
+	} `json:"categories"` // 使用者的类目
+	CreateTime string `json:"create_time"` // 使用者的申请时间
+	AssessURL  string `json:"assess_url"`  // 使用者的小程序码
+	Reason     string `json:"reason"`      // 使用者的申请说明
+}
+
+// 获取当前所有插件使用方(供插件开发者调用).
+//  page: 要拉取第几页的数据, 从 1 开始
+//  num:  每页的记录数
+func (clt *Client) GetPluginDevApplyList(page, num int) (list []PluginApply, err error) {
+	if page < 1 {
+		err = errors.New("page must be positive")
+		return
+	}
+	if num < 1 {
+		err = errors.New("num must be positive")
+		return
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		Page   int    `json:"page"`
+		Num    int    `json:"num"`
+	}{
+		Action: "dev_apply_list",
+		Page:   page,
+		Num:    num,
+	}
+
+	var result struct {
+		mp.Error
+		ApplyList []PluginApply `json:"apply_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/devplugin?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.ApplyList
+	return
+}
+
+// 同意小程序 appId 使用插件的申请(供插件开发者调用).
+func (clt *Client) AgreePluginDevApply(appId string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		AppId  string `json:"appid"`
+	}{
+		Action: "dev_agree",
+		AppId:  appId,
+	}
+	return clt.postPlugin("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+// 拒绝使用插件的申请(供插件开发者调用).
+//  reason: 拒绝理由
+func (clt *Client) RefusePluginDevApply(reason string) (err error) {
+	if reason == "" {
+		return errors.New("empty reason")
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		Reason string `json:"reason"`
+	}{
+		Action: "dev_refuse",
+		Reason: reason,
+	}
+	return clt.postPlugin("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+// 删除已拒绝的申请者(供插件开发者调用).
+func (clt *Client) DeletePluginDevApply(appId string) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		AppId  string `json:"appid"`
+	}{
+		Action: "dev_delete",
+		AppId:  appId,
+	}
+	return clt.postPlugin("https://api.weixin.qq.com/wxa/devplugin?access_token=", &request)
+}
+
+func (clt *Client) postPlugin(incompleteURL string, request interface{}) (err error) {
+	var result mp.Error
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
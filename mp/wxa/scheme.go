@@ -0,0 +1,131 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package wxa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ErrCodeQuotaExceeded = 85078 // 生成 URL Scheme 超过了每天的额度
+	ErrCodeDailyLimit    = 45009 // 调用接口超过了每天的次数限制
+)
+
+// 超过每天额度的错误
+type QuotaError struct {
+	ErrCode int
+	ErrMsg  string
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("wxa quota exceeded, please try again tomorrow (errcode: %d, errmsg: %s)", e.ErrCode, e.ErrMsg)
+}
+
+// 失效的类型
+const (
+	ExpireTypeTime     = 0 // 到达 ExpireTime 失效
+	ExpireTypeInterval = 1 // 经过 ExpireInterval 天后失效
+)
+
+// 要打开的小程序版本
+const (
+	EnvVersionRelease = "release" // 正式版
+	EnvVersionTrial   = "trial"   // 体验版
+	EnvVersionDevelop = "develop" // 开发版
+)
+
+type JumpWxa struct {
+	Path       string `json:"path"`                  // 小程序页面路径, 必须是已经发布的小程序存在的页面, 不可携带 query; path 为空时会跳转小程序主页
+	Query      string `json:"query,omitempty"`       // 进入小程序时带的 query, 最大1024个字符
+	EnvVersion string `json:"env_version,omitempty"` // 要打开的小程序版本, 默认为 EnvVersionRelease
+}
+
+// 生成 URL Scheme 的参数
+type SchemeRequest struct {
+	JumpWxa        *JumpWxa `json:"jump_wxa,omitempty"`        // 跳转到的目标小程序信息
+	IsExpire       bool     `json:"is_expire"`                 // 生成的 scheme 码类型, 到期失效: true, 永久有效: false
+	ExpireType     int      `json:"expire_type"`               // 到期失效的类型, ExpireTypeTime 或者 ExpireTypeInterval
+	ExpireTime     int64    `json:"expire_time,omitempty"`     // 到期失效的 scheme 码的失效时间, 为 Unix 时间戳
+	ExpireInterval int      `json:"expire_interval,omitempty"` // 到期失效的 scheme 码的失效间隔天数, 最多30天
+}
+
+// 获取小程序 scheme 码, 格式为 weixin://dl/business/?t=...
+//
+//  NOTE: scheme 码每天有生成额度限制, 超过额度时返回 *QuotaError.
+func (clt *Client) GenerateScheme(req *SchemeRequest) (openlink string, err error) {
+	if req == nil {
+		err = errors.New("nil SchemeRequest")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Openlink string `json:"openlink"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/generatescheme?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, req, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = checkQuota(&result.Error)
+		return
+	}
+	openlink = result.Openlink
+	return
+}
+
+// 获取小程序 Short Link, 格式为 https://wxaurl.cn/...
+//  pageURL:     通过 Short Link 进入的小程序页面路径, 必须是已经发布的小程序存在的页面, 可携带 query, 最大1024个字符
+//  pageTitle:   页面标题, 不能包含违法信息, 超过20字符会用... 截断代替
+//  isPermanent: 生成的 Short Link 类型, 短期有效: false, 永久有效: true
+//
+//  NOTE: 永久有效的 Short Link 有数量上限, 超过额度时返回 *QuotaError.
+func (clt *Client) GenerateShortLink(pageURL, pageTitle string, isPermanent bool) (link string, err error) {
+	if pageURL == "" {
+		err = errors.New("empty pageURL")
+		return
+	}
+
+	var request = struct {
+		PageURL     string `json:"page_url"`
+		PageTitle   string `json:"page_title,omitempty"`
+		IsPermanent bool   `json:"is_permanent"`
+	}{
+		PageURL:     pageURL,
+		PageTitle:   pageTitle,
+		IsPermanent: isPermanent,
+	}
+
+	var result struct {
+		mp.Error
+		Link string `json:"link"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/genwxashortlink?access_token="
+	if err = ((*mp.Client)(clt)).PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = checkQuota(&result.Error)
+		return
+	}
+	link = result.Link
+	return
+}
+
+func checkQuota(e *mp.Error) error {
+	switch e.ErrCode {
+	case ErrCodeQuotaExceeded, ErrCodeDailyLimit:
+		return &QuotaError{ErrCode: e.ErrCode, ErrMsg: e.ErrMsg}
+	}
+	return e
+}
@@ -0,0 +1,148 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(ioutil.Discard)
+	},
+}
+
+// GzipHandler 包装 handler, 如果请求带有 Accept-Encoding: gzip 并且响应的 body 不小于 minSize 字节,
+// 则对响应的 body 做 gzip 压缩, 并设置 Content-Encoding: gzip 头部.
+//  一般用于在内部代理层和回调服务之间压缩被动回复的 XML, 比如:
+//  http.Handle("/wechat_callback", util.GzipHandler(mp.NewServerFrontend(server, nil, nil), 1024))
+//
+//  NOTE: 为了判断 body 的大小, 在 body 达到 minSize 之前会先缓存起来, 所以 handler 调用 WriteHeader 后
+//  状态码并不会马上发送, 而是等到确定是否压缩后再发送.
+func GzipHandler(handler http.Handler, minSize int) http.Handler {
+	if handler == nil {
+		panic("nil http.Handler")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptGzip(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{
+			ResponseWriter: w,
+			minSize:        minSize,
+		}
+		defer gw.close()
+
+		handler.ServeHTTP(gw, r)
+	})
+}
+
+func acceptGzip(r *http.Request) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.Split(v, ";")[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minSize int
+
+	statusCode int          // handler 设置的状态码, 0 表示没有设置
+	buf        []byte       // 确定是否压缩之前缓存的 body
+	decided    bool         // 是否已经确定了是否压缩, 确定后状态码已经发送
+	gzipWriter *gzip.Writer // 确定压缩后不为 nil
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.decided || w.statusCode != 0 {
+		return
+	}
+	w.statusCode = code
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (n int, err error) {
+	if w.decided {
+		if w.gzipWriter != nil {
+			return w.gzipWriter.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.minSize {
+		return len(p), nil
+	}
+
+	// 如果 handler 自己设置了 Content-Encoding, 则不再压缩
+	if w.Header().Get("Content-Encoding") == "" {
+		if err = w.startGzip(); err != nil {
+			return 0, err
+		}
+	} else if err = w.flushPlain(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *gzipResponseWriter) startGzip() (err error) {
+	w.decided = true
+
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	w.writeStatusCode()
+
+	w.gzipWriter = gzipWriterPool.Get().(*gzip.Writer)
+	w.gzipWriter.Reset(w.ResponseWriter)
+
+	buf := w.buf
+	w.buf = nil
+	_, err = w.gzipWriter.Write(buf)
+	return
+}
+
+func (w *gzipResponseWriter) flushPlain() (err error) {
+	w.decided = true
+	w.writeStatusCode()
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) > 0 {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return
+}
+
+func (w *gzipResponseWriter) writeStatusCode() {
+	if w.statusCode != 0 {
+		w.ResponseWriter.WriteHeader(w.statusCode)
+	}
+}
+
+// body 没有达到 minSize 则不压缩直接输出
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		w.flushPlain()
+		return
+	}
+	if w.gzipWriter != nil {
+		w.gzipWriter.Close()
+		w.gzipWriter.Reset(ioutil.Discard)
+		gzipWriterPool.Put(w.gzipWriter)
+		w.gzipWriter = nil
+	}
+}
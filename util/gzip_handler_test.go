@@ -0,0 +1,99 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func gzipTestHandler(statusCode int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		if statusCode != 0 {
+			w.WriteHeader(statusCode)
+		}
+		// 分两次写入, 覆盖缓存跨越 minSize 的情况
+		half := len(body) / 2
+		w.Write([]byte(body[:half]))
+		w.Write([]byte(body[half:]))
+	})
+}
+
+func TestGzipHandlerCompress(t *testing.T) {
+	body := strings.Repeat("<xml><Content><![CDATA[hello]]></Content></xml>", 100)
+	handler := GzipHandler(gzipTestHandler(http.StatusAccepted, body), 1024)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=1.0")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("状态码错误, have: %d, want: %d", rec.Code, http.StatusAccepted)
+	}
+	if v := rec.Header().Get("Content-Encoding"); v != "gzip" {
+		t.Errorf("Content-Encoding 错误, have: %q, want: gzip", v)
+	}
+	if v := rec.Header().Get("Vary"); v != "Accept-Encoding" {
+		t.Errorf("Vary 错误, have: %q, want: Accept-Encoding", v)
+	}
+
+	gr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(have) != body {
+		t.Errorf("解压后的 body 错误, have: %s, want: %s", have, body)
+	}
+}
+
+func TestGzipHandlerSmallBody(t *testing.T) {
+	body := "<xml></xml>"
+	handler := GzipHandler(gzipTestHandler(http.StatusAccepted, body), 1024)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("状态码错误, have: %d, want: %d", rec.Code, http.StatusAccepted)
+	}
+	if v := rec.Header().Get("Content-Encoding"); v != "" {
+		t.Errorf("Content-Encoding 错误, have: %q, want: \"\"", v)
+	}
+	if rec.Body.String() != body {
+		t.Errorf("body 错误, have: %s, want: %s", rec.Body.String(), body)
+	}
+}
+
+func TestGzipHandlerNotAccepted(t *testing.T) {
+	body := strings.Repeat("a", 2048)
+	handler := GzipHandler(gzipTestHandler(0, body), 1024)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if v := rec.Header().Get("Content-Encoding"); v != "" {
+		t.Errorf("Content-Encoding 错误, have: %q, want: \"\"", v)
+	}
+	if v := rec.Header().Get("Vary"); v != "Accept-Encoding" {
+		t.Errorf("Vary 错误, have: %q, want: Accept-Encoding", v)
+	}
+	if rec.Body.String() != body {
+		t.Errorf("body 错误, have: %d bytes, want: %d bytes", rec.Body.Len(), len(body))
+	}
+}
@@ -0,0 +1,98 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// 加密消息的 XML 信封, 只关心 Encrypt 字段,
+// 可以解析微信服务器推送过来的 http body 和回复给微信服务器的 http body.
+type encryptedEnvelope struct {
+	XMLName      struct{} `xml:"xml"`
+	EncryptedMsg string   `xml:"Encrypt"`
+}
+
+// MessageEncryptor 用于在 http 处理流程之外加密和解密消息, 比如命令行工具, 测试数据, 数据迁移脚本等.
+//  加密方案和公众号, 企业号安全模式的消息加密一样.
+type MessageEncryptor struct {
+	appId  string
+	aesKey [32]byte
+}
+
+// 创建一个新的 MessageEncryptor.
+//  appId:         公众号的 AppId 或者企业号的 CorpId
+//  encodedAESKey: 微信管理后台的 EncodingAESKey, 43 个字符
+func NewMessageEncryptor(appId, encodedAESKey string) (encryptor *MessageEncryptor, err error) {
+	if appId == "" {
+		err = errors.New("empty appId")
+		return
+	}
+	aesKey, err := AESKeyDecode(encodedAESKey)
+	if err != nil {
+		return
+	}
+
+	encryptor = &MessageEncryptor{
+		appId: appId,
+	}
+	copy(encryptor.aesKey[:], aesKey)
+	return
+}
+
+// 加密明文消息 plainXML, 返回 <xml><Encrypt>...</Encrypt></xml> 格式的加密消息.
+//  每次加密都使用新的 16 字节随机数.
+func (encryptor *MessageEncryptor) Encrypt(plainXML string) (encryptedXML string, err error) {
+	random := make([]byte, 16)
+	if _, err = io.ReadFull(rand.Reader, random); err != nil {
+		return
+	}
+
+	ciphertext := AESEncryptMsg(random, []byte(plainXML), encryptor.appId, encryptor.aesKey)
+	envelope := encryptedEnvelope{
+		EncryptedMsg: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	b, err := xml.Marshal(&envelope)
+	if err != nil {
+		return
+	}
+	encryptedXML = string(b)
+	return
+}
+
+// 解密 <xml><Encrypt>...</Encrypt></xml> 格式的加密消息, 返回明文消息.
+//  会校验消息里的 AppId 是否和 encryptor 的一致.
+func (encryptor *MessageEncryptor) Decrypt(encryptedXML string) (plainXML string, err error) {
+	var envelope encryptedEnvelope
+	if err = xml.Unmarshal([]byte(encryptedXML), &envelope); err != nil {
+		return
+	}
+	if envelope.EncryptedMsg == "" {
+		err = errors.New("Encrypt is empty")
+		return
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.EncryptedMsg)
+	if err != nil {
+		return
+	}
+	_, rawXMLMsg, appId, err := AESDecryptMsg(ciphertext, encryptor.aesKey)
+	if err != nil {
+		return
+	}
+	if string(appId) != encryptor.appId {
+		err = fmt.Errorf("the message's AppId mismatch, have: %s, want: %s", appId, encryptor.appId)
+		return
+	}
+	plainXML = string(rawXMLMsg)
+	return
+}
@@ -0,0 +1,84 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageEncryptor(t *testing.T) {
+	encryptor, err := NewMessageEncryptor(testCorpId, testEncodedAESKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plainXML := "<xml><ToUserName><![CDATA[toUser]]></ToUserName><Content><![CDATA[你好]]></Content></xml>"
+
+	encryptedXML, err := encryptor.Encrypt(plainXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encryptedXML, "<xml><Encrypt>") || strings.Contains(encryptedXML, "toUser") {
+		t.Fatalf("unexpected encrypted xml: %s", encryptedXML)
+	}
+
+	encryptedXML2, err := encryptor.Encrypt(plainXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encryptedXML == encryptedXML2 {
+		t.Error("Encrypt should use a new random for each message")
+	}
+
+	have, err := encryptor.Decrypt(encryptedXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != plainXML {
+		t.Errorf("Decrypt:\nhave %q\nwant %q", have, plainXML)
+	}
+}
+
+func TestMessageEncryptorAppIdMismatch(t *testing.T) {
+	encryptor, err := NewMessageEncryptor(testCorpId, testEncodedAESKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewMessageEncryptor("wx0987654321", testEncodedAESKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encryptedXML, err := encryptor.Encrypt("<xml></xml>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Decrypt(encryptedXML); err == nil {
+		t.Error("expected AppId mismatch error")
+	}
+}
+
+func TestNewMessageEncryptorInvalidKey(t *testing.T) {
+	if _, err := NewMessageEncryptor("wx1234567890", "tooshort"); err == nil {
+		t.Error("expected error for invalid encodedAESKey")
+	}
+}
+
+func TestMessageEncryptorDecrypt(t *testing.T) {
+	encryptor, err := NewMessageEncryptor(testCorpId, testEncodedAESKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := encryptor.Decrypt("<xml><Encrypt><![CDATA[" + testEncryptedMsg + "]]></Encrypt></xml>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != testDecryptedMsgID {
+		t.Errorf("Decrypt:\nhave %q\nwant %q", have, testDecryptedMsgID)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"sort"
+
+	"github.com/chanxuehong/util/security"
 )
 
 // 微信公众号 明文模式/URL认证 签名
@@ -26,7 +28,9 @@ func Sign(token, timestamp, nonce string) (signature string) {
 	return hex.EncodeToString(hashsum[:])
 }
 
-// 微信公众号/企业号 密文模式消息签名
+// 微信公众号/企业号 密文模式消息签名.
+//  校验推送过来的消息的 msg_signature 和计算被动回复消息的 MsgSignature 都用这个方法,
+//  签名算法: 将 token, timestamp, nonce, encryptedMsg 字典排序后拼接, 然后做 sha1.
 func MsgSign(token, timestamp, nonce, encryptedMsg string) (signature string) {
 	strs := sort.StringSlice{token, timestamp, nonce, encryptedMsg}
 	strs.Sort()
@@ -41,3 +45,14 @@ func MsgSign(token, timestamp, nonce, encryptedMsg string) (signature string) {
 	hashsum := sha1.Sum(buf)
 	return hex.EncodeToString(hashsum[:])
 }
+
+// 校验公众号 明文模式/URL认证 签名, 用常量时间比较防止时序攻击.
+func CheckSignature(token, signature, timestamp, nonce string) bool {
+	return security.SecureCompareString(signature, Sign(token, timestamp, nonce))
+}
+
+// 校验 密文模式 消息的 msg_signature, 用常量时间比较防止时序攻击.
+//  encryptedMsg 是 http body 里 <Encrypt> 元素的内容(base64 编码的密文).
+func CheckMsgSignature(token, msgSignature, timestamp, nonce, encryptedMsg string) bool {
+	return security.SecureCompareString(msgSignature, MsgSign(token, timestamp, nonce, encryptedMsg))
+}
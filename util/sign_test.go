@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+// 微信官方文档(企业号回调模式)给出的测试数据
+const (
+	testToken          = "QDG6eK"
+	testCorpId         = "wx5823bf96d3bd56c7"
+	testEncodedAESKey  = "jWmYm7qr5nMoAUwZRjGtBxmz3KA1tkAj3ykkR6q2B2C"
+	testTimestamp      = "1409659589"
+	testNonce          = "263014780"
+	testEncryptedMsg   = "P9nAzCzyDtyTWESHep1vC5X9xho/qYX3Zpb4yKa9SKld1DsH3Iyt3tP3zNdtp+4RPcs8TgAE7OaBO+FZXvnaqQ=="
+	testMsgSignature   = "5c45ff5e21c57e6ad56bac8758b79b1d9ac89fd3"
+	testDecryptedMsgID = "1616140317555161061"
+)
+
+func TestMsgSign(t *testing.T) {
+	signature := MsgSign(testToken, testTimestamp, testNonce, testEncryptedMsg)
+	if signature != testMsgSignature {
+		t.Errorf("MsgSign 签名错误, have: %s, want: %s", signature, testMsgSignature)
+		return
+	}
+
+	// 参数顺序不影响签名结果
+	signature = MsgSign(testEncryptedMsg, testNonce, testToken, testTimestamp)
+	if signature != testMsgSignature {
+		t.Errorf("MsgSign 签名错误, have: %s, want: %s", signature, testMsgSignature)
+		return
+	}
+
+	if !CheckMsgSignature(testToken, testMsgSignature, testTimestamp, testNonce, testEncryptedMsg) {
+		t.Error("CheckMsgSignature 校验失败")
+	}
+	if CheckMsgSignature(testToken, testMsgSignature, testTimestamp, testNonce, testEncryptedMsg+"x") {
+		t.Error("CheckMsgSignature 密文被修改以后仍然校验成功")
+	}
+}
+
+func TestAESEncryptDecryptMsg(t *testing.T) {
+	key, err := AESKeyDecode(testEncodedAESKey)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var aesKey [32]byte
+	copy(aesKey[:], key)
+
+	ciphertext, err := base64.StdEncoding.DecodeString(testEncryptedMsg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	random, rawXMLMsg, appId, err := AESDecryptMsg(ciphertext, aesKey)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(rawXMLMsg) != testDecryptedMsgID {
+		t.Errorf("AESDecryptMsg 解密错误, have: %s, want: %s", rawXMLMsg, testDecryptedMsgID)
+		return
+	}
+	if string(appId) != testCorpId {
+		t.Errorf("AESDecryptMsg 解密错误, have appid: %s, want: %s", appId, testCorpId)
+		return
+	}
+
+	// 用同样的 random 加密, 结果应该和密文一致
+	ciphertext2 := AESEncryptMsg(random, rawXMLMsg, string(appId), aesKey)
+	if !bytes.Equal(ciphertext, ciphertext2) {
+		t.Errorf("AESEncryptMsg 加密错误, have: %s, want: %s",
+			base64.StdEncoding.EncodeToString(ciphertext2), testEncryptedMsg)
+		return
+	}
+
+	// 回复消息的签名是对加密后的消息签名
+	signature := MsgSign(testToken, testTimestamp, testNonce, base64.StdEncoding.EncodeToString(ciphertext2))
+	if signature != testMsgSignature {
+		t.Errorf("MsgSign 签名错误, have: %s, want: %s", signature, testMsgSignature)
+		return
+	}
+}
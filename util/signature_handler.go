@@ -0,0 +1,49 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"net/http"
+)
+
+// SignatureMiddleware 返回一个校验公众号回调 URL 签名(signature, timestamp, nonce)的 net/http 中间件,
+// 校验通过后交给 next 处理, 否则返回 403.
+//  用于不使用 mp.ServerFrontend, 而是用其他 http 框架自己处理回调消息的场景, 比如:
+//  http.Handle("/wechat_callback", util.SignatureMiddleware(token)(myHandler))
+//
+//  NOTE: 只校验 URL 上的 signature, 安全模式下消息体的 msg_signature 需要 next 自己校验.
+func SignatureMiddleware(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			panic("nil http.Handler")
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queryValues := r.URL.Query()
+
+			signature := queryValues.Get("signature")
+			if signature == "" {
+				http.Error(w, "signature is empty", http.StatusForbidden)
+				return
+			}
+			timestamp := queryValues.Get("timestamp")
+			if timestamp == "" {
+				http.Error(w, "timestamp is empty", http.StatusForbidden)
+				return
+			}
+			nonce := queryValues.Get("nonce")
+			if nonce == "" {
+				http.Error(w, "nonce is empty", http.StatusForbidden)
+				return
+			}
+
+			if !CheckSignature(token, signature, timestamp, nonce) {
+				http.Error(w, "check signature failed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"fmt"
+)
+
+// 不需要编码的字符: A-Z a-z 0-9 - _ . ! ~ * ' ( )
+func wxShouldEscape(c byte) bool {
+	switch {
+	case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+		return false
+	}
+	switch c {
+	case '-', '_', '.', '!', '~', '*', '\'', '(', ')':
+		return false
+	}
+	return true
+}
+
+// 按照微信的规则对 s 做百分号编码, 和 javascript 的 encodeURIComponent 一致.
+//  除了 A-Z a-z 0-9 - _ . ! ~ * ' ( ) 之外的字节都编码成 %XX(大写十六进制), 空格编码成 %20.
+//
+//  NOTE: 和标准库的区别
+//  1. url.QueryEscape 会编码 ! * ' ( ), 并且把空格编码成 +;
+//  2. url.PathEscape 不会编码 $ & + , / : ; = @ 等字符.
+//  微信的二维码扫码结果, 部分接口返回的 url 等都是按照这个规则编码的,
+//  需要和这些数据做比较或者签名的时候应该使用 WXEncode/WXDecode.
+func WXEncode(s string) string {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if wxShouldEscape(s[i]) {
+			n++
+		}
+	}
+	if n == 0 {
+		return s
+	}
+
+	const upperhex = "0123456789ABCDEF"
+
+	t := make([]byte, len(s)+2*n)
+	j := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if wxShouldEscape(c) {
+			t[j] = '%'
+			t[j+1] = upperhex[c>>4]
+			t[j+2] = upperhex[c&15]
+			j += 3
+		} else {
+			t[j] = c
+			j++
+		}
+	}
+	return string(t)
+}
+
+func unhex(c byte) (b byte, ok bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// WXEncode 的逆运算.
+//  %XX 的十六进制不区分大小写; 和 url.QueryUnescape 不同, + 不会被解码成空格.
+func WXDecode(s string) (string, error) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' {
+			n++
+		}
+	}
+	if n == 0 {
+		return s, nil
+	}
+
+	t := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '%' {
+			t = append(t, c)
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid escape %q at offset %d", s[i:], i)
+		}
+		hi, ok1 := unhex(s[i+1])
+		lo, ok2 := unhex(s[i+2])
+		if !ok1 || !ok2 {
+			return "", fmt.Errorf("invalid escape %q at offset %d", s[i:i+3], i)
+		}
+		t = append(t, hi<<4|lo)
+		i += 2
+	}
+	return string(t), nil
+}
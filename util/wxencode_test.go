@@ -0,0 +1,69 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWXEncodeAllBytes(t *testing.T) {
+	const unescaped = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_.!~*'()"
+
+	for c := 0; c <= 0xFF; c++ {
+		s := string([]byte{byte(c)})
+
+		want := fmt.Sprintf("%%%02X", c)
+		if strings.IndexByte(unescaped, byte(c)) >= 0 {
+			want = s
+		}
+
+		if have := WXEncode(s); have != want {
+			t.Errorf("WXEncode(0x%02X):\nhave %q\nwant %q", c, have, want)
+			continue
+		}
+		if have, err := WXDecode(want); err != nil || have != s {
+			t.Errorf("WXDecode(%q):\nhave %q, %v\nwant %q, <nil>", want, have, err, s)
+		}
+		if lower := strings.ToLower(want); want != s {
+			if have, err := WXDecode(lower); err != nil || have != s {
+				t.Errorf("WXDecode(%q):\nhave %q, %v\nwant %q, <nil>", lower, have, err, s)
+			}
+		}
+	}
+}
+
+func TestWXEncode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"a b", "a%20b"},
+		{"a+b", "a%2Bb"},
+		{"http://weixin.qq.com/q/abc?x=1&y=2", "http%3A%2F%2Fweixin.qq.com%2Fq%2Fabc%3Fx%3D1%26y%3D2"},
+		{"(hello)!*'~", "(hello)!*'~"},
+		{"微信", "%E5%BE%AE%E4%BF%A1"},
+	}
+
+	for _, tt := range tests {
+		if have := WXEncode(tt.in); have != tt.want {
+			t.Errorf("WXEncode(%q):\nhave %q\nwant %q", tt.in, have, tt.want)
+		}
+		if have, err := WXDecode(tt.want); err != nil || have != tt.in {
+			t.Errorf("WXDecode(%q):\nhave %q, %v\nwant %q, <nil>", tt.want, have, err, tt.in)
+		}
+	}
+}
+
+func TestWXDecode(t *testing.T) {
+	if have, err := WXDecode("a+b"); err != nil || have != "a+b" {
+		t.Errorf("WXDecode(%q):\nhave %q, %v\nwant %q, <nil>", "a+b", have, err, "a+b")
+	}
+
+	for _, s := range []string{"%", "%2", "a%2", "%zz", "%2g", "abc%"} {
+		if _, err := WXDecode(s); err == nil {
+			t.Errorf("WXDecode(%q): expected error", s)
+		}
+	}
+}